@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Attachments: listed in their own detail section, downloaded into the
+// configured download_dir (s) and optionally opened from there (enter),
+// and uploaded from a file picker (A).
+
+const (
+	attachmentUploadModalWScale = 0.5
+	attachmentUploadModalHScale = 0.6
+	// attachmentLines is how many lines one attachment takes in the section.
+	attachmentLines = 4
+)
+
+type attachmentDownloadedMsg struct {
+	path string
+	open bool
+}
+
+type attachmentUploadedMsg struct {
+	issueKey    string
+	attachments []jira.Attachment
+}
+
+// AttachmentUploadFormData picks the local file to attach.
+type AttachmentUploadFormData struct {
+	Path string
+	Form *huh.Form
+}
+
+func NewAttachmentUploadFormData(startDir string, height int) *AttachmentUploadFormData {
+	d := &AttachmentUploadFormData{}
+	d.Form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewFilePicker().
+				Title("Attach file").
+				Description("enter opens a directory or picks a file · h goes up").
+				CurrentDirectory(startDir).
+				FileAllowed(true).
+				DirAllowed(false).
+				Height(height).
+				Picking(true).
+				Value(&d.Path),
+		),
+	)
+	return d
+}
+
+// formatBytes renders n as a short human size: 512 B, 1.5 KB, 12 MB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	value := float64(n) / float64(div)
+	suffix := []string{"KB", "MB", "GB", "TB"}[exp]
+	if value >= 10 {
+		return fmt.Sprintf("%.0f %s", value, suffix)
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// createDownloadFile creates the file filename is saved to in dir without
+// overwriting an existing one: "report.pdf", then "report (1).pdf" and so on.
+// O_EXCL makes the check and the creation one step, so a file that appears
+// in between is never truncated.
+func createDownloadFile(dir, filename string) (*os.File, error) {
+	name := filepath.Base(filepath.Clean("/" + filename))
+	if name == "/" || name == "." {
+		name = "attachment"
+	}
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+
+	path := filepath.Join(dir, name)
+	for i := 1; ; i++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if !errors.Is(err, os.ErrExist) {
+			return f, err
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", stem, i, ext))
+	}
+}
+
+func (m model) buildAttachmentsContent(width int) string {
+	var content strings.Builder
+	if m.activeIssue == nil {
+		return ""
+	}
+
+	count := len(m.activeIssue.Attachments)
+	for i, a := range m.activeIssue.Attachments {
+		content.WriteString(m.renderAttachment(a, width, m.attachmentsCursor == i, i == count-1))
+	}
+
+	return content.String()
+}
+
+func (m model) renderAttachment(a jira.Attachment, width int, isSelected bool, isLast bool) string {
+	var content strings.Builder
+
+	line1 := a.Filename
+	line2 := ui.TruncateLongString(ui.DimTextStyle.Render(formatBytes(a.Size)+" • "+a.Author+" • "+timeAgo(a.Created)), width)
+
+	if isSelected {
+		content.WriteString(ui.IconCursor + ui.SelectedRowStyle.MaxWidth(width-4).Render(line1) + "\n")
+	} else {
+		content.WriteString(ui.NormalRowStyle.MaxWidth(width-4).Render(line1) + "\n")
+	}
+	content.WriteString(line2 + "\n")
+
+	if !isLast {
+		content.WriteString(ui.SeparatorStyle.Render("  ────") + "\n\n")
+	} else {
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+func (m model) renderAttachmentsPanel(width int, height int) string {
+	viewport := m.attachmentsViewport.View()
+	return ui.RenderPanelWithLabel("Attachments", viewport, width, height, m.focusedSection == attachmentsSection)
+}
+
+// setAttachmentsContent sizes the attachments viewport for the current layout
+// and fills it.
+func (m *model) setAttachmentsContent() {
+	m.attachmentsViewport.SetWidth(m.detailLayout.rightColumnWidth)
+	m.attachmentsViewport.SetHeight(m.detailLayout.attachmentsHeight)
+	m.attachmentsViewport.SetContent(m.buildAttachmentsContent(m.detailLayout.rightColumnWidth - ui.PanelOverheadWidth))
+}
+
+// moveAttachmentsCursor moves the cursor by delta within the issue's
+// attachments and scrolls it into view.
+func (m model) moveAttachmentsCursor(delta int) model {
+	last := len(m.activeIssue.Attachments) - 1
+	m.attachmentsCursor = max(0, min(m.attachmentsCursor+delta, last))
+	m.attachmentsViewport.SetYOffset(m.attachmentsCursor * attachmentLines)
+	m.setAttachmentsContent()
+	return m
+}
+
+// selectedAttachment is the attachment under the cursor, if any.
+func (m model) selectedAttachment() (jira.Attachment, bool) {
+	if m.activeIssue == nil || m.attachmentsCursor < 0 || m.attachmentsCursor >= len(m.activeIssue.Attachments) {
+		return jira.Attachment{}, false
+	}
+	return m.activeIssue.Attachments[m.attachmentsCursor], true
+}
+
+// downloadAttachment saves the selected attachment, opening it afterwards
+// when open is set.
+func (m model) downloadAttachment(open bool) (tea.Model, tea.Cmd) {
+	a, ok := m.selectedAttachment()
+	if !ok {
+		return m, nil
+	}
+	if m.downloadDir == "" {
+		m.setErrorMsg("No download directory configured (download_dir)")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+
+	m.loadingCount++
+	m.setInfo("Downloading " + a.Filename + "...")
+	return m, m.downloadAttachmentCmd(a, m.downloadDir, open)
+}
+
+func (m model) downloadAttachmentCmd(a jira.Attachment, dir string, open bool) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return errMsg{err}
+		}
+		f, err := createDownloadFile(dir, a.Filename)
+		if err != nil {
+			return errMsg{err}
+		}
+		path := f.Name()
+
+		_, err = m.client.DownloadAttachment(context.Background(), a, f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			// Don't leave a truncated file behind.
+			_ = os.Remove(path)
+			return errMsg{err}
+		}
+
+		return attachmentDownloadedMsg{path: path, open: open}
+	}
+}
+
+func (m model) handleAttachmentDownloaded(msg attachmentDownloadedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.setSuccess("Saved " + msg.path)
+	cmds := []tea.Cmd{m.clearStatusAfter(clearMsgTimeout)}
+	if msg.open {
+		// browserCommand (xdg-open) hands local files to their default app too.
+		cmds = append(cmds, openInBrowserCmd(msg.path))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// openAttachmentUpload opens the file picker in the working directory.
+func (m model) openAttachmentUpload() (tea.Model, tea.Cmd) {
+	if m.activeIssue == nil {
+		return m, nil
+	}
+	startDir, err := os.Getwd()
+	if err != nil {
+		startDir, _ = os.UserHomeDir()
+	}
+
+	height := ui.GetModalHeight(m.windowHeight, attachmentUploadModalHScale) - ui.PanelOverheadHeight - 3
+	m.attachmentUploadData = NewAttachmentUploadFormData(startDir, max(height, 5))
+	m.pendingIssue = m.activeIssue
+	m.previousMode = m.mode
+	m.mode = attachmentUploadView
+	return m, m.attachmentUploadData.Form.Init()
+}
+
+func (m model) updateAttachmentUploadView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyPressMsg, ok := msg.(tea.KeyPressMsg); ok && keyPressMsg.String() == "esc" {
+		m.mode = m.previousMode
+		m.attachmentUploadData = nil
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	form, cmd := m.attachmentUploadData.Form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.attachmentUploadData.Form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.attachmentUploadData.Form.State == huh.StateCompleted {
+		path := m.attachmentUploadData.Path
+		m.attachmentUploadData = nil
+		m.mode = m.previousMode
+		if m.pendingIssue == nil || path == "" {
+			return m, tea.Batch(cmds...)
+		}
+
+		m.loadingCount++
+		m.setInfo("Uploading " + filepath.Base(path) + "...")
+		cmds = append(cmds, m.uploadAttachmentCmd(m.pendingIssue.Key, path))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) renderAttachmentUploadView() string {
+	label := "Attach file"
+	if m.pendingIssue != nil {
+		label += " to " + m.pendingIssue.Key
+	}
+	return m.renderModal(label, m.attachmentUploadData.Form.View(), attachmentUploadModalWScale, attachmentUploadModalHScale)
+}
+
+func (m model) uploadAttachmentCmd(issueKey, path string) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return errMsg{err}
+		}
+		defer func() { _ = f.Close() }()
+
+		attachments, err := m.client.UploadAttachment(context.Background(), issueKey, filepath.Base(path), f)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return attachmentUploadedMsg{issueKey: issueKey, attachments: attachments}
+	}
+}
+
+func (m model) handleAttachmentUploaded(msg attachmentUploadedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	if m.activeIssue != nil && m.activeIssue.Key == msg.issueKey {
+		m.activeIssue.Attachments = append(m.activeIssue.Attachments, msg.attachments...)
+		m.setAttachmentsContent()
+	}
+
+	name := "file"
+	if len(msg.attachments) == 1 {
+		name = msg.attachments[0].Filename
+	}
+	m.setSuccess(fmt.Sprintf("Attached %s to %s", name, msg.issueKey))
+	return m, m.clearStatusAfter(clearMsgTimeout)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1536, "1.5 KB"},
+		{12 * 1024 * 1024, "12 MB"},
+		{3 << 30, "3.0 GB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCreateDownloadFile(t *testing.T) {
+	dir := t.TempDir()
+	create := func(name string) string {
+		t.Helper()
+		f, err := createDownloadFile(dir, name)
+		if err != nil {
+			t.Fatalf("createDownloadFile(%q): %v", name, err)
+		}
+		_ = f.Close()
+		return f.Name()
+	}
+
+	if got := create("report.pdf"); got != filepath.Join(dir, "report.pdf") {
+		t.Errorf("path = %q", got)
+	}
+	_ = os.WriteFile(filepath.Join(dir, "report.pdf"), []byte("keep"), 0o600)
+	if got := create("report.pdf"); got != filepath.Join(dir, "report (1).pdf") {
+		t.Errorf("an existing file should not be overwritten, got %q", got)
+	}
+	if b, _ := os.ReadFile(filepath.Join(dir, "report.pdf")); string(b) != "keep" {
+		t.Errorf("the existing file was truncated to %q", b)
+	}
+	if got := create("../../etc/passwd"); got != filepath.Join(dir, "passwd") {
+		t.Errorf("filenames must stay inside dir, got %q", got)
+	}
+}
+
+func TestDownloadAttachmentCmd(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/attachment/content/10", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, _ := jira.NewClient(srv.URL, "user@example.com", "token", "", "")
+	m := model{client: client}
+	dir := filepath.Join(t.TempDir(), "downloads")
+
+	msg, ok := m.downloadAttachmentCmd(jira.Attachment{ID: "10", Filename: "log.txt"}, dir, true)().(attachmentDownloadedMsg)
+	if !ok || !msg.open {
+		t.Fatalf("expected attachmentDownloadedMsg, got %+v", msg)
+	}
+	if content, _ := os.ReadFile(msg.path); string(content) != "hello" {
+		t.Errorf("saved %q", content)
+	}
+
+	if _, ok := m.downloadAttachmentCmd(jira.Attachment{ID: "11", Filename: "gone.txt"}, dir, false)().(errMsg); !ok {
+		t.Error("a failed download should report an error")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "gone.txt")); !os.IsNotExist(err) {
+		t.Error("a failed download should not leave a file behind")
+	}
+}
+
+func TestAttachmentsSection(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.activeIssue = &jira.Issue{Key: "DEV-1", Attachments: []jira.Attachment{
+		{ID: "1", Filename: "a.png", Size: 2048, Author: "Jane"},
+		{ID: "2", Filename: "b.log", Size: 10, Author: "Ana"},
+	}}
+	m.mode = detailView
+	m.focusedSection = attachmentsSection
+	m.detailLayout = m.calculateDetailLayout()
+	m.setAttachmentsContent()
+
+	next, _ := m.Update(keyPress("j"))
+	m = next.(model)
+	if m.attachmentsCursor != 1 {
+		t.Errorf("cursor = %d, want 1", m.attachmentsCursor)
+	}
+	next, _ = m.Update(keyPress("j"))
+	if next.(model).attachmentsCursor != 1 {
+		t.Error("cursor should stop at the last attachment")
+	}
+
+	// "y k" yanks the key rather than moving the cursor.
+	next, _ = m.Update(keyPress("y"))
+	next, _ = next.(model).Update(keyPress("k"))
+	if nm := next.(model); nm.attachmentsCursor != 1 || nm.lastKey != "" {
+		t.Errorf("cursor = %d, lastKey = %q after y k", nm.attachmentsCursor, nm.lastKey)
+	}
+
+	content := ansi.Strip(m.buildAttachmentsContent(60))
+	for _, want := range []string{"a.png", "2.0 KB", "Jane", "b.log"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("attachments content missing %q:\n%s", want, content)
+		}
+	}
+
+	m.downloadDir = ""
+	next, cmd := m.Update(keyPress("s"))
+	if nm := next.(model); nm.statusMessage.msgType != errStatusBarMsg || nm.loadingCount != 0 || cmd == nil {
+		t.Errorf("no download dir should be an error, got %q", nm.statusMessage.content)
+	}
+}
+
+func TestAttachmentUploadedAppends(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.activeIssue = &jira.Issue{Key: "DEV-1"}
+	m.loadingCount = 1
+
+	next, _ := m.Update(attachmentUploadedMsg{issueKey: "DEV-1", attachments: []jira.Attachment{{ID: "5", Filename: "notes.md"}}})
+	nm := next.(model)
+	if len(nm.activeIssue.Attachments) != 1 || nm.loadingCount != 0 {
+		t.Errorf("attachments = %+v, loadingCount %d", nm.activeIssue.Attachments, nm.loadingCount)
+	}
+	if !strings.Contains(nm.statusMessage.content, "notes.md") {
+		t.Errorf("status = %q", nm.statusMessage.content)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"log/slog"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/cache"
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+// restoreCache fills the model from the on-disk cache before the first frame so
+// the initial board renders immediately. Kinds that come back fresh are
+// recorded in cacheFresh and skipped by startupFetchCmds; stale ones are shown
+// until the network copy replaces them.
+func (m *model) restoreCache() {
+	m.cacheFresh = make(map[cache.Kind]bool)
+	if m.cache == nil {
+		return
+	}
+
+	load := func(kind cache.Kind, key string, v any) bool {
+		fresh, err := m.cache.Load(kind, key, v)
+		if err != nil {
+			return false
+		}
+		m.cacheFresh[kind] = fresh
+		return true
+	}
+
+	var me jira.User
+	if load(cache.Myself, "", &me) {
+		m.myself = &me
+	}
+	load(cache.Projects, "", &m.projects)
+	load(cache.Priorities, "", &m.priorities)
+	load(cache.IssueTypes, "", &m.issueTypes)
+	load(cache.Users, "", &m.usersCache)
+	load(cache.Statuses, "", &m.statuses)
+	if len(m.customFieldConfig) > 0 && load(cache.Fields, "", &m.fields) {
+		m.resolveCustomFields()
+	}
+
+	if load(cache.Issues, m.activeBoardJQL(), &m.issues) {
+		m.activeProjects = activeProjectsFor(m.issues, m.projects)
+		m.sections = m.sectionsFor(m.issues)
+		if si, ok := m.currentIssue(); ok {
+			m.selectedIssue = si
+		}
+	}
+}
+
+// startupFetchCmds returns the requests Init issues: everything the cache
+// could not supply fresh. When the board itself is fresh, its statuses and
+// worklog totals (normally chained off issuesLoadedMsg) are fetched directly.
+func (m model) startupFetchCmds() []tea.Cmd {
+	var cmds []tea.Cmd
+	if !m.cacheFresh[cache.Myself] {
+		cmds = append(cmds, m.fetchMySelfCmd())
+	}
+	if !m.cacheFresh[cache.Projects] {
+		cmds = append(cmds, m.fetchProjectsCmd())
+	}
+	if !m.cacheFresh[cache.Issues] {
+		cmds = append(cmds, m.fetchMyIssuesCmd())
+	} else {
+		// Without projects, statuses wait for projectsLoadedMsg.
+		if len(m.projects) > 0 && !m.cacheFresh[cache.Statuses] {
+			cmds = append(cmds, m.fetchStatusesCmd(m.activeProjects, m.activeTabID()))
+		}
+		cmds = append(cmds, m.fetchAllWorklogsTotalCmd(m.issues, m.activeTabID()))
+	}
+	if !m.cacheFresh[cache.Priorities] {
+		cmds = append(cmds, m.fetchPrioritiesCmd())
+	}
+	if !m.cacheFresh[cache.Users] {
+		cmds = append(cmds, m.fetchAllUsersCmd())
+	}
+	if !m.cacheFresh[cache.IssueTypes] {
+		cmds = append(cmds, m.fetchIssueTypesCmd())
+	}
+	if len(m.customFieldConfig) > 0 && !m.cacheFresh[cache.Fields] {
+		cmds = append(cmds, m.fetchFieldsCmd())
+	}
+	return cmds
+}
+
+// cachedBoardIssues returns the last-known issues for a board, or nil.
+func (m model) cachedBoardIssues(jql string) []jira.Issue {
+	var issues []jira.Issue
+	if _, err := m.cache.Load(cache.Issues, jql, &issues); err != nil {
+		return nil
+	}
+	return issues
+}
+
+// saveCacheCmd writes v to the cache off the update loop. Failures only cost
+// a slower next launch, so they are logged rather than surfaced.
+func (m model) saveCacheCmd(kind cache.Kind, key string, v any) tea.Cmd {
+	if m.cache == nil {
+		return nil
+	}
+	store := m.cache
+	return func() tea.Msg {
+		if err := store.Save(kind, key, v); err != nil {
+			slog.Warn("saving cache", "kind", kind, "err", err)
+		}
+		return nil
+	}
+}
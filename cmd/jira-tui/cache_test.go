@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/oliverjhernandez/jira-tui/internal/cache"
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func newCacheModel(store *cache.Store) model {
+	m := newTabModel([]Tab{{id: 0, title: "My Issues", kind: tabMyIssues, board: boardState{jql: myIssuesJQL}}}, 0)
+	m.cache = store
+	m.statuses = nil
+	return m
+}
+
+func TestRestoreCacheWithoutStoreFetchesEverything(t *testing.T) {
+	m := newCacheModel(nil)
+	m.restoreCache()
+
+	if len(m.issues) != 0 || m.myself != nil {
+		t.Errorf("nothing should be restored without a store")
+	}
+	if got := len(m.startupFetchCmds()); got != 6 {
+		t.Errorf("startupFetchCmds = %d, want 6", got)
+	}
+}
+
+func TestRestoreCacheRendersLastKnownBoard(t *testing.T) {
+	store := cache.New(t.TempDir())
+	projects := []jira.Project{{ID: "P", Key: "DEV"}}
+	issues := []jira.Issue{
+		{Key: "DEV-1", Status: "In Progress", Project: projects[0]},
+		{Key: "DEV-2", Status: "In Progress", Project: projects[0]},
+	}
+	statuses := map[string][]jira.Status{
+		"P": {{Name: "In Progress", StatusCategory: jira.StatusCategory{Key: "indeterminate"}}},
+	}
+	for kind, v := range map[cache.Kind]any{
+		cache.Myself:     jira.User{ID: "me", Name: "Me"},
+		cache.Projects:   projects,
+		cache.Priorities: []jira.Priority{{ID: "1", Name: "High"}},
+		cache.IssueTypes: []jira.IssueType{{ID: "1", Name: "Task"}},
+		cache.Users:      []jira.User{{ID: "u1", Name: "Ana"}},
+		cache.Statuses:   statuses,
+	} {
+		if err := store.Save(kind, "", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Save(cache.Issues, myIssuesJQL, issues); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newCacheModel(store)
+	m.restoreCache()
+
+	if m.myself == nil || m.myself.ID != "me" {
+		t.Errorf("myself = %+v", m.myself)
+	}
+	if len(m.issues) != 2 || len(m.activeProjects) != 1 {
+		t.Fatalf("issues = %d, activeProjects = %d", len(m.issues), len(m.activeProjects))
+	}
+	if m.selectedIssue == nil || m.selectedIssue.Key != "DEV-1" {
+		t.Errorf("selectedIssue = %+v, want DEV-1", m.selectedIssue)
+	}
+
+	// Everything is fresh: only the board's worklog totals are still fetched.
+	if got := len(m.startupFetchCmds()); got != 1 {
+		t.Errorf("startupFetchCmds = %d, want 1", got)
+	}
+
+	// Without projects the worklog totals are still fetched, next to them.
+	m.projects = nil
+	m.cacheFresh[cache.Projects] = false
+	if got := len(m.startupFetchCmds()); got != 2 {
+		t.Errorf("startupFetchCmds = %d, want projects and worklog totals", got)
+	}
+}
+
+func TestCachedBoardIssuesPerJQL(t *testing.T) {
+	store := cache.New(t.TempDir())
+	if err := store.Save(cache.Issues, "project = DEV", []jira.Issue{{Key: "DEV-1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newCacheModel(store)
+	if got := m.cachedBoardIssues("project = DEV"); len(got) != 1 {
+		t.Errorf("cached board = %+v", got)
+	}
+	if got := m.cachedBoardIssues("project = OPS"); got != nil {
+		t.Errorf("unknown board = %+v, want nil", got)
+	}
+}
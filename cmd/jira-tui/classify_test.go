@@ -3,6 +3,7 @@ package main
 import (
 	"testing"
 
+	"github.com/oliverjhernandez/jira-tui/internal/config"
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
 )
 
@@ -32,7 +33,7 @@ func TestClassifyIssues(t *testing.T) {
 		{Key: "DEV-5", Status: "Totally Unknown", Project: jira.Project{ID: "10"}}, // unclassified
 	}
 
-	m := &model{}
+	m := &model{workflow: config.Workflow{InTransitStatuses: []string{"ready to deploy"}}}
 	sections := m.classifyIssues(issues, statuses)
 
 	checks := map[string]int{
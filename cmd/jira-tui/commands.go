@@ -11,18 +11,21 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/outbox"
 	"github.com/oliverjhernandez/jira-tui/internal/ui"
 )
 
 type detailLayout struct {
-	leftColumnWidth  int
-	rightColumnWidth int
-	metadataHeight   int
-	descHeight       int
-	commentsHeight   int
-	worklogsHeight   int
-	issueLinksHeight int
-	subTasksHeight   int
+	leftColumnWidth   int
+	rightColumnWidth  int
+	metadataHeight    int
+	descHeight        int
+	commentsHeight    int
+	historyHeight     int
+	worklogsHeight    int
+	issueLinksHeight  int
+	subTasksHeight    int
+	attachmentsHeight int
 }
 
 type listLayout struct {
@@ -36,6 +39,9 @@ type listLayout struct {
 type issuesLoadedMsg struct {
 	issues []jira.Issue
 	tabID  int
+	// fetchedAt is when the request started; it seeds the board's next
+	// incremental poll.
+	fetchedAt time.Time
 }
 
 type subTasksLoadedMsg struct {
@@ -97,6 +103,8 @@ type newIssuePostedMsg struct{}
 
 type issueLinkPostedMsg struct{}
 
+type issueLinkDeletedMsg struct{}
+
 type updatedDescriptionMsg struct{}
 
 type updatedSummaryMsg struct{}
@@ -125,6 +133,12 @@ type myIssuesPollMsg struct{}
 
 type issueDetailPollMsg struct{}
 
+// retryNoticeMsg is sent from the jira client's retry transport, outside the
+// Update loop, whenever a request is about to be retried.
+type retryNoticeMsg struct {
+	notice jira.RetryNotice
+}
+
 type errMsg struct {
 	err error
 }
@@ -313,7 +327,13 @@ func (m model) postTransitionCmd(issueKey, transitionID, worklogTime string) tea
 		}
 
 		if err := m.client.PostTransition(context.Background(), issueKey, transitionID, nil, "", worklogTime); err != nil {
-			return errMsg{err}
+			return m.queueOrFail(outbox.Op{
+				Kind:           outbox.Transition,
+				IssueKey:       issueKey,
+				TransitionID:   transitionID,
+				TransitionName: m.pendingTransitionName(),
+				WorklogTime:    worklogTime,
+			}, err)
 		}
 
 		return transitionPostedMsg{}
@@ -341,7 +361,14 @@ func (m model) postBlockedTransitionCmd(issueKey, transitionID, reason string) t
 
 		err := m.client.PostTransition(context.Background(), issueKey, transitionID, fields, "", "")
 		if err != nil {
-			return errMsg{err}
+			return m.queueOrFail(outbox.Op{
+				Kind:           outbox.Transition,
+				IssueKey:       issueKey,
+				TransitionID:   transitionID,
+				TransitionName: m.pendingTransitionName(),
+				Fields:         fields,
+				Description:    reason,
+			}, err)
 		}
 
 		return transitionPostedMsg{}
@@ -358,7 +385,13 @@ func (m model) postTransitionWithReasonCmd(issueKey, transitionID, reason string
 
 		err := m.client.PostTransition(context.Background(), issueKey, transitionID, nil, comment, "")
 		if err != nil {
-			return errMsg{err}
+			return m.queueOrFail(outbox.Op{
+				Kind:           outbox.Transition,
+				IssueKey:       issueKey,
+				TransitionID:   transitionID,
+				TransitionName: m.pendingTransitionName(),
+				Body:           comment,
+			}, err)
 		}
 
 		return transitionPostedMsg{}
@@ -386,7 +419,7 @@ func (m model) updateDescriptionCmd(issueKey, description string) tea.Cmd {
 			return errMsg{fmt.Errorf("jira client not initialized")}
 		}
 
-		err := m.client.UpdateDescription(context.Background(), issueKey, description)
+		err := m.client.UpdateDescription(context.Background(), issueKey, description, m.usersCache)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -439,12 +472,13 @@ func (m model) fetchBoardIssuesCmd(jql string, tabID int) tea.Cmd {
 			return errMsg{fmt.Errorf("jira client not initialized")}
 		}
 
+		fetchedAt := time.Now()
 		issues, err := m.client.SearchIssuesJql(context.Background(), jql)
 		if err != nil {
 			return errMsg{err}
 		}
 
-		return issuesLoadedMsg{issues: issues, tabID: tabID}
+		return issuesLoadedMsg{issues: issues, tabID: tabID, fetchedAt: fetchedAt}
 	}
 }
 
@@ -506,7 +540,7 @@ func (m model) postCommentCmd(issueKey, comment string) tea.Cmd {
 
 		err := m.client.PostComment(context.Background(), issueKey, comment, m.usersCache)
 		if err != nil {
-			return errMsg{err}
+			return m.queueOrFail(outbox.Op{Kind: outbox.Comment, IssueKey: issueKey, Body: comment}, err)
 		}
 
 		return commentPostedMsg{}
@@ -620,9 +654,11 @@ func (m model) fetchAllWorklogsTotalCmd(issues []jira.Issue, tabID int) tea.Cmd
 		totals := make(map[string]int)
 		for range issues {
 			r := <-results
-			if r.err == nil {
-				totals[r.issueID] = r.total
+			if r.err != nil {
+				slog.Warn("fetching worklog total", "issueID", r.issueID, "err", r.err)
+				continue
 			}
+			totals[r.issueID] = r.total
 		}
 
 		return worklogTotalsLoadedMsg{totals: totals, tabID: tabID}
@@ -644,7 +680,15 @@ func (m model) postWorkLogCmd(issueID, startDate, accountID, description string,
 			time,
 		)
 		if err != nil {
-			return errMsg{err}
+			return m.queueOrFail(outbox.Op{
+				Kind:        outbox.Worklog,
+				IssueKey:    m.issueKeyByID(issueID),
+				IssueID:     issueID,
+				StartDate:   startDate,
+				AccountID:   accountID,
+				Description: description,
+				Seconds:     time,
+			}, err)
 		}
 
 		return workLogPostedMsg{}
@@ -666,13 +710,13 @@ func (m model) putWorkLogCmd(worklogID, issueID, startDate, accountID, descripti
 	}
 }
 
-func (m model) deleteWorkLogCmd(worklogID string) tea.Cmd {
+func (m model) deleteWorkLogCmd(issueID, worklogID string) tea.Cmd {
 	return func() tea.Msg {
 		if m.client == nil {
 			return errMsg{fmt.Errorf("jira client not initialized")}
 		}
 
-		err := m.client.DeleteWorkLog(context.Background(), worklogID)
+		err := m.client.DeleteWorkLog(context.Background(), issueID, worklogID)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -722,7 +766,7 @@ func (m model) unlinkIssueCmd(linkID string) tea.Cmd {
 			return errMsg{err}
 		}
 
-		return issueLinkPostedMsg{}
+		return issueLinkDeletedMsg{}
 	}
 }
 
@@ -734,30 +778,13 @@ func (m *model) classifyIssues(issues []jira.Issue, statuses map[string][]jira.S
 		{Name: "Done", CategoryKey: "done", Collapsed: true},
 	}
 
-	global := make(map[string]string)
-	for _, projStatuses := range statuses {
-		for _, s := range projStatuses {
-			global[strings.ToLower(strings.TrimSpace(s.Name))] = s.StatusCategory.Key
-		}
-	}
-
 	var unclassified []jira.Issue
 
 	for i := range issues {
 		issue := issues[i]
-		key := strings.ToLower(strings.TrimSpace(issue.Status))
 
-		categoryKey := ""
-		for _, s := range statuses[issue.Project.ID] {
-			if strings.ToLower(strings.TrimSpace(s.Name)) == key {
-				categoryKey = s.StatusCategory.Key
-				break
-			}
-		}
-		if categoryKey == "" {
-			categoryKey = global[key]
-		}
-		if intransitStatuses[issue.Status] {
+		categoryKey := statusCategoryKey(statuses, issue.Project.ID, issue.Status)
+		if m.isInTransit(issue) {
 			categoryKey = "transit"
 		}
 
@@ -787,20 +814,22 @@ func (m model) calculateDetailLayout() detailLayout {
 	leftColumnWidth := int(float64(panelWidth) * 0.8)
 	rightColumnWidth := int(float64(panelWidth) * 0.2)
 
-	metadataHeight := 7
+	metadataHeight := 9 + m.customMetadataHeight()
 	statusBarHeight := 1
 
-	leftFixedHeight := metadataHeight + statusBarHeight + tabBarHeight + (ui.PanelOverheadHeight * 2)
-	rightFixedHeight := statusBarHeight + tabBarHeight + (ui.PanelOverheadHeight * 3)
+	leftFixedHeight := metadataHeight + statusBarHeight + tabBarHeight + (ui.PanelOverheadHeight * 3)
+	rightFixedHeight := statusBarHeight + tabBarHeight + (ui.PanelOverheadHeight * 4)
 	leftColumnFreeHeight := m.windowHeight - leftFixedHeight
 	rightColumnFreeHeight := m.windowHeight - rightFixedHeight
 
-	descHeight := leftColumnFreeHeight / 2
-	commentsHeight := leftColumnFreeHeight / 2
+	descHeight := leftColumnFreeHeight / 3
+	commentsHeight := leftColumnFreeHeight / 3
+	historyHeight := leftColumnFreeHeight / 3
 
-	worklogsHeight := rightColumnFreeHeight / 3
-	issueLinksHeight := rightColumnFreeHeight / 3
-	subTasksHeight := rightColumnFreeHeight / 3
+	worklogsHeight := rightColumnFreeHeight / 4
+	issueLinksHeight := rightColumnFreeHeight / 4
+	subTasksHeight := rightColumnFreeHeight / 4
+	attachmentsHeight := rightColumnFreeHeight / 4
 
 	return detailLayout{
 		leftColumnWidth,
@@ -808,9 +837,11 @@ func (m model) calculateDetailLayout() detailLayout {
 		metadataHeight,
 		descHeight,
 		commentsHeight,
+		historyHeight,
 		worklogsHeight,
 		issueLinksHeight,
 		subTasksHeight,
+		attachmentsHeight,
 	}
 }
 
@@ -829,13 +860,15 @@ func (m model) calculateListLayout() listLayout {
 }
 
 func (m model) buildListContent() string {
-	if m.currentGrouping() == groupEpic {
+	switch m.currentGrouping() {
+	case groupEpic:
 		return m.buildEpicListContent()
+	case groupColumns:
+		return m.buildColumnsContent()
 	}
 
 	var listContent strings.Builder
 
-	sortSectionsIssues(m.sections)
 	sectionsToRender := m.sections
 	if m.filteredSections != nil {
 		sectionsToRender = m.filteredSections
@@ -846,7 +879,7 @@ func (m model) buildListContent() string {
 		fmt.Fprintf(&listContent, "%s\n", sectionHeader)
 		for ii, issue := range s.Issues {
 			selected := m.sectionCursor == si && m.cursor == ii
-			dimmed := closureStatuses[issue.Status]
+			dimmed := m.isClosed(issue)
 			listContent.WriteString(m.renderIssueRow(issue, selected, dimmed) + "\n")
 		}
 		listContent.WriteString("\n\n")
@@ -865,11 +898,11 @@ func (m model) renderInfoPanel() string {
 	for _, s := range m.sections {
 		switch s.CategoryKey {
 		case "indeterminate":
-			inProgress = len(s.Issues)
+			inProgress += len(s.Issues)
 		case "new":
-			toDo = len(s.Issues)
+			toDo += len(s.Issues)
 		case "done":
-			done = len(s.Issues)
+			done += len(s.Issues)
 		}
 	}
 	total := inProgress + toDo + done
@@ -880,6 +913,9 @@ func (m model) renderInfoPanel() string {
 		projects = append(projects, p.Name)
 	}
 	projectsStr = strings.Join(projects, " · ")
+	if s := m.currentSprint(); s != nil {
+		projectsStr = sprintInfo(s, time.Now())
+	}
 
 	userStyled := ui.InfoPanelUserStyle.Render(userName)
 	projectsStyled := ui.InfoPanelProjectStyle.Render(projectsStr)
@@ -907,6 +943,14 @@ func (m model) renderInfoPanel() string {
 	}
 	totalLoggedStr := ui.InfoPanelCountLabelStyle.Render(ui.IconTime + " Total Logged: " + ui.FormatTimeSpent(totalLoggedSeconds))
 	line3 := totalLoggedStr
+	if n := m.outbox.Len(); n > 0 {
+		pending := ui.InfoPanelPendingStyle.Render(fmt.Sprintf("%s %d pending (O)", ui.IconPending, n))
+		line3Gap := line1InnerWidth - lipgloss.Width(totalLoggedStr) - lipgloss.Width(pending)
+		if line3Gap < 0 {
+			line3Gap = 1
+		}
+		line3 = totalLoggedStr + strings.Repeat(" ", line3Gap) + pending
+	}
 
 	content := line1 + "\n" + line2 + "\n" + line3
 	return ui.InfoPanelStyle.Render(content)
@@ -958,6 +1002,11 @@ func (m model) renderMetadataPanel(width int, height int) string {
 	var detailsContent strings.Builder
 	detailsContent.WriteString(leftHeader + "\n")
 	detailsContent.WriteString(metadataRow1 + "\n" + metadataRow2)
+	detailsContent.WriteString("\n" + renderChipsRow(*m.activeIssue, width-ui.PanelOverheadWidth))
+	detailsContent.WriteString("\n" + renderWatchersRow(*m.activeIssue, m.myself, width-ui.PanelOverheadWidth))
+	for _, row := range m.customMetadataRows(colwidth) {
+		detailsContent.WriteString("\n" + row)
+	}
 
 	return ui.RenderPanelWithLabel("Metadata", detailsContent.String(), width, height, m.focusedSection == metadataSection)
 }
@@ -1010,7 +1059,10 @@ func (m model) buildDescriptionContent(width int) string {
 	var content strings.Builder
 
 	if m.activeIssue.Description != nil {
-		descText := jira.ExtractText(m.activeIssue.Description, width)
+		descText := m.renderADF(m.activeIssue.Description, width-ui.PanelOverheadWidth)
+		if links, i, ok := m.highlightedLink(descriptionSection); ok {
+			descText = highlightLink(descText, links, i)
+		}
 		content.WriteString(descText + "\n\n")
 	} else {
 		content.WriteString(ui.StatusBarInfoStyle.Render("No description") + "\n\n")
@@ -1058,7 +1110,10 @@ func (m model) renderComment(c jira.Comment, width int, isSelected bool, isLast
 		comment.WriteString(author + timestamp + "\n")
 	}
 
-	bodyText := jira.ExtractText(c.Body, width)
+	bodyText := m.renderADF(c.Body, width-ui.PanelOverheadWidth)
+	if links, i, ok := m.highlightedLink(commentsSection); ok && isSelected {
+		bodyText = highlightLink(bodyText, links, i)
+	}
 	wrappedBody := ui.CommentBodyStyle.Render(bodyText)
 	comment.WriteString(wrappedBody + "\n")
 
@@ -1163,22 +1218,23 @@ func (m model) getUserName(accountID string) string {
 func (m model) renderIssueLink(l jira.IssueLink, width int, isSelected bool, isLast bool) string {
 	var content strings.Builder
 
+	var line string
 	if l.InwardIssue != nil {
 		linkType := ui.DimTextStyle.Render(l.Type.Inward)
 		key := ui.KeyFieldStyle.Render(l.InwardIssue.Key)
-		content.WriteString(linkType + " " + key + "\n")
+		line = linkType + " " + key
 	} else if l.OutwardIssue != nil {
 		linkType := ui.DimTextStyle.Render(l.Type.Outward)
 		key := ui.KeyFieldStyle.Render(l.OutwardIssue.Key)
-		content.WriteString(linkType + " " + key + "\n")
+		line = linkType + " " + key
 	}
 
-	// if isSelected {
-	// 	cursor := ui.IconCursor
-	// 	content.WriteString(cursor + issue + " " + key + " " + priority + " " + status + " " + assignee + "\n")
-	// } else {
-	// 	content.WriteString(issue + " " + key + " " + priority + " " + status + " " + assignee + "\n")
-	// }
+	if isSelected {
+		cursor := ui.IconCursor
+		content.WriteString(cursor + ui.SelectedRowStyle.MaxWidth(width-4).Render(line) + "\n")
+	} else {
+		content.WriteString(ui.NormalRowStyle.MaxWidth(width-4).Render(line) + "\n")
+	}
 
 	if !isLast {
 		content.WriteString(ui.SeparatorStyle.Render("  ────") + "\n\n")
@@ -1192,7 +1248,7 @@ func (m model) renderIssueLink(l jira.IssueLink, width int, isSelected bool, isL
 func (m model) buildSubTasksContent(width int) string {
 	var content strings.Builder
 	if m.activeIssue != nil {
-		sortIssuesByStatus(m.activeIssue.SubTasks)
+		m.sortIssuesByStatus(m.activeIssue.SubTasks)
 		subTasksCount := len(m.activeIssue.SubTasks)
 
 		if subTasksCount > 0 {
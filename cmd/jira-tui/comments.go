@@ -20,6 +20,9 @@ func (m model) updateCommentView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.mode = detailView
 			return m, nil
 
+		case editorKey:
+			return m, openEditorCmd(editComment, m.textArea.Value())
+
 		case "@":
 			var cmds []tea.Cmd
 			m.mode = userSearchView
@@ -0,0 +1,563 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/cache"
+	"github.com/oliverjhernandez/jira-tui/internal/config"
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Custom fields: the profile's custom_fields are resolved against Jira's field
+// list (/field) by name or ID, requested with every issue fetch (see
+// jira.Client.SetExtraFields) and shown as extra list columns and metadata
+// rows. F in the detail view edits one of them.
+
+const defaultCustomColumnWidth = 12
+
+// customField is a configured custom field resolved to Jira's metadata.
+type customField struct {
+	config.CustomField
+	field jira.Field
+}
+
+func (cf customField) header() string {
+	if cf.Header != "" {
+		return cf.Header
+	}
+	return strings.ToUpper(cf.field.Name)
+}
+
+func (cf customField) width() int {
+	if cf.Width > 0 {
+		return cf.Width
+	}
+	return defaultCustomColumnWidth
+}
+
+type fieldsLoadedMsg struct {
+	fields []jira.Field
+}
+
+type fieldOptionsLoadedMsg struct {
+	issueKey string
+	field    customField
+	options  []jira.FieldOption
+}
+
+type customFieldUpdatedMsg struct {
+	issueKey string
+	name     string
+}
+
+func (m model) fetchFieldsCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		fields, err := m.client.GetFields(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return fieldsLoadedMsg{fields}
+	}
+}
+
+// resolveCustomFields matches the configured custom fields against m.fields,
+// tells the client to fetch them and resizes the list columns. It returns the
+// configured names Jira doesn't know.
+func (m *model) resolveCustomFields() (unknown []string) {
+	m.customFields = nil
+	var ids []string
+	for _, c := range m.customFieldConfig {
+		f, ok := jira.FindField(m.fields, c.Name)
+		if !ok {
+			unknown = append(unknown, c.Name)
+			continue
+		}
+		m.customFields = append(m.customFields, customField{CustomField: c, field: f})
+		if !slices.Contains(ids, f.ID) {
+			ids = append(ids, f.ID)
+		}
+	}
+	if m.client != nil {
+		m.client.SetExtraFields(ids...)
+	}
+	m.columnWidths = m.listColumnWidths(m.windowWidth)
+	return unknown
+}
+
+// customFieldIDs lists the resolved fields' IDs, to tell whether a refetch
+// brings in new ones.
+func (m model) customFieldIDs() []string {
+	ids := make([]string, len(m.customFields))
+	for i, cf := range m.customFields {
+		ids[i] = cf.field.ID
+	}
+	return ids
+}
+
+func (m model) handleFieldsLoaded(msg fieldsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	before := m.customFieldIDs()
+	m.fields = msg.fields
+	unknown := m.resolveCustomFields()
+
+	cmds := []tea.Cmd{m.saveCacheCmd(cache.Fields, "", msg.fields)}
+	if len(unknown) > 0 {
+		m.setErrorMsg("Unknown custom field: " + strings.Join(unknown, ", "))
+		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
+	}
+	// The boards loaded without the fields' values; fetch them again with
+	// them, background tabs included.
+	if after := m.customFieldIDs(); len(after) > 0 && !slices.Equal(before, after) {
+		for _, t := range m.tabs {
+			m.loadingCount++
+			cmds = append(cmds, m.fetchBoardIssuesCmd(t.board.jql, t.id))
+		}
+	}
+	m.listViewport.SetContent(m.buildListContent())
+	return m, tea.Batch(cmds...)
+}
+
+// customFieldValue renders issue's value for cf, or "" when it has none.
+func customFieldValue(issue jira.Issue, cf customField) string {
+	return jira.FormatFieldValue(issue.CustomFields[cf.field.ID])
+}
+
+// visibleListColumns is listColumns followed by the custom field columns.
+func (m model) visibleListColumns() []listColumn {
+	cols := slices.Clone(listColumns)
+	for _, cf := range m.customFields {
+		if !cf.Column {
+			continue
+		}
+		w := cf.width()
+		cols = append(cols, listColumn{
+			header: cf.header(),
+			width:  func(ui.ColumnWidths) int { return w },
+			cell: func(m model, i jira.Issue, _, _ bool) string {
+				return ui.TruncateLongString(customFieldValue(i, cf), w)
+			},
+		})
+	}
+	return cols
+}
+
+// listColumnWidths sizes the list for width, taking the custom columns (and
+// their gaps) out of the summary.
+func (m model) listColumnWidths(width int) ui.ColumnWidths {
+	var extra int
+	for _, cf := range m.customFields {
+		if cf.Column {
+			extra += cf.width() + 1
+		}
+	}
+	return ui.CalculateColumnWidths(width).WithExtra(extra)
+}
+
+// customMetadataRows renders the fields configured for the detail view, three
+// to a row like the built-in metadata.
+func (m model) customMetadataRows(colwidth int) []string {
+	var rows, cols []string
+	for _, cf := range m.customFields {
+		if !cf.Detail {
+			continue
+		}
+		value := customFieldValue(*m.activeIssue, cf)
+		if value == "" {
+			value = ui.DimTextStyle.Render("—")
+		}
+		cols = append(cols, ui.RenderFieldStyled(cf.field.Name, value, colwidth))
+		if len(cols) == 3 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cols...))
+			cols = nil
+		}
+	}
+	if len(cols) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cols...))
+	}
+	return rows
+}
+
+// customMetadataHeight is how many lines customMetadataRows adds.
+func (m model) customMetadataHeight() int {
+	var n int
+	for _, cf := range m.customFields {
+		if cf.Detail {
+			n++
+		}
+	}
+	return (n + 2) / 3
+}
+
+// editableCustomFields are the configured fields F can edit.
+func (m model) editableCustomFields() []customField {
+	var fields []customField
+	for _, cf := range m.customFields {
+		if cf.field.Kind() != jira.FieldUnsupported {
+			fields = append(fields, cf)
+		}
+	}
+	return fields
+}
+
+// openCustomFieldEdit starts editing a custom field of the active issue,
+// asking which one first when several are configured.
+func (m model) openCustomFieldEdit() (tea.Model, tea.Cmd) {
+	fields := m.editableCustomFields()
+	switch len(fields) {
+	case 0:
+		m.setErrorMsg("No editable custom fields configured")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	case 1:
+		m.previousMode = m.mode
+		return m.editCustomField(fields[0])
+	}
+
+	m.customFieldPickerData = NewCustomFieldPickerFormData(fields)
+	m.previousMode = m.mode
+	m.mode = customFieldPickerView
+	return m, m.customFieldPickerData.Form.Init()
+}
+
+// editCustomField opens the edit form for cf, loading a select field's
+// options first.
+func (m model) editCustomField(cf customField) (tea.Model, tea.Cmd) {
+	switch cf.field.Kind() {
+	case jira.FieldSelect, jira.FieldMultiSelect:
+		m.mode = m.previousMode
+		m.loadingCount++
+		return m, m.fetchFieldOptionsCmd(m.activeIssue.Key, cf)
+	}
+	return m.openCustomFieldForm(cf, nil)
+}
+
+func (m model) fetchFieldOptionsCmd(issueKey string, cf customField) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		options, err := m.client.GetFieldOptions(context.Background(), issueKey, cf.field.ID)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return fieldOptionsLoadedMsg{issueKey: issueKey, field: cf, options: options}
+	}
+}
+
+func (m model) handleFieldOptionsLoaded(msg fieldOptionsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	if m.activeIssue == nil || m.activeIssue.Key != msg.issueKey || m.mode != detailView {
+		return m, nil
+	}
+	m.previousMode = m.mode
+	return m.openCustomFieldForm(msg.field, msg.options)
+}
+
+func (m model) openCustomFieldForm(cf customField, options []jira.FieldOption) (tea.Model, tea.Cmd) {
+	m.pendingIssue = m.activeIssue
+	current := m.activeIssue.CustomFields[cf.field.ID]
+	m.customFieldData = NewCustomFieldFormData(cf, current, options, m.usersCache, m.client)
+	m.mode = customFieldView
+	return m, m.customFieldData.Form.Init()
+}
+
+// CustomFieldPickerFormData chooses which custom field to edit.
+type CustomFieldPickerFormData struct {
+	FieldID string
+	Fields  []customField
+	Form    *huh.Form
+}
+
+func NewCustomFieldPickerFormData(fields []customField) *CustomFieldPickerFormData {
+	options := make([]huh.Option[string], len(fields))
+	for i, cf := range fields {
+		options[i] = huh.NewOption(cf.field.Name, cf.field.ID)
+	}
+
+	p := &CustomFieldPickerFormData{Fields: fields}
+	p.Form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Field").
+				Options(options...).
+				Value(&p.FieldID),
+		),
+	).WithWidth(40)
+
+	return p
+}
+
+func (m model) updateCustomFieldPickerView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyPressMsg, ok := msg.(tea.KeyPressMsg); ok && keyPressMsg.String() == "esc" {
+		m.mode = m.previousMode
+		m.customFieldPickerData = nil
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	form, cmd := m.customFieldPickerData.Form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.customFieldPickerData.Form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.customFieldPickerData.Form.State == huh.StateCompleted {
+		data := m.customFieldPickerData
+		m.customFieldPickerData = nil
+		for _, cf := range data.Fields {
+			if cf.field.ID == data.FieldID {
+				return m.editCustomField(cf)
+			}
+		}
+		m.mode = m.previousMode
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) renderCustomFieldPickerView() string {
+	return m.renderModal("Edit field", m.customFieldPickerData.Form.View(), 0.3, 0.3)
+}
+
+// CustomFieldFormData edits one custom field. Input backs the text-like kinds
+// (text, number, dates, labels), Choice single selects and Choices multi
+// selects; labels maps option and user IDs to what they display as.
+type CustomFieldFormData struct {
+	Field   customField
+	Input   string
+	Choice  string
+	Choices []string
+	labels  map[string]string
+	Form    *huh.Form
+}
+
+// NewCustomFieldFormData builds the form for cf's kind, starting from its
+// current raw value. Select fields choose from options, user fields from
+// users; client validates the text-like inputs and may be nil.
+func NewCustomFieldFormData(cf customField, current json.RawMessage, options []jira.FieldOption, users []jira.User, client *jira.Client) *CustomFieldFormData {
+	d := &CustomFieldFormData{Field: cf, labels: make(map[string]string)}
+	kind := cf.field.Kind()
+	title := cf.field.Name
+
+	var choices []huh.Option[string]
+	switch kind {
+	case jira.FieldSelect, jira.FieldMultiSelect:
+		for _, o := range options {
+			d.labels[o.ID] = o.Value
+			choices = append(choices, huh.NewOption(o.Value, o.ID))
+		}
+	case jira.FieldUser, jira.FieldMultiUser:
+		for _, u := range users {
+			d.labels[u.ID] = u.Name
+			choices = append(choices, huh.NewOption(u.Name, u.ID))
+		}
+	}
+	ids := jira.FieldValueIDs(current)
+
+	var field huh.Field
+	switch kind {
+	case jira.FieldSelect, jira.FieldUser:
+		if len(ids) > 0 {
+			d.Choice = ids[0]
+		}
+		choices = append([]huh.Option[string]{huh.NewOption("(none)", "")}, choices...)
+		field = huh.NewSelect[string]().
+			Title(title).
+			Options(choices...).
+			Value(&d.Choice).
+			Height(10)
+	case jira.FieldMultiSelect, jira.FieldMultiUser:
+		d.Choices = ids
+		field = huh.NewMultiSelect[string]().
+			Title(title).
+			Options(choices...).
+			Value(&d.Choices).
+			Filterable(true).
+			Height(10)
+	default:
+		d.Input = inputValue(kind, current)
+		field = huh.NewInput().
+			Title(title).
+			Description(inputHint(kind)).
+			Value(&d.Input).
+			Validate(func(s string) error {
+				if client == nil {
+					return nil
+				}
+				_, err := client.EncodeFieldValue(cf.field, splitInput(kind, s))
+				return err
+			})
+	}
+
+	d.Form = huh.NewForm(huh.NewGroup(field)).WithWidth(50)
+	return d
+}
+
+// inputValue is the current value as the user would type it.
+func inputValue(kind jira.FieldKind, raw json.RawMessage) string {
+	switch kind {
+	case jira.FieldLabels:
+		return strings.Join(jira.FieldValueIDs(raw), ", ")
+	case jira.FieldDate, jira.FieldDateTime:
+		var s string
+		if json.Unmarshal(raw, &s) != nil {
+			return ""
+		}
+		if kind == jira.FieldDateTime && len(s) >= 16 {
+			return strings.Replace(s[:16], "T", " ", 1)
+		}
+		return s
+	}
+	return jira.FormatFieldValue(raw)
+}
+
+func inputHint(kind jira.FieldKind) string {
+	switch kind {
+	case jira.FieldNumber:
+		return "A number; empty clears it"
+	case jira.FieldDate:
+		return "YYYY-MM-DD; empty clears it"
+	case jira.FieldDateTime:
+		return "YYYY-MM-DD HH:MM; empty clears it"
+	case jira.FieldLabels:
+		return "Comma-separated"
+	}
+	return "Empty clears it"
+}
+
+// splitInput turns the text input into EncodeFieldValue's values.
+func splitInput(kind jira.FieldKind, s string) []string {
+	if kind == jira.FieldLabels {
+		return strings.Split(s, ",")
+	}
+	return []string{s}
+}
+
+// values is what the form holds, as EncodeFieldValue takes it.
+func (d *CustomFieldFormData) values() []string {
+	switch d.Field.field.Kind() {
+	case jira.FieldSelect, jira.FieldUser:
+		return []string{d.Choice}
+	case jira.FieldMultiSelect, jira.FieldMultiUser:
+		return d.Choices
+	}
+	return splitInput(d.Field.field.Kind(), d.Input)
+}
+
+// display turns an encoded value into the raw JSON the list and detail render,
+// putting back the option values and user names the request leaves out.
+func (d *CustomFieldFormData) display(encoded any) json.RawMessage {
+	if encoded == nil {
+		return nil
+	}
+	label := func(ref map[string]any) map[string]any {
+		out := make(map[string]any, len(ref)+1)
+		for k, v := range ref {
+			out[k] = v
+			if id, ok := v.(string); ok && d.labels[id] != "" {
+				switch d.Field.field.Kind() {
+				case jira.FieldUser, jira.FieldMultiUser:
+					out["displayName"] = d.labels[id]
+				default:
+					out["value"] = d.labels[id]
+				}
+			}
+		}
+		return out
+	}
+	switch v := encoded.(type) {
+	case map[string]any:
+		encoded = label(v)
+	case []map[string]any:
+		labelled := make([]map[string]any, len(v))
+		for i, ref := range v {
+			labelled[i] = label(ref)
+		}
+		encoded = labelled
+	}
+	raw, err := json.Marshal(encoded)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+func (m model) updateCustomFieldView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyPressMsg, ok := msg.(tea.KeyPressMsg); ok && keyPressMsg.String() == "esc" {
+		m.mode = m.previousMode
+		m.customFieldData = nil
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	form, cmd := m.customFieldData.Form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.customFieldData.Form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.customFieldData.Form.State == huh.StateCompleted {
+		data := m.customFieldData
+		m.customFieldData = nil
+		m.mode = m.previousMode
+		if m.pendingIssue == nil || m.client == nil {
+			return m, tea.Batch(cmds...)
+		}
+
+		value, err := m.client.EncodeFieldValue(data.Field.field, data.values())
+		if err != nil {
+			m.setErrorMsg(err.Error())
+			return m, m.clearStatusAfter(clearMsgTimeout)
+		}
+		key := m.pendingIssue.Key
+		m.loadingCount++
+		cmds = append(cmds, m.optimisticCustomField(key, data.Field.field.ID, data.display(value),
+			m.updateCustomFieldCmd(key, data.Field, value)))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) renderCustomFieldView() string {
+	label := "Edit field"
+	if m.pendingIssue != nil {
+		label += " " + m.pendingIssue.Key
+	}
+	return m.renderModal(label, m.customFieldData.Form.View(), 0.35, 0.45)
+}
+
+func (m model) updateCustomFieldCmd(issueKey string, cf customField, value any) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		err := m.client.UpdateField(context.Background(), issueKey, cf.field.ID, value)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return customFieldUpdatedMsg{issueKey: issueKey, name: cf.field.Name}
+	}
+}
+
+func (m model) handleCustomFieldUpdated(msg customFieldUpdatedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.setSuccess(fmt.Sprintf("%s updated on %s", msg.name, msg.issueKey))
+	return m, m.clearStatusAfter(clearMsgTimeout)
+}
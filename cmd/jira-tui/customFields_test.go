@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"charm.land/huh/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/config"
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+var testFields = []jira.Field{
+	{ID: "customfield_10016", Name: "Story Points", Custom: true, Schema: jira.FieldSchema{Type: "number"}},
+	{ID: "customfield_10042", Name: "Cliente", Custom: true, Schema: jira.FieldSchema{Type: "option"}},
+}
+
+func newCustomFieldModel() model {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.customFieldConfig = []config.CustomField{
+		{Name: "Story Points", Column: true, Width: 6, Header: "SP"},
+		{Name: "customfield_10042", Detail: true},
+	}
+	m.fields = testFields
+	m.resolveCustomFields()
+	m.issues = []jira.Issue{{
+		Key: "DEV-1", Status: "In Progress", Project: jira.Project{ID: "P"},
+		CustomFields: map[string]json.RawMessage{
+			"customfield_10016": json.RawMessage(`5`),
+			"customfield_10042": json.RawMessage(`{"id":"1","value":"Acme"}`),
+		},
+	}}
+	m.refreshBoard("DEV-1")
+	return m
+}
+
+func TestResolveCustomFieldsAddsColumns(t *testing.T) {
+	m := newCustomFieldModel()
+
+	cols := m.visibleListColumns()
+	if len(cols) != len(listColumns)+1 || cols[len(cols)-1].header != "SP" {
+		t.Fatalf("expected one custom column after the built-in ones, got %d", len(cols))
+	}
+	if m.columnWidths.Extra != 7 {
+		t.Errorf("Extra = %d, want the column width plus its gap", m.columnWidths.Extra)
+	}
+
+	row := ansi.Strip(m.renderIssueRow(m.issues[0], false, false))
+	if !strings.HasSuffix(strings.TrimRight(row, " "), "5") {
+		t.Errorf("row should end with the story points, got %q", row)
+	}
+	if !strings.Contains(ansi.Strip(m.renderListColumnsHeader()), "SP") {
+		t.Error("header should label the custom column")
+	}
+
+	m.customFieldConfig = append(m.customFieldConfig, config.CustomField{Name: "Team"})
+	if unknown := m.resolveCustomFields(); len(unknown) != 1 || unknown[0] != "Team" {
+		t.Errorf("unknown = %v, want [Team]", unknown)
+	}
+}
+
+func TestFieldsLoadedRefetchesBoards(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}, {id: 1, board: boardState{jql: "b"}}}, 0)
+	m.customFieldConfig = []config.CustomField{{Name: "Story Points", Column: true}, {Name: "Team"}}
+	m.loadingCount = 1
+
+	next, cmd := m.Update(fieldsLoadedMsg{fields: testFields})
+	nm := next.(model)
+	if cmd == nil || nm.loadingCount != 2 {
+		t.Errorf("newly resolved fields should refetch every tab's board (loadingCount %d)", nm.loadingCount)
+	}
+	if len(nm.customFields) != 1 {
+		t.Errorf("customFields = %+v", nm.customFields)
+	}
+	if nm.statusMessage.msgType != errStatusBarMsg || !strings.Contains(nm.statusMessage.content, "Team") {
+		t.Errorf("expected an unknown field warning, got %q", nm.statusMessage.content)
+	}
+
+	// Same fields again (a stale cache refreshed): no refetch.
+	nm.loadingCount = 1
+	next, _ = nm.Update(fieldsLoadedMsg{fields: testFields})
+	if next.(model).loadingCount != 0 {
+		t.Error("unchanged fields should not refetch the board")
+	}
+}
+
+func TestCustomMetadataRows(t *testing.T) {
+	m := newCustomFieldModel()
+	m.activeIssue = &m.issues[0]
+
+	rows := m.customMetadataRows(30)
+	if len(rows) != 1 || m.customMetadataHeight() != 1 {
+		t.Fatalf("rows = %d, height %d, want 1", len(rows), m.customMetadataHeight())
+	}
+	if got := ansi.Strip(rows[0]); !strings.Contains(got, "Cliente: Acme") {
+		t.Errorf("row = %q", got)
+	}
+
+	m.activeIssue = &jira.Issue{Key: "DEV-2"}
+	if got := ansi.Strip(m.customMetadataRows(30)[0]); !strings.Contains(got, "Cliente: —") {
+		t.Errorf("an empty field shows a dash, got %q", got)
+	}
+}
+
+func TestEditSelectFieldAppliesOptimistically(t *testing.T) {
+	m := newCustomFieldModel()
+	m.client, _ = jira.NewClient("http://jira.invalid", "user@example.com", "token", "", "")
+	m.activeIssue = &m.issues[0]
+	m.mode = detailView
+
+	cliente := m.customFields[1]
+	next, _ := m.Update(fieldOptionsLoadedMsg{
+		issueKey: "DEV-1",
+		field:    cliente,
+		options:  []jira.FieldOption{{ID: "1", Value: "Acme"}, {ID: "2", Value: "Globex"}},
+	})
+	m = next.(model)
+	if m.mode != customFieldView || m.customFieldData.Choice != "1" {
+		t.Fatalf("form should open on the current option, mode %v", m.mode)
+	}
+
+	m.customFieldData.Choice = "2"
+	m.customFieldData.Form.State = huh.StateCompleted
+	next, cmd := m.Update(nil)
+	nm := next.(model)
+	if cmd == nil || nm.mode != detailView {
+		t.Fatalf("expected the update to be sent and the form closed, mode %v", nm.mode)
+	}
+	if got := customFieldValue(nm.issues[0], cliente); got != "Globex" {
+		t.Errorf("list copy shows %q, want Globex", got)
+	}
+	if got := customFieldValue(*nm.activeIssue, cliente); got != "Globex" || !nm.isPending("DEV-1") {
+		t.Errorf("detail copy shows %q (pending %v)", got, nm.isPending("DEV-1"))
+	}
+}
+
+func TestOpenCustomFieldEditWithoutFields(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.activeIssue = &jira.Issue{Key: "DEV-1"}
+	next, _ := m.openCustomFieldEdit()
+	if nm := next.(model); nm.statusMessage.msgType != errStatusBarMsg {
+		t.Errorf("expected an error, got %q", nm.statusMessage.content)
+	}
+}
@@ -19,6 +19,8 @@ func NewDescriptionFormData(initialValue string) *DescriptionFormData {
 			huh.NewText().
 				Title("Description (Markdown supported)").
 				Value(&d.Description).
+				// editorKey opens $VISUAL/$EDITOR instead (see editor.go).
+				ExternalEditor(false).
 				Lines(15),
 		),
 	).WithWidth(60)
@@ -35,6 +37,8 @@ func (m model) updateEditDescriptionView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.mode = detailView
 			m.editingDescription = false
 			return m, m.descriptionData.Form.Init()
+		case editorKey:
+			return m, openEditorCmd(editDescription, m.descriptionData.Description)
 		}
 	}
 
@@ -18,9 +18,11 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		metadataSection,
 		descriptionSection,
 		commentsSection,
+		historySection,
 		worklogsSection,
 		issueLinksSection,
 		subTasksSection,
+		attachmentsSection,
 	}
 
 	if keyPressMsg, ok := msg.(tea.KeyPressMsg); ok {
@@ -39,6 +41,17 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.focusedSection == descriptionSection || m.focusedSection == commentsSection {
+			switch keyPressMsg.String() {
+			case nextLinkKey:
+				return m.cycleLink(1)
+			case prevLinkKey:
+				return m.cycleLink(-1)
+			case followLinkKey:
+				return m.followLink()
+			}
+		}
+
 		switch m.focusedSection {
 		case descriptionSection:
 			switch {
@@ -73,7 +86,7 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case keyPressMsg.String() == "y" && m.lastKey == "y":
 				var cmds []tea.Cmd
 				m.lastKey = ""
-				textToCopy := jira.ExtractText(m.activeIssue.Description, m.detailLayout.leftColumnWidth)
+				textToCopy := m.renderADFText(m.activeIssue.Description, m.detailLayout.leftColumnWidth-ui.PanelOverheadWidth)
 				yankToClipboard(textToCopy)
 				m.setInfo("Description yanked to clipboard")
 				cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
@@ -117,7 +130,7 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.commentsCursor < 0 || m.commentsCursor >= len(m.activeIssue.Comments) {
 					return m, nil
 				}
-				textToCopy := jira.ExtractText(m.activeIssue.Comments[m.commentsCursor].Body, m.detailLayout.leftColumnWidth)
+				textToCopy := m.renderADFText(m.activeIssue.Comments[m.commentsCursor].Body, m.detailLayout.leftColumnWidth-ui.PanelOverheadWidth)
 				yankToClipboard(textToCopy)
 				m.setInfo("Comment yanked to clipboard")
 				cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
@@ -177,8 +190,10 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.commentsCursor < 0 || m.commentsCursor >= len(m.activeIssue.Comments) {
 					return m, nil
 				}
+				comment := m.activeIssue.Comments[m.commentsCursor]
 				m.loadingCount++
-				cmd := m.deleteCommentCmd(m.activeIssue.Key, m.activeIssue.Comments[m.commentsCursor].ID)
+				cmd := m.journal(undoEntry{kind: undoComment, issueKey: m.activeIssue.Key, comment: comment},
+					m.deleteCommentCmd(m.activeIssue.Key, comment.ID))
 				return m, cmd
 			}
 		case worklogsSection:
@@ -223,8 +238,68 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.worklogsCursor < 0 || m.worklogsCursor >= len(m.activeIssue.Worklogs) {
 					return m, nil
 				}
+				worklog := m.activeIssue.Worklogs[m.worklogsCursor]
 				m.loadingCount++
-				cmd := m.deleteWorkLogCmd(strconv.Itoa(m.activeIssue.Worklogs[m.worklogsCursor].ID))
+				cmd := m.journal(undoEntry{kind: undoWorklog, issueKey: m.activeIssue.Key, issueID: m.activeIssue.ID, worklog: worklog},
+					m.deleteWorkLogCmd(m.activeIssue.ID, strconv.Itoa(worklog.ID)))
+				return m, cmd
+			}
+		case issueLinksSection:
+			switch {
+
+			case keyPressMsg.String() == "j":
+				if m.IssueLinksCursor < len(m.activeIssue.IssueLinks)-1 {
+					m.IssueLinksCursor++
+				}
+
+				cursorLine := m.IssueLinksCursor * 3
+				m.issueLinksViewport.SetYOffset(cursorLine)
+
+				ilContent := m.buildIssueLinksContent(m.detailLayout.rightColumnWidth - ui.PanelOverheadWidth)
+				m.issueLinksViewport.SetContent(ilContent)
+				return m, nil
+
+			case keyPressMsg.String() == "k":
+				if m.IssueLinksCursor > 0 {
+					m.IssueLinksCursor--
+				}
+
+				cursorLine := m.IssueLinksCursor * 3
+				m.issueLinksViewport.SetYOffset(cursorLine)
+
+				ilContent := m.buildIssueLinksContent(m.detailLayout.rightColumnWidth - ui.PanelOverheadWidth)
+				m.issueLinksViewport.SetContent(ilContent)
+				return m, nil
+
+			case keyPressMsg.String() == "o":
+				if m.IssueLinksCursor < 0 || m.IssueLinksCursor >= len(m.activeIssue.IssueLinks) {
+					return m, nil
+				}
+				key := linkedIssueKey(m.activeIssue.IssueLinks[m.IssueLinksCursor])
+				if key == "" {
+					return m, nil
+				}
+				return m, m.openIssueInBrowser(key)
+
+			case keyPressMsg.String() == "enter":
+				if m.IssueLinksCursor < 0 || m.IssueLinksCursor >= len(m.activeIssue.IssueLinks) {
+					return m, nil
+				}
+				key := linkedIssueKey(m.activeIssue.IssueLinks[m.IssueLinksCursor])
+				if key == "" {
+					return m, nil
+				}
+				m.pushNavHistory()
+				return m.openDetail(key)
+
+			case keyPressMsg.String() == "d":
+				if m.IssueLinksCursor < 0 || m.IssueLinksCursor >= len(m.activeIssue.IssueLinks) {
+					return m, nil
+				}
+				link := m.activeIssue.IssueLinks[m.IssueLinksCursor]
+				m.loadingCount++
+				cmd := m.journal(undoEntry{kind: undoIssueLink, issueKey: m.activeIssue.Key, link: link},
+					m.unlinkIssueCmd(link.ID))
 				return m, cmd
 			}
 		case subTasksSection:
@@ -260,6 +335,7 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				if m.subTasksCursor >= 0 && m.subTasksCursor < len(m.activeIssue.SubTasks) {
 					m.loadingCount++
+					m.pushNavHistory()
 					m.activeIssue = &m.activeIssue.SubTasks[m.subTasksCursor]
 					detailCmd := m.fetchIssueDetailCmd(m.activeIssue.Key)
 					cmds = append(cmds, detailCmd)
@@ -267,6 +343,12 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, tea.Batch(cmds...)
 
+			case keyPressMsg.String() == "o":
+				if m.subTasksCursor < 0 || m.subTasksCursor >= len(m.activeIssue.SubTasks) {
+					return m, nil
+				}
+				return m, m.openIssueInBrowser(m.activeIssue.SubTasks[m.subTasksCursor].Key)
+
 			case keyPressMsg.String() == "n":
 				i := &NewIssueFormData{
 					ParentKey: m.activeIssue.Key,
@@ -293,6 +375,7 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				m.previousMode = m.mode
 				m.mode = transitionView
+				m.movingCard = false
 				m.transitionCursor = 0
 				m.loadingCount++
 				subTask := m.activeIssue.SubTasks[m.subTasksCursor]
@@ -313,13 +396,44 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mode = userSearchView
 
 				if m.usersCache != nil {
-					m.loadingCount++
 					m.searchUserData = NewSearchUserFormData(m.usersCache)
 					cmds = append(cmds, m.searchUserData.Form.Init())
 				}
 
 				return m, tea.Batch(cmds...)
 			}
+		case historySection:
+			switch keyPressMsg.String() {
+			case "j":
+				m.historyViewport.ScrollDown(1)
+				return m, nil
+			case "k":
+				m.historyViewport.ScrollUp(1)
+				return m, nil
+			case "ctrl+d":
+				m.historyViewport.HalfPageDown()
+				return m, nil
+			case "ctrl+u":
+				m.historyViewport.HalfPageUp()
+				return m, nil
+			case "f":
+				return m.cycleHistoryFilter(), nil
+			}
+		case attachmentsSection:
+			// A pending prefix takes the next key: "y s" yanks the summary.
+			if m.lastKey != "" {
+				break
+			}
+			switch keyPressMsg.String() {
+			case "j":
+				return m.moveAttachmentsCursor(1), nil
+			case "k":
+				return m.moveAttachmentsCursor(-1), nil
+			case "s":
+				return m.downloadAttachment(false)
+			case "enter":
+				return m.downloadAttachment(true)
+			}
 		}
 
 		switch {
@@ -339,7 +453,7 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case keyPressMsg.String() == "K" && m.lastKey == "y":
 			var cmds []tea.Cmd
 			m.lastKey = ""
-			textToCopy := jiraURL + m.activeIssue.Key
+			textToCopy := m.browseURL(m.activeIssue.Key)
 			yankToClipboard(textToCopy)
 			m.setInfo("URL yanked to clipboard")
 			cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
@@ -366,6 +480,7 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 					Key:  m.activeIssue.Parent.Key,
 					Type: m.activeIssue.Parent.Type,
 				}
+				m.pushNavHistory()
 				m.activeIssue = &issue
 				m.loadingCount++
 				return m, m.fetchIssueDetailCmd(m.activeIssue.Key)
@@ -377,6 +492,7 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var cmds []tea.Cmd
 			m.previousMode = m.mode
 			m.mode = transitionView
+			m.movingCard = false
 			m.transitionCursor = 0
 			m.pendingIssue = m.activeIssue
 
@@ -412,12 +528,14 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.userSelectionMode = assignUser
 
 			if m.usersCache != nil {
-				m.loadingCount++
 				m.searchUserData = NewSearchUserFormData(m.usersCache)
 				cmds = append(cmds, m.searchUserData.Form.Init())
 			}
 			return m, tea.Batch(cmds...)
 
+		case keyPressMsg.String() == "M":
+			return m.openSprintPicker()
+
 		// priorities
 		case keyPressMsg.String() == "p":
 			m.priorityData = NewPriorityFormData(m.priorities, m.activeIssue.Priority.Name)
@@ -425,6 +543,37 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.mode = priorityView
 			return m, m.priorityData.Form.Init()
 
+		// custom fields
+		case keyPressMsg.String() == "F":
+			return m.openCustomFieldEdit()
+
+		// attachments
+		case keyPressMsg.String() == "A":
+			return m.openAttachmentUpload()
+
+		// watchers
+		case keyPressMsg.String() == "W":
+			return m.toggleWatch()
+
+		case keyPressMsg.String() == imagesKey:
+			return m.toggleImages()
+
+		case keyPressMsg.String() == navBackKey:
+			return m.navigateBack()
+
+		case keyPressMsg.String() == "ctrl+w":
+			return m.openWatchers()
+
+		// labels, components, fix versions
+		case keyPressMsg.String() == "L":
+			return m.openListFieldEdit(jira.LabelsField)
+
+		case keyPressMsg.String() == "C":
+			return m.openListFieldEdit(jira.ComponentsField)
+
+		case keyPressMsg.String() == "V":
+			return m.openListFieldEdit(jira.FixVersionsField)
+
 		// edit summary (contextual edit on the metadata section)
 		case keyPressMsg.String() == "e":
 			if m.activeIssue == nil {
@@ -439,12 +588,12 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case keyPressMsg.String() == "tab" || keyPressMsg.String() == "]":
 			currentIdx := findIndex(m.focusedSection, detailViewSections)
 			m.focusedSection = detailViewSections[(currentIdx+1)%len(detailViewSections)]
-			return m, nil
+			return m, m.fetchRestOfHistoryCmd()
 
 		case keyPressMsg.String() == "shift+tab" || keyPressMsg.String() == "[":
 			currentIdx := findIndex(m.focusedSection, detailViewSections)
 			m.focusedSection = detailViewSections[(currentIdx-1+len(detailViewSections))%len(detailViewSections)]
-			return m, nil
+			return m, m.fetchRestOfHistoryCmd()
 
 		// link
 		case keyPressMsg.String() == "l":
@@ -477,6 +626,11 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, tea.Batch(cmds...)
 
+		// open in browser; the issue-links and sub-tasks sections open the
+		// issue under the cursor instead
+		case keyPressMsg.String() == "o":
+			return m, m.openIssueInBrowser(m.activeIssue.Key)
+
 		case keyPressMsg.String() == "ctrl+r":
 			if m.loadingCount > 0 {
 				return m, nil
@@ -490,6 +644,7 @@ func (m model) updateDetailView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.detailReturnView = listView
 			m.detailPolling = false
 			m.activeIssue = nil
+			m.navHistory = nil
 
 			m.descViewport.SetContent("")
 			m.commentsViewport.SetContent("")
@@ -516,15 +671,17 @@ func (m model) renderDetailView() string {
 	metadataPanel := m.renderMetadataPanel(m.detailLayout.leftColumnWidth, m.detailLayout.metadataHeight)
 	descriptionPanel := m.renderDescriptionPanel(m.detailLayout.leftColumnWidth, m.detailLayout.descHeight)
 	commentsPanel := m.renderCommentsPanel(m.detailLayout.leftColumnWidth, m.detailLayout.commentsHeight)
+	historyPanel := m.renderHistoryPanel(m.detailLayout.leftColumnWidth, m.detailLayout.historyHeight)
 
 	worklogPanel := m.renderWorklogsPanel(m.detailLayout.rightColumnWidth, m.detailLayout.worklogsHeight)
 	issueLinksPanel := m.renderIssueLinksPanel(m.detailLayout.rightColumnWidth, m.detailLayout.issueLinksHeight)
 	subTasksPanel := m.renderSubTasksPanel(m.detailLayout.rightColumnWidth, m.detailLayout.subTasksHeight)
+	attachmentsPanel := m.renderAttachmentsPanel(m.detailLayout.rightColumnWidth, m.detailLayout.attachmentsHeight)
 
 	statusBar := m.renderStatusBar()
 
-	leftColumn := lipgloss.JoinVertical(lipgloss.Left, metadataPanel, descriptionPanel, commentsPanel)
-	rightColumn := lipgloss.JoinVertical(lipgloss.Left, worklogPanel, issueLinksPanel, subTasksPanel)
+	leftColumn := lipgloss.JoinVertical(lipgloss.Left, metadataPanel, descriptionPanel, commentsPanel, historyPanel)
+	rightColumn := lipgloss.JoinVertical(lipgloss.Left, worklogPanel, issueLinksPanel, subTasksPanel, attachmentsPanel)
 
 	columns := lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, rightColumn)
 
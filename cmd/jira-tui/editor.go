@@ -0,0 +1,113 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// editorKey opens the form's text in $VISUAL/$EDITOR.
+const editorKey = "ctrl+g"
+
+// fallbackEditor is used when neither $VISUAL nor $EDITOR is set.
+const fallbackEditor = "vi"
+
+// editorTarget is the form an external editor session fills in.
+type editorTarget int
+
+const (
+	editDescription editorTarget = iota
+	editComment
+)
+
+// editorFinishedMsg carries the text saved in the external editor back to
+// the form it was opened from.
+type editorFinishedMsg struct {
+	target editorTarget
+	text   string
+	err    error
+}
+
+// editorCommand is $VISUAL, else $EDITOR, else vi, split into the program
+// and its arguments so values like "code --wait" work.
+func editorCommand() (string, []string) {
+	fields := strings.Fields(cmp.Or(os.Getenv("VISUAL"), os.Getenv("EDITOR")))
+	if len(fields) == 0 {
+		return fallbackEditor, nil
+	}
+	return fields[0], fields[1:]
+}
+
+// openEditorCmd writes text to a Markdown temp file, then suspends the TUI
+// while the user's editor has it and reads it back once the editor exits.
+// It's called from Update, so the file is written before the program hands
+// the terminal over.
+func openEditorCmd(target editorTarget, text string) tea.Cmd {
+	path, err := writeEditorFile(text)
+	if err != nil {
+		return func() tea.Msg { return editorFinishedMsg{target: target, err: err} }
+	}
+
+	name, args := editorCommand()
+	cmd := exec.Command(name, append(args, path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return editorFinishedMsg{target: target, err: fmt.Errorf("running %s: %w", name, err)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return editorFinishedMsg{target: target, err: err}
+		}
+		// Editors end the file with a newline the form doesn't need.
+		return editorFinishedMsg{target: target, text: strings.TrimRight(string(data), "\n")}
+	})
+}
+
+func writeEditorFile(text string) (string, error) {
+	f, err := os.CreateTemp("", "jira-tui-*.md")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.WriteString(text)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// handleEditorFinished loads the edited text into the form it came from, to
+// be reviewed and submitted as usual. A form closed in the meantime is left
+// alone.
+func (m model) handleEditorFinished(msg editorFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.setError("external editor", msg.err)
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+
+	var cmd tea.Cmd
+	switch msg.target {
+	case editDescription:
+		if m.mode != descriptionView || m.descriptionData == nil {
+			return m, nil
+		}
+		m.descriptionData = NewDescriptionFormData(msg.text)
+		cmd = m.descriptionData.Form.Init()
+		m.setInfo("Loaded from editor: review, then enter to save")
+	case editComment:
+		if m.mode != commentView {
+			return m, nil
+		}
+		m.textArea.SetValue(msg.text)
+		m.setInfo("Loaded from editor: review, then ctrl+s to save")
+	}
+	return m, tea.Batch(cmd, m.clearStatusAfter(clearMsgTimeout))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"charm.land/bubbles/v2/textarea"
+)
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "nano")
+	if name, args := editorCommand(); name != "code" || !slices.Equal(args, []string{"--wait"}) {
+		t.Errorf("editorCommand() = %q %q, want $VISUAL split", name, args)
+	}
+
+	t.Setenv("VISUAL", "")
+	if name, _ := editorCommand(); name != "nano" {
+		t.Errorf("editorCommand() = %q, want $EDITOR", name)
+	}
+
+	t.Setenv("EDITOR", "")
+	if name, args := editorCommand(); name != fallbackEditor || len(args) != 0 {
+		t.Errorf("editorCommand() = %q %q, want %s", name, args, fallbackEditor)
+	}
+}
+
+func TestWriteEditorFile(t *testing.T) {
+	path, err := writeEditorFile("hi @[Jane Doe]")
+	if err != nil {
+		t.Fatalf("writeEditorFile: %v", err)
+	}
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "hi @[Jane Doe]" {
+		t.Errorf("file holds %q (%v)", data, err)
+	}
+}
+
+func TestOpenEditorCmdHandsOffToProgram(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+	t.Setenv("VISUAL", "false")
+
+	cmd := openEditorCmd(editComment, "draft")
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Fatalf("the temp file should be written before the command runs, found %d", len(files))
+	}
+	// The command only asks the program to exec the editor; it must not run
+	// it itself.
+	if msg, ok := cmd().(editorFinishedMsg); ok {
+		t.Errorf("the editor ran inside the command: %+v", msg)
+	}
+}
+
+func TestEditorFinishedLoadsComment(t *testing.T) {
+	m := newTabModel([]Tab{{}}, 0)
+	m.mode = commentView
+	m.textArea = textarea.New()
+	m.textArea.SetValue("draft")
+
+	next, _ := m.handleEditorFinished(editorFinishedMsg{target: editComment, text: "long write-up\n\nthanks @[Jane Doe]"})
+	got := next.(model)
+	if v := got.textArea.Value(); v != "long write-up\n\nthanks @[Jane Doe]" {
+		t.Errorf("textarea = %q", v)
+	}
+	if got.mode != commentView {
+		t.Errorf("mode = %v, want the form kept open for review", got.mode)
+	}
+}
+
+func TestEditorFinishedLoadsDescription(t *testing.T) {
+	m := newTabModel([]Tab{{}}, 0)
+	m.mode = descriptionView
+	m.descriptionData = NewDescriptionFormData("old")
+
+	next, _ := m.handleEditorFinished(editorFinishedMsg{target: editDescription, text: "new"})
+	if d := next.(model).descriptionData.Description; d != "new" {
+		t.Errorf("description = %q, want new", d)
+	}
+}
+
+func TestEditorFinishedAfterFormClosed(t *testing.T) {
+	m := newTabModel([]Tab{{}}, 0)
+	m.mode = detailView
+	m.textArea = textarea.New()
+
+	next, _ := m.handleEditorFinished(editorFinishedMsg{target: editComment, text: "late"})
+	if v := next.(model).textArea.Value(); v != "" {
+		t.Errorf("closed form was filled with %q", v)
+	}
+}
+
+func TestEditorFinishedError(t *testing.T) {
+	m := newTabModel([]Tab{{}}, 0)
+	m.mode = commentView
+	m.textArea = textarea.New()
+	m.textArea.SetValue("draft")
+
+	next, _ := m.handleEditorFinished(editorFinishedMsg{target: editComment, err: errors.New("exit status 1")})
+	got := next.(model)
+	if got.statusMessage.msgType != errStatusBarMsg {
+		t.Errorf("status = %+v, want an error", got.statusMessage)
+	}
+	if got.textArea.Value() != "draft" {
+		t.Errorf("draft lost: %q", got.textArea.Value())
+	}
+}
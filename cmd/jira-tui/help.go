@@ -34,9 +34,12 @@ var helpGroups = []helpGroup{
 		{"gt / gT", "Next / previous tab"},
 		{"x", "Close current tab"},
 		{"b", "Open epic board"},
+		{"S", "Open the project's active sprint"},
 		{"B", "Open saved-board picker"},
 		{"P", "Open project picker"},
-		{"v", "Toggle status / epic view"},
+		{"v", "Cycle status / epic / kanban view"},
+		{"O", "Outbox: queued offline changes (r send · dd discard)"},
+		{"u", "Undo last delete / transition (asks first)"},
 		{"?", "Toggle this help"},
 		{"q / ctrl+c", "Quit"},
 	}},
@@ -53,10 +56,15 @@ var helpGroups = []helpGroup{
 		{"t", "Transition"},
 		{"a", "Assign"},
 		{"p", "Priority"},
+		{"M", "Move to sprint"},
+		{"J / K", "Rank down / up (sprint and epic views)"},
+		{"h / l", "Previous / next column (kanban)"},
+		{"H / L", "Move card to previous / next column (kanban)"},
 		{"/", "Filter list"},
 		{"ctrl+s", "Search issues"},
 		{"ctrl+r", "Refresh"},
 		{"y k / y K / y s", "Yank key / URL / summary"},
+		{"o", "Open issue in browser"},
 	}},
 	{"Detail", []helpBind{
 		{"tab / shift+tab", "Next / previous section"},
@@ -66,13 +74,27 @@ var helpGroups = []helpGroup{
 		{"t", "Transition"},
 		{"a", "Assign"},
 		{"p", "Priority"},
+		{"M", "Move to sprint"},
+		{"F", "Edit custom field"},
+		{"L / C / V", "Edit labels / components / fix versions"},
 		{"c", "New comment"},
-		{"d", "Delete comment / worklog"},
+		{"d", "Delete comment / worklog / issue link"},
 		{"w", "Log work"},
 		{"l", "Link issue"},
 		{"n", "New sub-task (sub-tasks section)"},
+		{"s / enter", "Download / download and open attachment (attachments section)"},
+		{"A", "Attach a file"},
+		{"W", "Watch / unwatch"},
+		{"ctrl+w", "Manage watchers"},
+		{"f", "Filter history by field (history section)"},
 		{"gp", "Go to parent"},
+		{"n / N", "Next / previous link or issue key (description, comments)"},
+		{"enter", "Follow the selected link: issues open here, URLs in the browser"},
+		{"enter", "Open linked issue / sub-task (issue links, sub-tasks)"},
+		{"backspace", "Back to the previously viewed issue"},
+		{"o", "Open issue / linked issue / sub-task in browser"},
 		{"yy", "Yank focused text"},
+		{"I", "Toggle inline images"},
 		{"ctrl+r", "Refresh"},
 		{"esc", "Back"},
 	}},
@@ -86,6 +108,7 @@ var helpGroups = []helpGroup{
 		{"enter", "Confirm / submit"},
 		{"esc", "Cancel"},
 		{"tab / shift+tab", "Next / previous field"},
+		{"ctrl+g", "Edit description / comment in $VISUAL or $EDITOR"},
 	}},
 }
 
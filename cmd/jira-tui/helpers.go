@@ -3,51 +3,20 @@ package main
 import (
 	"fmt"
 	"log/slog"
+	"os/exec"
 	"slices"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/atotto/clipboard"
+	"github.com/oliverjhernandez/jira-tui/internal/adf"
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
 	"github.com/oliverjhernandez/jira-tui/internal/ui"
 )
 
-var priorityOrder = map[string]int{
-	"Critica":     1,
-	"Highest":     2,
-	"High":        3,
-	"Medium":      4,
-	"Low":         5,
-	"Lowest":      6,
-	"Por Definir": 7,
-}
-
-var statusOrder = map[string]int{
-	"Trabajando":               1,
-	"To Do":                    2,
-	"Selected for Development": 3,
-	"Backlog":                  4,
-	"Done":                     5,
-	"Cancelada":                6,
-	"Validación":               7,
-	"🔴 BLOQUEADO":              8,
-	"Ready to Deploy":          9,
-}
-
-var intransitStatuses = map[string]bool{
-	"Ready to Deploy": true,
-	"Validación":      true,
-	"🔴 BLOQUEADO":     true,
-}
-
-var closureStatuses = map[string]bool{
-	"Done":      true,
-	"Cancelada": true,
-}
-
 func filterIssues(issues []jira.Issue, filter string) []jira.Issue {
 	var filtered []jira.Issue
 
@@ -194,6 +163,17 @@ func (m model) getAbsoluteCursorLine() int {
 	return lines
 }
 
+// renderADF renders a description or comment body wrapped to width, with
+// mentions named from the users cache and screenshots drawn inline.
+func (m model) renderADF(doc *jira.ContentDoc, width int) string {
+	return adf.Render(doc, adf.Options{Width: width, Users: m.usersCache, Image: m.inlineImage})
+}
+
+// renderADFText is renderADF without images, for copying.
+func (m model) renderADFText(doc *jira.ContentDoc, width int) string {
+	return adf.Render(doc, adf.Options{Width: width, Users: m.usersCache})
+}
+
 func (m model) getCommentCursorLine() int {
 	lines := 0
 	width := m.detailLayout.leftColumnWidth
@@ -203,7 +183,7 @@ func (m model) getCommentCursorLine() int {
 
 		lines += 1
 
-		bodyText := jira.ExtractText(c.Body, width-ui.PanelOverheadWidth)
+		bodyText := m.renderADF(c.Body, width-2*ui.PanelOverheadWidth)
 		wrappedBody := ui.CommentBodyStyle.Width(width - ui.PanelOverheadWidth).Render(bodyText)
 		lines += lipgloss.Height(wrappedBody)
 
@@ -213,28 +193,28 @@ func (m model) getCommentCursorLine() int {
 	return lines
 }
 
-func sortSectionsIssues(sections []Section) {
+func (m model) sortSectionsIssues(sections []Section) {
 	for si := range sections {
-		slices.SortFunc(sections[si].Issues, func(a, b jira.Issue) int {
-			if statusOrder[a.Status] != statusOrder[b.Status] {
-				return statusOrder[a.Status] - statusOrder[b.Status]
+		slices.SortStableFunc(sections[si].Issues, func(a, b jira.Issue) int {
+			if ra, rb := m.statusRank(a), m.statusRank(b); ra != rb {
+				return ra - rb
 			}
-			return priorityOrder[a.Priority.Name] - priorityOrder[b.Priority.Name]
+			return m.priorityRank(a) - m.priorityRank(b)
 		})
 	}
 }
 
-func sortSectionsIssuesByPriority(sections []Section) {
+func (m model) sortSectionsIssuesByPriority(sections []Section) {
 	for si := range sections {
-		sort.Slice(sections[si].Issues, func(i, j int) bool {
-			return priorityOrder[sections[si].Issues[i].Priority.Name] < priorityOrder[sections[si].Issues[j].Priority.Name]
+		slices.SortStableFunc(sections[si].Issues, func(a, b jira.Issue) int {
+			return m.priorityRank(a) - m.priorityRank(b)
 		})
 	}
 }
 
-func sortIssuesByStatus(issues []jira.Issue) {
-	slices.SortFunc(issues, func(a, b jira.Issue) int {
-		return statusOrder[a.Status] - statusOrder[b.Status]
+func (m model) sortIssuesByStatus(issues []jira.Issue) {
+	slices.SortStableFunc(issues, func(a, b jira.Issue) int {
+		return m.statusRank(a) - m.statusRank(b)
 	})
 }
 
@@ -247,6 +227,48 @@ func findIndex(section focusedSection, order []focusedSection) int {
 	return 0
 }
 
+// browserCommand opens a URL in the system browser.
+var browserCommand = "xdg-open"
+
+// openInBrowserCmd hands url to browserCommand without waiting for it to exit.
+func openInBrowserCmd(url string) tea.Cmd {
+	return func() tea.Msg {
+		if err := exec.Command(browserCommand, url).Start(); err != nil {
+			return errMsg{fmt.Errorf("opening %s: %w", url, err)}
+		}
+		return nil
+	}
+}
+
+// browseURL is the web link for issueKey on the configured Jira site.
+func (m model) browseURL(issueKey string) string {
+	if m.client == nil {
+		return ""
+	}
+	return m.client.BrowseURL(issueKey)
+}
+
+// openIssueInBrowser opens issueKey's web page and reports it in the status bar.
+func (m *model) openIssueInBrowser(issueKey string) tea.Cmd {
+	url := m.browseURL(issueKey)
+	if url == "" {
+		return nil
+	}
+	m.setInfo("Opening " + issueKey + " in browser")
+	return tea.Batch(openInBrowserCmd(url), m.clearStatusAfter(clearMsgTimeout))
+}
+
+// linkedIssueKey returns the key of the issue on the other end of l.
+func linkedIssueKey(l jira.IssueLink) string {
+	if l.InwardIssue != nil {
+		return l.InwardIssue.Key
+	}
+	if l.OutwardIssue != nil {
+		return l.OutwardIssue.Key
+	}
+	return ""
+}
+
 func yankToClipboard(text string) {
 	err := clipboard.WriteAll(text)
 	if err != nil {
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// History: the issue's changelog in its own detail section, newest first.
+// Each entry is what one author changed at one time; f cycles a filter
+// through the fields that appear in it. The issue comes with the first page
+// of its changelog; the rest is fetched when the section is focused.
+
+// historyLoadedMsg carries the changelog pages fetched for issueKey. err is
+// set when paging stopped early; the entries fetched so far are still shown.
+type historyLoadedMsg struct {
+	tabID    int
+	issueKey string
+	entries  []jira.HistoryEntry
+	err      error
+}
+
+// historyFields lists the fields changed in entries, most recently changed
+// first.
+func historyFields(entries []jira.HistoryEntry) []string {
+	var fields []string
+	for _, e := range slices.Backward(entries) {
+		for _, it := range e.Items {
+			if !slices.Contains(fields, it.Field) {
+				fields = append(fields, it.Field)
+			}
+		}
+	}
+	return fields
+}
+
+// fieldTitle capitalizes Jira's lower-case system field names ("status")
+// for display; custom field names are already titled.
+func fieldTitle(field string) string {
+	r, size := utf8.DecodeRuneInString(field)
+	return string(unicode.ToUpper(r)) + field[size:]
+}
+
+// nextHistoryFilter is the field after current in the filter cycle, where ""
+// (every field) comes before the first field and after the last.
+func nextHistoryFilter(fields []string, current string) string {
+	i := slices.Index(fields, current)
+	if i+1 >= len(fields) {
+		return ""
+	}
+	return fields[i+1]
+}
+
+func (m model) buildHistoryContent(width int) string {
+	if m.activeIssue == nil {
+		return ""
+	}
+
+	var entries []string
+	for _, e := range slices.Backward(m.activeIssue.History) {
+		var items []string
+		for _, it := range e.Items {
+			if m.historyFilter != "" && it.Field != m.historyFilter {
+				continue
+			}
+			from, to := it.From, it.To
+			if from == "" {
+				from = "—"
+			}
+			if to == "" {
+				to = "—"
+			}
+			line := "  " + ui.DetailLabelStyle.Render(fieldTitle(it.Field)+": ") + from + " → " + to
+			items = append(items, ui.TruncateLongString(line, width))
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		author := ui.WorklogsAuthorStyle.Render(e.Author)
+		timestamp := ui.WorklogsTimestampStyle.Render(" • " + timeAgo(e.Created))
+		entries = append(entries, author+timestamp+"\n"+strings.Join(items, "\n")+"\n")
+	}
+
+	if len(entries) == 0 {
+		if m.historyFilter != "" {
+			return ui.StatusBarInfoStyle.Render("No changes to "+fieldTitle(m.historyFilter)) + "\n"
+		}
+		return ui.StatusBarInfoStyle.Render("No history") + "\n"
+	}
+	return strings.Join(entries, ui.SeparatorStyle.Render("  ────")+"\n\n")
+}
+
+func (m model) renderHistoryPanel(width int, height int) string {
+	label := "History"
+	if is := m.activeIssue; is != nil && len(is.History) < is.HistoryTotal {
+		label += fmt.Sprintf(" (%d of %d)", len(is.History), is.HistoryTotal)
+	}
+	if m.historyFilter != "" {
+		label += " · " + fieldTitle(m.historyFilter)
+	}
+	viewport := m.historyViewport.View()
+	return ui.RenderPanelWithLabel(label, viewport, width, height, m.focusedSection == historySection)
+}
+
+// setHistoryContent sizes the history viewport for the current layout and
+// fills it.
+func (m *model) setHistoryContent() {
+	m.historyViewport.SetWidth(m.detailLayout.leftColumnWidth)
+	m.historyViewport.SetHeight(m.detailLayout.historyHeight)
+	m.historyViewport.SetContent(m.buildHistoryContent(m.detailLayout.leftColumnWidth - ui.PanelOverheadWidth))
+}
+
+// cycleHistoryFilter shows only the next field's changes, or all of them
+// after the last field.
+func (m model) cycleHistoryFilter() model {
+	if m.activeIssue == nil {
+		return m
+	}
+	m.historyFilter = nextHistoryFilter(historyFields(m.activeIssue.History), m.historyFilter)
+	m.setHistoryContent()
+	m.historyViewport.GotoTop()
+	return m
+}
+
+// fetchRestOfHistoryCmd loads the whole changelog of the active issue when it
+// came with only part of it, once the history section is focused. Polls and
+// reloads only fetch the embedded page, so most detail loads never page
+// through the changelog.
+func (m *model) fetchRestOfHistoryCmd() tea.Cmd {
+	is := m.activeIssue
+	if is == nil || m.focusedSection != historySection || len(is.History) >= is.HistoryTotal || m.historyLoading == is.Key {
+		return nil
+	}
+	m.historyLoading = is.Key
+	m.loadingCount++
+
+	client, tabID, key := m.client, m.activeTabID(), is.Key
+	return func() tea.Msg {
+		msg := historyLoadedMsg{tabID: tabID, issueKey: key}
+		if client == nil {
+			msg.err = fmt.Errorf("jira client not initialized")
+			return msg
+		}
+		msg.entries, msg.err = client.GetChangelog(context.Background(), key, 0)
+		return msg
+	}
+}
+
+func (m model) handleHistoryLoaded(msg historyLoadedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	if m.historyLoading == msg.issueKey {
+		m.historyLoading = ""
+	}
+
+	idx, ok := m.tabIndexByID(msg.tabID)
+	if !ok {
+		return m, nil
+	}
+	is := m.tabs[idx].detail.activeIssue
+	if idx == m.activeTab {
+		is = m.activeIssue
+	}
+	// Drop pages for an issue that has since been left.
+	if is != nil && is.Key == msg.issueKey {
+		is.History = jira.MergeHistory(is.History, msg.entries)
+		if idx == m.activeTab {
+			m.setHistoryContent()
+		}
+	}
+
+	if msg.err != nil {
+		m.setError("fetching issue history", msg.err)
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+	return m, nil
+}
+
+// keepLoadedHistory carries the changelog pages fetched for prev over to
+// next, a reload of the same issue, when no change was made in between.
+func keepLoadedHistory(prev, next *jira.Issue) {
+	if prev == nil || next == nil || prev.Key != next.Key {
+		return
+	}
+	if prev.HistoryTotal == next.HistoryTotal && len(prev.History) > len(next.History) {
+		next.History = prev.History
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+var testHistory = []jira.HistoryEntry{
+	{ID: "1", Author: "Jane", Created: "2026-10-01T10:00:00.000+0000", Items: []jira.HistoryItem{
+		{Field: "status", From: "To Do", To: "In Progress"},
+	}},
+	{ID: "2", Author: "Ana", Created: "2026-10-02T10:00:00.000+0000", Items: []jira.HistoryItem{
+		{Field: "assignee", To: "Ana"},
+		{Field: "status", From: "In Progress", To: "To Do"},
+	}},
+}
+
+func newHistoryModel() model {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.activeIssue = &jira.Issue{Key: "DEV-1", History: testHistory}
+	m.mode = detailView
+	m.focusedSection = historySection
+	m.detailLayout = m.calculateDetailLayout()
+	m.setHistoryContent()
+	return m
+}
+
+func TestHistoryFields(t *testing.T) {
+	if got := strings.Join(historyFields(testHistory), ","); got != "assignee,status" {
+		t.Errorf("historyFields = %q, want the most recent first", got)
+	}
+	if got := nextHistoryFilter([]string{"a", "b"}, ""); got != "a" {
+		t.Errorf("after all comes the first field, got %q", got)
+	}
+	if got := nextHistoryFilter([]string{"a", "b"}, "b"); got != "" {
+		t.Errorf("after the last field comes all, got %q", got)
+	}
+}
+
+func TestBuildHistoryContent(t *testing.T) {
+	m := newHistoryModel()
+
+	content := ansi.Strip(m.buildHistoryContent(80))
+	if strings.Index(content, "Ana") > strings.Index(content, "Jane") {
+		t.Errorf("newest change should come first:\n%s", content)
+	}
+	for _, want := range []string{"Status: In Progress → To Do", "Assignee: — → Ana"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q:\n%s", want, content)
+		}
+	}
+
+	m.historyFilter = "assignee"
+	content = ansi.Strip(m.buildHistoryContent(80))
+	if strings.Contains(content, "Status") || strings.Contains(content, "Jane") {
+		t.Errorf("filtered history should only show assignee changes:\n%s", content)
+	}
+
+	m.historyFilter = "priority"
+	if got := ansi.Strip(m.buildHistoryContent(80)); !strings.Contains(got, "No changes to Priority") {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestHistoryFilterKey(t *testing.T) {
+	m := newHistoryModel()
+
+	next, _ := m.Update(keyPress("f"))
+	m = next.(model)
+	if m.historyFilter != "assignee" {
+		t.Errorf("historyFilter = %q, want assignee", m.historyFilter)
+	}
+	if !strings.Contains(ansi.Strip(m.renderHistoryPanel(80, 10)), "History · Assignee") {
+		t.Error("the panel label should name the filter")
+	}
+
+	next, _ = m.Update(keyPress("f"))
+	next, _ = next.(model).Update(keyPress("f"))
+	if got := next.(model).historyFilter; got != "" {
+		t.Errorf("cycling past the last field should show all, got %q", got)
+	}
+}
+
+func TestDetailReloadKeepsHistoryFilter(t *testing.T) {
+	m := newHistoryModel()
+	m.historyFilter = "status"
+	m.loadingCount = 1
+
+	next, _ := m.Update(issueDetailLoadedMsg{detail: &jira.Issue{Key: "DEV-1", History: testHistory}})
+	if got := next.(model).historyFilter; got != "status" {
+		t.Errorf("a refresh of the same issue should keep the filter, got %q", got)
+	}
+
+	m.loadingCount = 1
+	next, _ = m.Update(issueDetailLoadedMsg{detail: &jira.Issue{Key: "DEV-2"}})
+	if got := next.(model).historyFilter; got != "" {
+		t.Errorf("another issue should reset the filter, got %q", got)
+	}
+}
+
+func TestRestOfHistoryFetchedOnFocus(t *testing.T) {
+	m := newHistoryModel()
+	m.activeIssue.History = testHistory[:1]
+	m.activeIssue.HistoryTotal = 2
+	m.focusedSection = commentsSection
+
+	if m.fetchRestOfHistoryCmd() != nil {
+		t.Fatal("the changelog should only be paged with the history section focused")
+	}
+
+	next, cmd := m.updateDetailView(keyPress("tab"))
+	m = next.(model)
+	if cmd == nil || m.loadingCount != 1 || m.historyLoading != "DEV-1" {
+		t.Fatalf("focusing history should fetch the rest (loadingCount %d)", m.loadingCount)
+	}
+	if m.fetchRestOfHistoryCmd() != nil {
+		t.Error("the rest should not be fetched twice at once")
+	}
+	if label := ansi.Strip(m.renderHistoryPanel(60, 10)); !strings.Contains(label, "(1 of 2)") {
+		t.Errorf("panel should say the history is partial:\n%s", label)
+	}
+
+	// The pages overlap what the issue came with.
+	next, _ = m.Update(historyLoadedMsg{issueKey: "DEV-1", entries: testHistory})
+	m = next.(model)
+	if len(m.activeIssue.History) != 2 || m.historyLoading != "" || m.loadingCount != 0 {
+		t.Errorf("history = %d entries, loading %q/%d", len(m.activeIssue.History), m.historyLoading, m.loadingCount)
+	}
+
+	// A poll reload brings only the first page back; what was fetched stays.
+	reloaded := &jira.Issue{Key: "DEV-1", History: testHistory[:1], HistoryTotal: 2}
+	keepLoadedHistory(m.activeIssue, reloaded)
+	if len(reloaded.History) != 2 {
+		t.Errorf("reload kept %d entries, want 2", len(reloaded.History))
+	}
+	changed := &jira.Issue{Key: "DEV-1", History: testHistory[:1], HistoryTotal: 3}
+	keepLoadedHistory(m.activeIssue, changed)
+	if len(changed.History) != 1 {
+		t.Error("a changelog that grew should be fetched again")
+	}
+}
@@ -0,0 +1,419 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/config"
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/termimg"
+)
+
+// imagesKey turns inline images on and off in the detail view.
+const imagesKey = "I"
+
+// Screenshots are shrunk to fit this many cells.
+const (
+	thumbnailMaxCols = 60
+	thumbnailMaxRows = 16
+)
+
+// sixelSettleDelay is how long sixel images wait for the frame they're
+// drawn over to reach the terminal.
+const sixelSettleDelay = 100 * time.Millisecond
+
+// imageState tracks how, and whether, screenshots embedded in descriptions
+// and comments are drawn in the detail view.
+type imageState struct {
+	// mode is the inline_images setting; protocol is what it resolved to,
+	// None when the terminal can't draw images.
+	mode     string
+	protocol termimg.Protocol
+	// disabled is set with imagesKey.
+	disabled bool
+	cell     termimg.CellSize
+	// thumbnails are keyed by attachment ID. Entries are added when a
+	// download succeeds; failed ones are fetched again next time.
+	thumbnails map[string]*thumbnail
+	nextID     int
+	// layout is what the screen looked like when placed was worked out;
+	// placed is where the sixel images were last drawn.
+	layout sixelLayout
+	placed []termimg.Placement
+}
+
+// sixelLayout is what decides where the sixel blocks land on screen. While it
+// stays the same, the frame isn't searched for them again.
+type sixelLayout struct {
+	mode                viewMode
+	width, height       int
+	activeTab           int
+	issueKey, updated   string
+	layout              detailLayout
+	descY, commentsY    int
+	descLines, comLines int
+	images              int
+	disabled            bool
+}
+
+// thumbnail is an attachment's preview.
+type thumbnail struct {
+	img        image.Image
+	id         int
+	cols, rows int
+	// sixel is the encoded image, for the sixel protocol.
+	sixel string
+}
+
+type thumbnailLoadedMsg struct {
+	attachmentID string
+	img          image.Image
+	err          error
+}
+
+// sixelDrawMsg draws the sixel images laid out as placed, unless the
+// layout changed again while it waited.
+type sixelDrawMsg struct {
+	placed []termimg.Placement
+}
+
+// newImageState resolves the inline_images setting. "auto" starts from what
+// the environment tells; queryImageSupportCmd asks the terminal for the rest.
+func newImageState(mode string) imageState {
+	s := imageState{
+		mode:       mode,
+		cell:       termimg.DefaultCellSize,
+		thumbnails: make(map[string]*thumbnail),
+	}
+	if mode == config.InlineImagesAuto {
+		s.protocol = termimg.FromEnv(os.Getenv)
+	} else if p, err := termimg.ParseProtocol(mode); err == nil {
+		s.protocol = p
+	}
+	return s
+}
+
+// active reports whether images are drawn.
+func (s imageState) active() bool {
+	return s.protocol != termimg.None && !s.disabled
+}
+
+// ready returns t when it can be drawn.
+func (s imageState) ready(attachmentID string) (*thumbnail, bool) {
+	t, ok := s.thumbnails[attachmentID]
+	return t, ok
+}
+
+// queryImageSupportCmd asks the terminal for its cell size, to size images,
+// and, when the environment didn't settle it, whether it speaks sixel.
+func (m model) queryImageSupportCmd() tea.Cmd {
+	if m.images.mode == config.InlineImagesOff || m.images.mode == "" {
+		return nil
+	}
+	query := ansi.WindowOp(16) // report the cell size in pixels
+	if m.images.mode == config.InlineImagesAuto && m.images.protocol == termimg.None {
+		query += ansi.RequestPrimaryDeviceAttributes
+	}
+	return tea.Raw(query)
+}
+
+// handleDeviceAttributes switches "auto" to sixel when the terminal
+// advertises it.
+func (m model) handleDeviceAttributes(msg uv.PrimaryDeviceAttributesEvent) (tea.Model, tea.Cmd) {
+	if m.images.mode != config.InlineImagesAuto || m.images.protocol != termimg.None || !termimg.SupportsSixel(msg) {
+		return m, nil
+	}
+	m.images.protocol = termimg.Sixel
+	return m, m.fetchThumbnailsCmd()
+}
+
+// handleCellSize resizes the images drawn so far to the terminal's cells.
+func (m model) handleCellSize(msg uv.CellSizeEvent) (tea.Model, tea.Cmd) {
+	cell := termimg.CellSize{Width: msg.Width, Height: msg.Height}
+	if cell.Width <= 0 || cell.Height <= 0 || cell == m.images.cell {
+		return m, nil
+	}
+	m.images.cell = cell
+
+	var cmds []tea.Cmd
+	thumbnails := make(map[string]*thumbnail, len(m.images.thumbnails))
+	for id, t := range m.images.thumbnails {
+		t := *t
+		cmds = append(cmds, m.images.prepare(&t))
+		thumbnails[id] = &t
+	}
+	m.images.thumbnails = thumbnails
+	m.refreshADFContent()
+	return m, tea.Batch(cmds...)
+}
+
+// embeddedImages are the image attachments the active issue's description
+// and comments show. Jira names embedded files after the attachment.
+func (m model) embeddedImages() []jira.Attachment {
+	if m.activeIssue == nil {
+		return nil
+	}
+	docs := []*jira.ContentDoc{m.activeIssue.Description}
+	for _, c := range m.activeIssue.Comments {
+		docs = append(docs, c.Body)
+	}
+
+	var images []jira.Attachment
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		for _, media := range mediaNodes(doc.Content) {
+			if a, ok := m.mediaAttachment(media); ok && !slices.ContainsFunc(images, func(b jira.Attachment) bool { return b.ID == a.ID }) {
+				images = append(images, a)
+			}
+		}
+	}
+	return images
+}
+
+// mediaNodes lists the attrs of the files embedded in nodes.
+func mediaNodes(nodes []jira.ContentNode) []jira.NodeAttrs {
+	var media []jira.NodeAttrs
+	for _, n := range nodes {
+		if n.Type == "media" && n.Attrs != nil {
+			media = append(media, *n.Attrs)
+		}
+		media = append(media, mediaNodes(n.Content)...)
+	}
+	return media
+}
+
+// mediaAttachment finds the image attachment an embedded file shows. Media
+// IDs are attachment IDs on Server/DC; on Cloud they're media service IDs the
+// attachment list doesn't carry, so the file name is used, but only when one
+// attachment has it: a renamed or repeated name shows no image rather than
+// the wrong one.
+func (m model) mediaAttachment(media jira.NodeAttrs) (jira.Attachment, bool) {
+	if m.activeIssue == nil {
+		return jira.Attachment{}, false
+	}
+	var named []jira.Attachment
+	for _, a := range m.activeIssue.Attachments {
+		switch {
+		case media.ID != "" && a.ID == media.ID:
+			return a, a.IsImage()
+		case media.Alt != "" && a.Filename == media.Alt:
+			named = append(named, a)
+		}
+	}
+	if len(named) != 1 || !named[0].IsImage() {
+		return jira.Attachment{}, false
+	}
+	return named[0], true
+}
+
+// fetchThumbnailsCmd downloads the previews of the active issue's embedded
+// images that haven't been loaded yet.
+func (m model) fetchThumbnailsCmd() tea.Cmd {
+	if !m.images.active() {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, a := range m.embeddedImages() {
+		if _, ok := m.images.ready(a.ID); !ok {
+			cmds = append(cmds, m.fetchThumbnailCmd(a))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m model) fetchThumbnailCmd(a jira.Attachment) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return thumbnailLoadedMsg{attachmentID: a.ID, err: fmt.Errorf("jira client not initialized")}
+		}
+		var buf bytes.Buffer
+		if _, err := m.client.DownloadThumbnail(context.Background(), a, &buf); err != nil {
+			return thumbnailLoadedMsg{attachmentID: a.ID, err: err}
+		}
+		img, err := termimg.Decode(&buf)
+		return thumbnailLoadedMsg{attachmentID: a.ID, img: img, err: err}
+	}
+}
+
+// handleThumbnailLoaded gives the preview an image ID and redraws the
+// description and comments with it. Previews that fail keep the file name
+// and are fetched again with the issue.
+func (m model) handleThumbnailLoaded(msg thumbnailLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		slog.Warn("fetching thumbnail", "attachmentID", msg.attachmentID, "err", msg.err)
+		return m, nil
+	}
+	if _, ok := m.images.ready(msg.attachmentID); ok || m.images.nextID >= termimg.MaxID {
+		return m, nil
+	}
+	m.images.nextID++
+	t := &thumbnail{id: m.images.nextID, img: msg.img}
+	m.images.thumbnails = maps.Clone(m.images.thumbnails)
+	m.images.thumbnails[msg.attachmentID] = t
+
+	cmd := m.images.prepare(t)
+	m.refreshADFContent()
+	return m, cmd
+}
+
+// prepare sizes t to the cells and, for kitty, sends it to the terminal.
+func (s imageState) prepare(t *thumbnail) tea.Cmd {
+	t.cols, t.rows = termimg.Fit(t.img.Bounds().Size(), s.cell, thumbnailMaxCols, thumbnailMaxRows)
+	t.sixel = ""
+	if t.cols == 0 {
+		return nil
+	}
+
+	var err error
+	switch s.protocol {
+	case termimg.Kitty:
+		var seq string
+		if seq, err = termimg.KittyTransmit(t.id, t.img, t.cols, t.rows); err == nil {
+			return tea.Raw(seq)
+		}
+	case termimg.Sixel:
+		t.sixel, err = termimg.EncodeSixel(t.img, t.cols, t.rows, s.cell)
+	}
+	if err != nil {
+		slog.Warn("encoding thumbnail", "protocol", s.protocol, "err", err)
+		t.cols = 0
+	}
+	return nil
+}
+
+// inlineImage draws an embedded screenshot for the ADF renderer, or returns
+// "" to show its name only.
+func (m model) inlineImage(media jira.NodeAttrs, width int) string {
+	if !m.images.active() {
+		return ""
+	}
+	a, ok := m.mediaAttachment(media)
+	if !ok {
+		return ""
+	}
+	t, ok := m.images.ready(a.ID)
+	if !ok || t.cols == 0 || t.cols > width {
+		return ""
+	}
+	switch m.images.protocol {
+	case termimg.Kitty:
+		return termimg.KittyBlock(t.id, t.cols, t.rows)
+	case termimg.Sixel:
+		return termimg.SixelBlock(t.id, t.cols, t.rows)
+	}
+	return ""
+}
+
+// refreshADFContent re-renders the description and comments in place.
+func (m *model) refreshADFContent() {
+	if m.activeIssue == nil {
+		return
+	}
+	m.descViewport.SetContent(m.buildDescriptionContent(m.detailLayout.leftColumnWidth))
+	m.commentsViewport.SetContent(m.buildCommentsContent(m.detailLayout.leftColumnWidth))
+}
+
+// toggleImages turns inline images off, or back on.
+func (m model) toggleImages() (tea.Model, tea.Cmd) {
+	if m.images.protocol == termimg.None {
+		m.setInfo("This terminal can't show images inline (set inline_images to force kitty or sixel)")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+	m.images.disabled = !m.images.disabled
+	m.refreshADFContent()
+	if m.images.disabled {
+		m.setInfo("Inline images off")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+	m.setInfo("Inline images on")
+	return m, tea.Batch(m.fetchThumbnailsCmd(), m.clearStatusAfter(clearMsgTimeout))
+}
+
+// currentSixelLayout describes the screen as far as the sixel blocks care.
+func (m model) currentSixelLayout() sixelLayout {
+	l := sixelLayout{
+		mode:      m.mode,
+		width:     m.windowWidth,
+		height:    m.windowHeight,
+		activeTab: m.activeTab,
+		layout:    m.detailLayout,
+		descY:     m.descViewport.YOffset(),
+		commentsY: m.commentsViewport.YOffset(),
+		descLines: m.descViewport.TotalLineCount(),
+		comLines:  m.commentsViewport.TotalLineCount(),
+		images:    m.images.nextID,
+		disabled:  m.images.disabled,
+	}
+	if m.activeIssue != nil {
+		l.issueKey, l.updated = m.activeIssue.Key, m.activeIssue.Updated
+	}
+	return l
+}
+
+// syncSixels keeps the sixel images drawn over their blocks. The terminal
+// draws sixels over the text, so whenever the blocks on screen move the
+// screen is cleared and they're redrawn once the new frame is out. Finding
+// the blocks renders the whole view, so it's only done when the layout,
+// scrolling or content changed.
+func (m model) syncSixels() (model, tea.Cmd) {
+	if m.images.protocol != termimg.Sixel {
+		return m, nil
+	}
+	layout := m.currentSixelLayout()
+	if layout == m.images.layout {
+		return m, nil
+	}
+	m.images.layout = layout
+
+	var placed []termimg.Placement
+	if m.images.active() && m.mode == detailView {
+		heights := make(map[int]int)
+		for _, t := range m.images.thumbnails {
+			if t.sixel != "" {
+				heights[t.id] = t.rows
+			}
+		}
+		if len(heights) > 0 {
+			placed = termimg.FindSixelBlocks(m.renderView(), heights)
+		}
+	}
+	if slices.Equal(placed, m.images.placed) {
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	if len(m.images.placed) > 0 {
+		cmds = append(cmds, tea.ClearScreen)
+	}
+	m.images.placed = placed
+	if len(placed) > 0 {
+		cmds = append(cmds, tea.Tick(sixelSettleDelay, func(time.Time) tea.Msg {
+			return sixelDrawMsg{placed: placed}
+		}))
+	}
+	return m, tea.Sequence(cmds...)
+}
+
+func (m model) handleSixelDraw(msg sixelDrawMsg) (tea.Model, tea.Cmd) {
+	if !slices.Equal(msg.placed, m.images.placed) {
+		return m, nil
+	}
+	sixels := make(map[int]string)
+	for _, t := range m.images.thumbnails {
+		sixels[t.id] = t.sixel
+	}
+	return m, tea.Raw(termimg.DrawSixels(msg.placed, func(id int) string { return sixels[id] }))
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"image"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi/kitty"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/termimg"
+)
+
+// newImageModel is a detail view of an issue whose description embeds the
+// screenshot attachment 10.
+func newImageModel(mode string) model {
+	m := newTabModel([]Tab{{}}, 0)
+	m.images = newImageState(mode)
+	m.mode = detailView
+	m.activeIssue = &jira.Issue{
+		Key: "DEV-1",
+		Description: &jira.ContentDoc{Content: []jira.ContentNode{
+			{Type: "paragraph", Content: []jira.ContentNode{{Type: "text", Text: "Broken:"}}},
+			{Type: "mediaSingle", Content: []jira.ContentNode{
+				{Type: "media", Attrs: &jira.NodeAttrs{Alt: "shot.png"}},
+			}},
+		}},
+		Attachments: []jira.Attachment{
+			{ID: "10", Filename: "shot.png", MimeType: "image/png"},
+			{ID: "11", Filename: "log.txt", MimeType: "text/plain"},
+		},
+	}
+	m.detailLayout = m.calculateDetailLayout()
+	m.descViewport.SetWidth(m.detailLayout.leftColumnWidth)
+	m.descViewport.SetHeight(m.detailLayout.descHeight)
+	m.refreshADFContent()
+	return m
+}
+
+// loadThumbnail fetches the previews m asks for and delivers one image for
+// each.
+func loadThumbnail(t *testing.T, m model) (model, tea.Cmd) {
+	t.Helper()
+	if m.fetchThumbnailsCmd() == nil {
+		t.Fatal("expected the embedded screenshot to be fetched")
+	}
+	if len(m.images.thumbnails) != 0 {
+		t.Fatalf("thumbnails = %v, want nothing recorded until the download is in", m.images.thumbnails)
+	}
+	next, cmd := m.handleThumbnailLoaded(thumbnailLoadedMsg{attachmentID: "10", img: image.NewRGBA(image.Rect(0, 0, 100, 40))})
+	m = next.(model)
+	if _, ok := m.images.ready("10"); !ok || len(m.images.thumbnails) != 1 {
+		t.Fatalf("thumbnails = %v, want only attachment 10", m.images.thumbnails)
+	}
+	if m.fetchThumbnailsCmd() != nil {
+		t.Error("a loaded thumbnail should not be fetched again")
+	}
+	return m, cmd
+}
+
+func TestNewImageState(t *testing.T) {
+	t.Setenv("KITTY_WINDOW_ID", "")
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("TERM", "xterm-kitty")
+	if p := newImageState("auto").protocol; p != termimg.Kitty {
+		t.Errorf("auto under kitty = %v", p)
+	}
+	t.Setenv("TERM", "xterm-256color")
+	if p := newImageState("auto").protocol; p != termimg.None {
+		t.Errorf("auto under xterm = %v, want none until the terminal answers", p)
+	}
+	if p := newImageState("sixel").protocol; p != termimg.Sixel {
+		t.Errorf("sixel = %v", p)
+	}
+	if s := newImageState("off"); s.protocol != termimg.None || s.active() {
+		t.Errorf("off = %v", s.protocol)
+	}
+}
+
+func TestDeviceAttributesEnableSixel(t *testing.T) {
+	m := newImageModel("auto")
+	m.images.protocol = termimg.None
+	next, _ := m.handleDeviceAttributes(uv.PrimaryDeviceAttributesEvent{62, 4, 22})
+	if p := next.(model).images.protocol; p != termimg.Sixel {
+		t.Errorf("protocol = %v, want sixel from DA1", p)
+	}
+
+	m = newImageModel("off")
+	next, _ = m.handleDeviceAttributes(uv.PrimaryDeviceAttributesEvent{62, 4})
+	if p := next.(model).images.protocol; p != termimg.None {
+		t.Errorf("protocol = %v, want off to stay off", p)
+	}
+}
+
+func TestKittyThumbnailRendersInline(t *testing.T) {
+	m := newImageModel("kitty")
+	if strings.ContainsRune(m.descViewport.View(), kitty.Placeholder) {
+		t.Fatal("no image should be drawn before the thumbnail loads")
+	}
+
+	m, cmd := loadThumbnail(t, m)
+	if cmd == nil {
+		t.Fatal("expected the image to be sent to the terminal")
+	}
+	if raw, ok := cmd().(tea.RawMsg); !ok || !strings.HasPrefix(raw.Msg.(string), "\x1b_G") {
+		t.Errorf("cmd = %v, want a kitty graphics sequence", raw)
+	}
+	view := m.descViewport.View()
+	if !strings.ContainsRune(view, kitty.Placeholder) || !strings.Contains(view, "shot.png") {
+		t.Errorf("description should show the image over its name:\n%s", view)
+	}
+	if strings.ContainsRune(m.renderADFText(m.activeIssue.Description, 60), kitty.Placeholder) {
+		t.Error("copied text should not carry the image")
+	}
+
+	next, _ := m.updateDetailView(keyPress(imagesKey))
+	m = next.(model)
+	if !m.images.disabled || strings.ContainsRune(m.descViewport.View(), kitty.Placeholder) {
+		t.Error("the toggle should turn images off")
+	}
+	next, _ = m.updateDetailView(keyPress(imagesKey))
+	if strings.Count(next.(model).descViewport.View(), string(kitty.Placeholder)) == 0 {
+		t.Error("the toggle should turn images back on")
+	}
+}
+
+func TestMediaAttachment(t *testing.T) {
+	m := newImageModel("kitty")
+	m.activeIssue.Attachments = []jira.Attachment{
+		{ID: "10", Filename: "shot.png", MimeType: "image/png"},
+		{ID: "11", Filename: "dup.png", MimeType: "image/png"},
+		{ID: "12", Filename: "dup.png", MimeType: "image/png"},
+		{ID: "13", Filename: "log.txt", MimeType: "text/plain"},
+	}
+	tests := []struct {
+		name  string
+		media jira.NodeAttrs
+		want  string
+	}{
+		{"by id", jira.NodeAttrs{ID: "12", Alt: "renamed.png"}, "12"},
+		{"unique name", jira.NodeAttrs{ID: "6f1c-uuid", Alt: "shot.png"}, "10"},
+		{"repeated name", jira.NodeAttrs{Alt: "dup.png"}, ""},
+		{"renamed", jira.NodeAttrs{ID: "6f1c-uuid", Alt: "old.png"}, ""},
+		{"not an image", jira.NodeAttrs{ID: "13"}, ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if a, ok := m.mediaAttachment(tt.media); ok {
+			got = a.ID
+		}
+		if got != tt.want {
+			t.Errorf("%s: matched %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestThumbnailFailureKeepsName(t *testing.T) {
+	m := newImageModel("kitty")
+	next, cmd := m.handleThumbnailLoaded(thumbnailLoadedMsg{attachmentID: "10", err: errors.New("boom")})
+	m = next.(model)
+	if cmd != nil || strings.ContainsRune(m.descViewport.View(), kitty.Placeholder) {
+		t.Error("a failed thumbnail should not be drawn")
+	}
+	if m.fetchThumbnailsCmd() == nil {
+		t.Error("a failed thumbnail should be fetched again")
+	}
+	if !strings.Contains(m.descViewport.View(), "[📎 shot.png]") {
+		t.Errorf("the name should stay:\n%s", m.descViewport.View())
+	}
+}
+
+func TestSixelThumbnailDrawnOverFrame(t *testing.T) {
+	m := newImageModel("sixel")
+	m, _ = loadThumbnail(t, m)
+
+	if content := m.View().Content; strings.ContainsRune(content, kitty.Placeholder) {
+		t.Error("sixel markers should be blanked in the frame")
+	}
+
+	m, cmd := m.syncSixels()
+	if len(m.images.placed) != 1 || cmd == nil {
+		t.Fatalf("placed = %v, want the screenshot laid out", m.images.placed)
+	}
+	if m2, cmd := m.syncSixels(); cmd != nil || len(m2.images.placed) != 1 {
+		t.Error("an unchanged layout should not be redrawn")
+	}
+	// The layout is all that's compared: placements that went stale without
+	// it changing aren't looked for again.
+	stale := m
+	stale.images.placed = nil
+	if _, cmd := stale.syncSixels(); cmd != nil {
+		t.Error("the frame should not be searched while the layout is unchanged")
+	}
+
+	_, cmd = m.handleSixelDraw(sixelDrawMsg{placed: m.images.placed})
+	if raw, ok := cmd().(tea.RawMsg); !ok || !strings.Contains(raw.Msg.(string), "\x1bP0;1q") {
+		t.Errorf("cmd = %v, want the sixel drawn", raw)
+	}
+	if _, cmd := m.handleSixelDraw(sixelDrawMsg{}); cmd != nil {
+		t.Error("a stale layout should not be drawn")
+	}
+
+	// Leaving the detail view clears the images off the screen.
+	m.mode = listView
+	if m, cmd = m.syncSixels(); len(m.images.placed) != 0 || cmd == nil {
+		t.Errorf("placed = %v, want the images cleared", m.images.placed)
+	}
+}
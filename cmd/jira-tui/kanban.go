@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Kanban view (groupColumns): one column per status, side by side, in the
+// order the project's statuses come from GetStatuses. Each column is a
+// Section, so sectionCursor picks the column and cursor the card in it.
+
+const kanbanMinColumnWidth = 28
+
+// groupByStatusColumns makes a column for every status of the projects on the
+// board (empty ones included, so cards can be moved there), merged by name
+// across projects. Statuses Jira didn't list for a project get a trailing
+// column of their own.
+func (m model) groupByStatusColumns(issues []jira.Issue) []Section {
+	var columns []Section
+	index := make(map[string]int)
+	column := func(name, categoryKey string) int {
+		key := strings.ToLower(name)
+		if i, ok := index[key]; ok {
+			return i
+		}
+		index[key] = len(columns)
+		columns = append(columns, Section{Name: name, CategoryKey: categoryKey})
+		return len(columns) - 1
+	}
+
+	seen := make(map[string]bool)
+	for _, is := range issues {
+		if seen[is.Project.ID] {
+			continue
+		}
+		seen[is.Project.ID] = true
+		for _, st := range m.statuses[is.Project.ID] {
+			column(st.Name, st.StatusCategory.Key)
+		}
+	}
+
+	for _, is := range issues {
+		i := column(is.Status, "other")
+		columns[i].Issues = append(columns[i].Issues, is)
+	}
+	return columns
+}
+
+// columnWindow returns the first visible column and how many fit in width,
+// scrolled so the cursor's column is visible.
+func columnWindow(n, cursor, width int) (first, count int) {
+	count = max(1, min(n, width/kanbanMinColumnWidth))
+	first = max(0, min(cursor-count+1, n-count))
+	return first, count
+}
+
+// renderColumnsHeader is the pinned header of the kanban view: the visible
+// columns' status names and card counts, over a rule.
+func (m model) renderColumnsHeader() string {
+	cols := m.navSections()
+	width := m.listLayout.panelContentWidth
+	first, count := columnWindow(len(cols), m.sectionCursor, width)
+	colWidth := width / count
+
+	var cells []string
+	for ci := first; ci < first+count && ci < len(cols); ci++ {
+		title := fmt.Sprintf("%s (%d)", cols[ci].Name, len(cols[ci].Issues))
+		style := ui.ColumnHeaderStyle
+		if ci == m.sectionCursor {
+			style = style.Foreground(ui.ThemeFg)
+		}
+		cells = append(cells, ui.PadCell("  "+style.Render(ui.TruncateLongString(title, colWidth-3)), colWidth))
+	}
+	if first > 0 {
+		cells[0] = ui.PadCell(ui.DimTextStyle.Render("‹ ")+strings.TrimPrefix(cells[0], "  "), colWidth)
+	}
+	header := strings.Join(cells, "")
+	rule := ui.ColumnHeaderRuleStyle.Render(strings.Repeat("─", max(width, lipgloss.Width(header))))
+	return header + "\n" + rule
+}
+
+// buildColumnsContent renders the cards of the visible columns, one line per
+// card.
+func (m model) buildColumnsContent() string {
+	cols := m.navSections()
+	if len(cols) == 0 {
+		return ""
+	}
+
+	width := m.listLayout.panelContentWidth
+	first, count := columnWindow(len(cols), m.sectionCursor, width)
+	colWidth := width / count
+
+	rendered := make([]string, 0, count)
+	for ci := first; ci < first+count && ci < len(cols); ci++ {
+		rendered = append(rendered, m.renderColumn(cols[ci], ci, colWidth))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+func (m model) renderColumn(s Section, ci, width int) string {
+	var lines []string
+	for ii, is := range s.Issues {
+		selected := ci == m.sectionCursor && ii == m.cursor
+		card := ui.PadCell(is.Key+" "+is.Summary, width-3)
+		style := ui.NormalRowStyle
+		switch {
+		case selected:
+			style = ui.SelectedRowStyle
+		case m.isClosed(is):
+			style = ui.DimTextStyle
+		}
+		lines = append(lines, rowPrefix(selected, m.isPending(is.Key))+style.Render(card))
+	}
+	if len(s.Issues) == 0 {
+		lines = append(lines, "  "+ui.DimTextStyle.Render("—"))
+	}
+	return lipgloss.NewStyle().Width(width).MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// updateColumnsKeys handles the keys that mean something else in the kanban
+// view: j/k stay within the column, h/l switch columns and H/L move the card.
+// ok is false for keys the list view should handle as usual.
+func (m model) updateColumnsKeys(key string) (_ tea.Model, _ tea.Cmd, ok bool) {
+	switch key {
+	case "j", "down":
+		m.columnStep(1)
+	case "k", "up":
+		m.columnStep(-1)
+	case "ctrl+d":
+		m.columnStep(m.listViewport.Height() / 2)
+	case "ctrl+u":
+		m.columnStep(-m.listViewport.Height() / 2)
+	case "h", "left":
+		m.switchColumn(-1)
+	case "l", "right":
+		m.switchColumn(1)
+	case "H":
+		next, cmd := m.moveCard(-1)
+		return next, cmd, true
+	case "L":
+		next, cmd := m.moveCard(1)
+		return next, cmd, true
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// columnStep moves the cursor n cards down (or up) its column and keeps it on
+// screen.
+func (m *model) columnStep(n int) {
+	cols := m.navSections()
+	if m.sectionCursor < 0 || m.sectionCursor >= len(cols) || len(cols[m.sectionCursor].Issues) == 0 {
+		return
+	}
+	issues := cols[m.sectionCursor].Issues
+	m.cursor = max(0, min(m.cursor+n, len(issues)-1))
+	m.selectedIssue = &issues[m.cursor]
+	m.listViewport.SetContent(m.buildListContent())
+	m.scrollToColumnCursor()
+}
+
+// switchColumn moves to the next non-empty column in dir, keeping the row if
+// the column is long enough.
+func (m *model) switchColumn(dir int) {
+	cols := m.navSections()
+	for ci := m.sectionCursor + dir; ci >= 0 && ci < len(cols); ci += dir {
+		if len(cols[ci].Issues) == 0 {
+			continue
+		}
+		m.sectionCursor = ci
+		m.cursor = min(m.cursor, len(cols[ci].Issues)-1)
+		m.selectedIssue = &cols[ci].Issues[m.cursor]
+		m.listViewport.SetContent(m.buildListContent())
+		m.scrollToColumnCursor()
+		return
+	}
+}
+
+func (m *model) scrollToColumnCursor() {
+	offset, height := m.listViewport.YOffset(), m.listViewport.Height()
+	switch {
+	case m.cursor < offset:
+		m.listViewport.SetYOffset(m.cursor)
+	case m.cursor >= offset+height:
+		m.listViewport.SetYOffset(m.cursor - height + 1)
+	}
+}
+
+// moveCard transitions the selected card to the status of the column next to
+// it, picking the transition that leads there. Transitions are fetched first
+// when they aren't cached; the move resumes in resumeCardMove.
+func (m model) moveCard(dir int) (tea.Model, tea.Cmd) {
+	cols := m.navSections()
+	target := m.sectionCursor + dir
+	issue, ok := m.currentIssue()
+	if !ok || target < 0 || target >= len(cols) {
+		return m, nil
+	}
+
+	if issue.Description == nil {
+		m.setErrorMsg("Cannot transition, missing description")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+	if issue.OriginalEstimate == "" {
+		m.setErrorMsg("Cannot transition, missing original estimate")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+
+	pending := *issue
+	m.pendingIssue = &pending
+	m.pendingMoveStatus = cols[target].Name
+	m.movingCard = true
+
+	if cached, ok := m.transitionCache[issue.Key][issue.Status]; ok && len(cached) > 0 {
+		return m.resumeCardMove(cached)
+	}
+	m.loadingCount++
+	return m, m.fetchTransitionsCmd(issue.Key, issue.Status)
+}
+
+// resumeCardMove finishes a card move once the issue's transitions are known.
+func (m model) resumeCardMove(transitions []jira.Transition) (tea.Model, tea.Cmd) {
+	status := m.pendingMoveStatus
+	m.pendingMoveStatus = ""
+
+	t, ok := transitionTo(transitions, status)
+	if !ok {
+		m.setErrorMsg(fmt.Sprintf("No transition from %s to %s", m.pendingIssue.Status, status))
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+	m.previousMode = m.mode
+	return m.routeTransition(t)
+}
+
+// transitionTo finds the transition whose target status is status.
+func transitionTo(transitions []jira.Transition, status string) (jira.Transition, bool) {
+	for _, t := range transitions {
+		if strings.EqualFold(t.Name, status) {
+			return t, true
+		}
+	}
+	return jira.Transition{}, false
+}
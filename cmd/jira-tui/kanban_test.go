@@ -0,0 +1,204 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func newKanbanModel() model {
+	m := newTabModel([]Tab{{id: 0, grouping: groupColumns, board: boardState{jql: "a"}}}, 0)
+	m.statuses = map[string][]jira.Status{
+		"P": {
+			{Name: "To Do", StatusCategory: jira.StatusCategory{Key: "new"}},
+			{Name: "In Progress", StatusCategory: jira.StatusCategory{Key: "indeterminate"}},
+			{Name: "Review", StatusCategory: jira.StatusCategory{Key: "indeterminate"}},
+			{Name: "Done", StatusCategory: jira.StatusCategory{Key: "done"}},
+		},
+	}
+	desc := &jira.ContentDoc{Type: "doc"}
+	m.issues = []jira.Issue{
+		{Key: "DEV-1", Status: "To Do", Description: desc, OriginalEstimate: "3600", Project: jira.Project{ID: "P"}},
+		{Key: "DEV-2", Status: "To Do", Description: desc, OriginalEstimate: "3600", Project: jira.Project{ID: "P"}},
+		{Key: "DEV-3", Status: "Done", Description: desc, OriginalEstimate: "3600", Project: jira.Project{ID: "P"}},
+	}
+	m.transitionCache = make(map[string]map[string][]jira.Transition)
+	m.listLayout = m.calculateListLayout()
+	m.refreshBoard("DEV-1")
+	return m
+}
+
+func TestGroupByStatusColumns(t *testing.T) {
+	m := newKanbanModel()
+	m.issues = append(m.issues, jira.Issue{Key: "DEV-4", Status: "Archived", Project: jira.Project{ID: "P"}})
+
+	cols := m.groupByStatusColumns(m.issues)
+	var names []string
+	for _, c := range cols {
+		names = append(names, c.Name)
+	}
+	want := []string{"To Do", "In Progress", "Review", "Done", "Archived"}
+	if len(names) != len(want) {
+		t.Fatalf("columns = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("columns = %v, want %v (workflow order, unknown last)", names, want)
+		}
+	}
+	if len(cols[0].Issues) != 2 || len(cols[1].Issues) != 0 || cols[3].Issues[0].Key != "DEV-3" {
+		t.Errorf("cards not placed by status: %+v", cols)
+	}
+	if cols[2].CategoryKey != "indeterminate" {
+		t.Errorf("columns keep their status category for the info panel, got %q", cols[2].CategoryKey)
+	}
+}
+
+func TestKanbanSortsWhenBuilt(t *testing.T) {
+	m := newKanbanModel()
+	m.priorities = []jira.Priority{{Name: "High"}, {Name: "Low"}}
+	m.issues[0].Priority = jira.Priority{Name: "Low"}
+	m.issues[1].Priority = jira.Priority{Name: "High"}
+	m.refreshBoard("DEV-1")
+
+	col := m.sections[0].Issues
+	if col[0].Key != "DEV-2" || col[1].Key != "DEV-1" {
+		t.Fatalf("column = %s,%s, want sorted by priority", col[0].Key, col[1].Key)
+	}
+	if m.sections[m.sectionCursor].Issues[m.cursor].Key != "DEV-1" {
+		t.Errorf("cursor %d/%d should still point at DEV-1", m.sectionCursor, m.cursor)
+	}
+
+	m.buildColumnsContent()
+	if col := m.sections[0].Issues; col[0].Key != "DEV-2" {
+		t.Error("rendering should not reorder columns")
+	}
+}
+
+func TestColumnWindow(t *testing.T) {
+	tests := []struct {
+		n, cursor, width int
+		first, count     int
+	}{
+		{4, 0, 200, 0, 4},
+		{6, 0, 84, 0, 3},
+		{6, 4, 84, 2, 3},
+		{6, 5, 84, 3, 3},
+		{3, 0, 10, 0, 1},
+	}
+	for _, tt := range tests {
+		first, count := columnWindow(tt.n, tt.cursor, tt.width)
+		if first != tt.first || count != tt.count {
+			t.Errorf("columnWindow(%d, %d, %d) = %d, %d, want %d, %d", tt.n, tt.cursor, tt.width, first, count, tt.first, tt.count)
+		}
+	}
+}
+
+func TestKanbanNavigation(t *testing.T) {
+	m := newKanbanModel()
+
+	next, _ := m.Update(keyPress("j"))
+	m = next.(model)
+	if m.sectionCursor != 0 || m.selectedIssue.Key != "DEV-2" {
+		t.Fatalf("j should move down the column, at %d/%d", m.sectionCursor, m.cursor)
+	}
+	next, _ = m.Update(keyPress("j"))
+	m = next.(model)
+	if m.sectionCursor != 0 || m.selectedIssue.Key != "DEV-2" {
+		t.Fatalf("j must not leave the column, at %d/%d", m.sectionCursor, m.cursor)
+	}
+
+	// l skips the empty In Progress and Review columns.
+	next, _ = m.Update(keyPress("l"))
+	m = next.(model)
+	if m.sectionCursor != 3 || m.cursor != 0 || m.selectedIssue.Key != "DEV-3" {
+		t.Fatalf("l should land on Done/DEV-3, at %d/%d", m.sectionCursor, m.cursor)
+	}
+	next, _ = m.Update(keyPress("h"))
+	m = next.(model)
+	if m.sectionCursor != 0 {
+		t.Errorf("h should go back to To Do, at %d", m.sectionCursor)
+	}
+}
+
+func TestMoveCardPicksTransition(t *testing.T) {
+	m := newKanbanModel()
+	m.transitionCache["DEV-1"] = map[string][]jira.Transition{
+		"To Do": {{ID: "11", Name: "Done"}, {ID: "21", Name: "In Progress"}},
+	}
+
+	next, cmd := m.Update(keyPress("L"))
+	nm := next.(model)
+	if cmd == nil {
+		t.Fatal("expected the transition to be posted")
+	}
+	if nm.mode != listView {
+		t.Errorf("mode = %v, moving a card should stay on the board", nm.mode)
+	}
+	if nm.issues[0].Status != "In Progress" {
+		t.Errorf("card should move optimistically, status %q", nm.issues[0].Status)
+	}
+
+	nm.loadingCount = 1
+	next, _ = nm.Update(transitionPostedMsg{})
+	nm = next.(model)
+	if nm.mode != listView || nm.movingCard {
+		t.Errorf("mode = %v, movingCard = %v: a posted move should stay on the board", nm.mode, nm.movingCard)
+	}
+}
+
+func TestMoveCardWithoutMatchingTransition(t *testing.T) {
+	m := newKanbanModel()
+	m.transitionCache["DEV-1"] = map[string][]jira.Transition{"To Do": {{ID: "11", Name: "Done"}}}
+
+	next, _ := m.Update(keyPress("L"))
+	nm := next.(model)
+	if nm.statusMessage.msgType != errStatusBarMsg || nm.issues[0].Status != "To Do" {
+		t.Errorf("expected an error and no change, got %q / %q", nm.statusMessage.content, nm.issues[0].Status)
+	}
+}
+
+func TestMoveCardResumesAfterTransitionsLoad(t *testing.T) {
+	m := newKanbanModel()
+
+	next, cmd := m.Update(keyPress("L"))
+	m = next.(model)
+	if cmd == nil || m.pendingMoveStatus != "In Progress" {
+		t.Fatalf("expected a transitions fetch for the move, pending %q", m.pendingMoveStatus)
+	}
+
+	next, _ = m.Update(transitionsLoadedMsg{
+		issueKey:    "DEV-1",
+		status:      "To Do",
+		transitions: []jira.Transition{{ID: "21", Name: "In Progress"}},
+	})
+	nm := next.(model)
+	if nm.pendingMoveStatus != "" || nm.issues[0].Status != "In Progress" {
+		t.Errorf("move should resume: pending %q, status %q", nm.pendingMoveStatus, nm.issues[0].Status)
+	}
+}
+
+func TestToggleGroupingCycles(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	for _, want := range []listGrouping{groupEpic, groupColumns, groupStatus} {
+		next, _ := m.Update(keyPress("v"))
+		m = next.(model)
+		if got := m.currentGrouping(); got != want {
+			t.Fatalf("grouping = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestInfoPanelCountsEveryColumnOfACategory(t *testing.T) {
+	m := newKanbanModel()
+	m.issues[0].Status = "In Progress"
+	m.issues[1].Status = "Review"
+	m.refreshBoard("DEV-1")
+
+	if got := ansi.Strip(m.renderInfoPanel()); !strings.Contains(got, "In Progress: 2") {
+		t.Errorf("info panel = %q, want both in-progress columns counted", got)
+	}
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Labels, components and fix versions: shown as chips in the metadata panel
+// and edited in one multi-select modal (L, C and V in the detail view) that
+// autocompletes from the values already in use. Saving sends only what was
+// added and removed, so concurrent edits by others aren't overwritten.
+
+const (
+	listFieldModalWScale = 0.5
+	listFieldModalHScale = 0.6
+	// listFieldMaxRows caps the suggestions shown under the input.
+	listFieldMaxRows = 12
+)
+
+// chipStyle is how values of field render in the metadata panel.
+func chipStyle(field jira.ListField) lipgloss.Style {
+	switch field {
+	case jira.ComponentsField:
+		return ui.ComponentChipStyle
+	case jira.FixVersionsField:
+		return ui.VersionChipStyle
+	default:
+		return ui.LabelChipStyle
+	}
+}
+
+// renderChipsRow is the metadata line with the issue's labels, components and
+// fix versions, cut to width.
+func renderChipsRow(issue jira.Issue, width int) string {
+	var parts []string
+	for _, f := range []jira.ListField{jira.LabelsField, jira.ComponentsField, jira.FixVersionsField} {
+		parts = append(parts, ui.DetailLabelStyle.Render(f.Title()+": ")+ui.RenderChips(issue.ListValues(f), chipStyle(f)))
+	}
+	return ansi.Truncate(strings.Join(parts, "   "), width, "…")
+}
+
+type listOptionsLoadedMsg struct {
+	field      jira.ListField
+	projectKey string
+	options    []string
+}
+
+type listFieldUpdatedMsg struct {
+	issueKey string
+	field    jira.ListField
+}
+
+// ListFieldEditData is the state of the multi-select modal: the values the
+// issue had, the ones now selected, and what's known to pick from.
+type ListFieldEditData struct {
+	field      jira.ListField
+	issueKey   string
+	projectKey string
+	original   []string
+	selected   []string
+	options    []string
+	input      textinput.Model
+	cursor     int
+}
+
+func NewListFieldEditData(field jira.ListField, issue jira.Issue, options []string) *ListFieldEditData {
+	ti := textinput.New()
+	ti.Placeholder = "Type to filter"
+	if field == jira.LabelsField {
+		ti.Placeholder = "Type to filter or add a label"
+	}
+	ti.CharLimit = 255
+	ti.Focus()
+
+	current := issue.ListValues(field)
+	return &ListFieldEditData{
+		field:      field,
+		issueKey:   issue.Key,
+		projectKey: issue.Project.Key,
+		original:   slices.Clone(current),
+		selected:   slices.Clone(current),
+		options:    mergeOptions(current, options),
+		input:      ti,
+	}
+}
+
+// mergeOptions appends the values of extra not already in base, keeping
+// base's order.
+func mergeOptions(base, extra []string) []string {
+	out := slices.Clone(base)
+	for _, v := range extra {
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// matches are the options containing the typed text, case-insensitively.
+func (d *ListFieldEditData) matches() []string {
+	q := strings.ToLower(strings.TrimSpace(d.input.Value()))
+	if q == "" {
+		return d.options
+	}
+	var out []string
+	for _, o := range d.options {
+		if strings.Contains(strings.ToLower(o), q) {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// newLabel is the typed text when it can be added as a new label: labels only
+// (components and versions must exist), not blank, without spaces (Jira
+// rejects those) and not an existing option.
+func (d *ListFieldEditData) newLabel() string {
+	v := strings.TrimSpace(d.input.Value())
+	if d.field != jira.LabelsField || v == "" || strings.ContainsRune(v, ' ') {
+		return ""
+	}
+	for _, o := range d.options {
+		if strings.EqualFold(o, v) {
+			return ""
+		}
+	}
+	return v
+}
+
+// rows are the navigable entries: an "add" row for a new label, then the
+// matching options.
+func (d *ListFieldEditData) rows() []string {
+	rows := d.matches()
+	if l := d.newLabel(); l != "" {
+		rows = append([]string{l}, rows...)
+	}
+	return rows
+}
+
+// toggle selects or deselects value, adding it to the options if it's new.
+func (d *ListFieldEditData) toggle(value string) {
+	if i := slices.Index(d.selected, value); i >= 0 {
+		d.selected = slices.Delete(d.selected, i, i+1)
+		return
+	}
+	d.selected = append(d.selected, value)
+	if !slices.Contains(d.options, value) {
+		d.options = append(d.options, value)
+	}
+}
+
+// changes is what saving sends: the values added and removed.
+func (d *ListFieldEditData) changes() (add, remove []string) {
+	for _, v := range d.selected {
+		if !slices.Contains(d.original, v) {
+			add = append(add, v)
+		}
+	}
+	for _, v := range d.original {
+		if !slices.Contains(d.selected, v) {
+			remove = append(remove, v)
+		}
+	}
+	return add, remove
+}
+
+// listOptionsKey keys the session's option cache.
+func listOptionsKey(field jira.ListField, projectKey string) string {
+	return string(field) + "/" + projectKey
+}
+
+// projectListValues collects the values of field on the loaded issues of
+// projectKey, so suggestions work before (or without) the options request.
+func (m model) projectListValues(field jira.ListField, projectKey string) []string {
+	var values []string
+	for _, is := range m.issues {
+		if is.Project.Key != projectKey {
+			continue
+		}
+		values = mergeOptions(values, is.ListValues(field))
+	}
+	slices.Sort(values)
+	return values
+}
+
+// openListFieldEdit opens the modal for field on the active issue, loading the
+// project's options in the background the first time.
+func (m model) openListFieldEdit(field jira.ListField) (tea.Model, tea.Cmd) {
+	if m.activeIssue == nil {
+		return m, nil
+	}
+	projectKey := m.activeIssue.Project.Key
+	cached, ok := m.listOptions[listOptionsKey(field, projectKey)]
+	options := mergeOptions(m.projectListValues(field, projectKey), cached)
+
+	m.listFieldData = NewListFieldEditData(field, *m.activeIssue, options)
+	m.previousMode = m.mode
+	m.mode = listFieldView
+
+	cmds := []tea.Cmd{textinput.Blink}
+	if !ok {
+		m.loadingCount++
+		cmds = append(cmds, m.fetchListOptionsCmd(field, projectKey))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) fetchListOptionsCmd(field jira.ListField, projectKey string) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		options, err := m.client.GetListOptions(context.Background(), field, projectKey)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return listOptionsLoadedMsg{field: field, projectKey: projectKey, options: options}
+	}
+}
+
+func (m model) handleListOptionsLoaded(msg listOptionsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	if m.listOptions == nil {
+		m.listOptions = make(map[string][]string)
+	}
+	m.listOptions[listOptionsKey(msg.field, msg.projectKey)] = msg.options
+
+	if d := m.listFieldData; d != nil && d.field == msg.field && d.projectKey == msg.projectKey {
+		d.options = mergeOptions(d.options, msg.options)
+	}
+	return m, nil
+}
+
+func (m model) updateListFieldView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	d := m.listFieldData
+	if kp, ok := msg.(tea.KeyPressMsg); ok {
+		rows := d.rows()
+		switch kp.String() {
+		case "esc":
+			m.mode = m.previousMode
+			m.listFieldData = nil
+			return m, nil
+		case "up", "ctrl+p":
+			d.cursor = max(0, d.cursor-1)
+			return m, nil
+		case "down", "ctrl+n":
+			d.cursor = max(0, min(d.cursor+1, len(rows)-1))
+			return m, nil
+		case "enter", "tab":
+			if d.cursor < len(rows) {
+				d.toggle(rows[d.cursor])
+				d.input.SetValue("")
+				d.cursor = 0
+			}
+			return m, nil
+		case "backspace":
+			if d.input.Value() == "" && len(d.selected) > 0 {
+				d.selected = d.selected[:len(d.selected)-1]
+				return m, nil
+			}
+		case "ctrl+s":
+			return m.saveListField()
+		}
+	}
+
+	prev := d.input.Value()
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	if d.input.Value() != prev {
+		d.cursor = 0
+	}
+	return m, cmd
+}
+
+// saveListField sends the additions and removals, showing them right away.
+func (m model) saveListField() (tea.Model, tea.Cmd) {
+	d := m.listFieldData
+	m.mode = m.previousMode
+	m.listFieldData = nil
+
+	add, remove := d.changes()
+	if len(add) == 0 && len(remove) == 0 {
+		m.setInfo(d.field.Title() + " unchanged")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+
+	selected := d.selected
+	if len(selected) == 0 {
+		selected = nil
+	}
+	m.loadingCount++
+	return m, m.applyOptimistic(d.issueKey,
+		func(is *jira.Issue) { is.SetListValues(d.field, selected) },
+		func(is *jira.Issue) { is.SetListValues(d.field, d.original) },
+		m.updateListFieldCmd(d.issueKey, d.field, add, remove),
+	)
+}
+
+func (m model) updateListFieldCmd(issueKey string, field jira.ListField, add, remove []string) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		err := m.client.UpdateIssueList(context.Background(), issueKey, field, add, remove)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return listFieldUpdatedMsg{issueKey: issueKey, field: field}
+	}
+}
+
+func (m model) handleListFieldUpdated(msg listFieldUpdatedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.setSuccess(fmt.Sprintf("%s updated on %s", msg.field.Title(), msg.issueKey))
+	return m, m.clearStatusAfter(clearMsgTimeout)
+}
+
+func (m model) renderListFieldView() string {
+	d := m.listFieldData
+	var b strings.Builder
+
+	b.WriteString(ui.RenderChips(d.selected, chipStyle(d.field)) + "\n\n")
+	b.WriteString(d.input.View() + "\n\n")
+
+	rows := d.rows()
+	newLabel := d.newLabel()
+	first := max(0, d.cursor-listFieldMaxRows+1)
+	for i := first; i < len(rows) && i < first+listFieldMaxRows; i++ {
+		mark := "[ ] "
+		if slices.Contains(d.selected, rows[i]) {
+			mark = "[x] "
+		}
+		text := mark + rows[i]
+		if i == 0 && newLabel != "" {
+			text = "+ add " + rows[i]
+		}
+		if i == d.cursor {
+			b.WriteString(ui.IconCursor + ui.SelectedRowStyle.Render(" "+text) + "\n")
+		} else {
+			b.WriteString("  " + text + "\n")
+		}
+	}
+	if len(rows) == 0 {
+		b.WriteString(ui.DimTextStyle.Render("  No matches") + "\n")
+	}
+
+	b.WriteString("\n" + ui.StatusBarInfoStyle.Render("↑/↓ select · enter toggle · ctrl+s save · esc cancel"))
+	return m.renderModal(d.field.Title()+" "+d.issueKey, b.String(), listFieldModalWScale, listFieldModalHScale)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func newLabelsModel() model {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.issues = []jira.Issue{
+		{Key: "DEV-1", Status: "To Do", Project: jira.Project{ID: "P", Key: "DEV"}, Labels: []string{"backend"}},
+		{Key: "DEV-2", Status: "To Do", Project: jira.Project{ID: "P", Key: "DEV"}, Labels: []string{"urgent", "backend"}},
+		{Key: "OPS-1", Status: "To Do", Project: jira.Project{ID: "Q", Key: "OPS"}, Labels: []string{"infra"}},
+	}
+	m.refreshBoard("DEV-1")
+	m.activeIssue = &m.issues[0]
+	m.mode = detailView
+	return m
+}
+
+func typeText(m model, s string) model {
+	for _, r := range s {
+		next, _ := m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		m = next.(model)
+	}
+	return m
+}
+
+func TestListFieldEditDataChanges(t *testing.T) {
+	d := NewListFieldEditData(jira.LabelsField, jira.Issue{Key: "DEV-1", Labels: []string{"a", "b"}}, []string{"b", "c"})
+	if strings.Join(d.options, ",") != "a,b,c" {
+		t.Errorf("options = %v, want the current values first", d.options)
+	}
+
+	d.toggle("a")
+	d.toggle("c")
+	add, remove := d.changes()
+	if strings.Join(add, ",") != "c" || strings.Join(remove, ",") != "a" {
+		t.Errorf("add %v remove %v", add, remove)
+	}
+
+	d.input.SetValue("new")
+	if rows := d.rows(); len(rows) != 1 || rows[0] != "new" {
+		t.Errorf("rows = %v, want just the add row", rows)
+	}
+	d.input.SetValue("B")
+	if d.newLabel() != "" {
+		t.Error("an existing label (any case) should not be offered as new")
+	}
+	d.input.SetValue("two words")
+	if d.newLabel() != "" {
+		t.Error("labels with spaces should not be offered")
+	}
+
+	c := NewListFieldEditData(jira.ComponentsField, jira.Issue{Key: "DEV-1"}, []string{"API"})
+	c.input.SetValue("Web")
+	if c.newLabel() != "" {
+		t.Error("components can't be created from the modal")
+	}
+}
+
+func TestOpenListFieldEditSuggestsProjectLabels(t *testing.T) {
+	m := newLabelsModel()
+
+	next, cmd := m.openListFieldEdit(jira.LabelsField)
+	nm := next.(model)
+	if nm.mode != listFieldView || cmd == nil || nm.loadingCount != 1 {
+		t.Fatalf("expected the modal open and options requested, mode %v", nm.mode)
+	}
+	if got := strings.Join(nm.listFieldData.options, ","); got != "backend,urgent" {
+		t.Errorf("options = %q, want the project's labels only", got)
+	}
+
+	next, _ = nm.Update(listOptionsLoadedMsg{field: jira.LabelsField, projectKey: "DEV", options: []string{"frontend"}})
+	nm = next.(model)
+	if got := strings.Join(nm.listFieldData.options, ","); got != "backend,urgent,frontend" {
+		t.Errorf("options = %q after loading", got)
+	}
+
+	// Cached options: no second request.
+	nm.mode = detailView
+	nm.listFieldData = nil
+	next, _ = nm.openListFieldEdit(jira.LabelsField)
+	if next.(model).loadingCount != 0 {
+		t.Error("cached options should not be fetched again")
+	}
+}
+
+func TestSaveListFieldAppliesOptimistically(t *testing.T) {
+	m := newLabelsModel()
+	m.client, _ = jira.NewClient("http://jira.invalid", "user@example.com", "token", "", "")
+	m.listOptions = map[string][]string{listOptionsKey(jira.LabelsField, "DEV"): nil}
+
+	next, _ := m.Update(tea.KeyPressMsg{Code: 'L', Text: "L"})
+	m = next.(model)
+	if m.mode != listFieldView {
+		t.Fatalf("L should open the labels modal, mode %v", m.mode)
+	}
+
+	m = typeText(m, "api")
+	next, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = next.(model)
+	if strings.Join(m.listFieldData.selected, ",") != "backend,api" {
+		t.Fatalf("selected = %v", m.listFieldData.selected)
+	}
+	if !strings.Contains(ansi.Strip(m.renderListFieldView()), "api") {
+		t.Error("the new label should render as a chip")
+	}
+
+	next, cmd := m.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	nm := next.(model)
+	if cmd == nil || nm.mode != detailView {
+		t.Fatalf("expected the update sent and the modal closed, mode %v", nm.mode)
+	}
+	if got := strings.Join(nm.activeIssue.Labels, ","); got != "backend,api" || !nm.isPending("DEV-1") {
+		t.Errorf("labels = %q (pending %v)", got, nm.isPending("DEV-1"))
+	}
+	if !strings.Contains(ansi.Strip(renderChipsRow(*nm.activeIssue, 200)), "api") {
+		t.Error("metadata chips should show the new label")
+	}
+}
+
+func TestSaveListFieldUnchanged(t *testing.T) {
+	m := newLabelsModel()
+	m.listOptions = map[string][]string{listOptionsKey(jira.LabelsField, "DEV"): nil}
+	next, _ := m.openListFieldEdit(jira.LabelsField)
+	next, cmd := next.(model).saveListField()
+	nm := next.(model)
+	if nm.loadingCount != 0 || nm.isPending("DEV-1") || cmd == nil {
+		t.Error("nothing should be sent without changes")
+	}
+	if !strings.Contains(nm.statusMessage.content, "unchanged") {
+		t.Errorf("status = %q", nm.statusMessage.content)
+	}
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Keys for following links in the description and comments.
+const (
+	nextLinkKey   = "n"
+	prevLinkKey   = "N"
+	followLinkKey = "enter"
+	navBackKey    = "backspace"
+)
+
+// docLink is a link or issue reference in a description or comment.
+type docLink struct {
+	// text is what the link shows as.
+	text string
+	// url is empty for issue keys mentioned in plain text.
+	url string
+	// issueKey is set when the link points at an issue on this site.
+	issueKey string
+}
+
+// linkScope is the text a link is selected in: a different section, comment
+// or issue starts over from its first link.
+type linkScope struct {
+	issueKey string
+	section  focusedSection
+	comment  int
+}
+
+// docLinks lists doc's links, smart links and issue keys in reading order.
+// Code is skipped.
+func (m model) docLinks(doc *jira.ContentDoc) []docLink {
+	if doc == nil {
+		return nil
+	}
+	var links []docLink
+	m.collectLinks(doc.Content, &links)
+	return links
+}
+
+func (m model) collectLinks(nodes []jira.ContentNode, links *[]docLink) {
+	for _, n := range nodes {
+		switch n.Type {
+		case "codeBlock":
+			continue
+		case "inlineCard", "blockCard", "embedCard":
+			if n.Attrs != nil && n.Attrs.URL != "" {
+				*links = append(*links, docLink{text: n.Attrs.URL, url: n.Attrs.URL, issueKey: m.issueKeyFromURL(n.Attrs.URL)})
+			}
+		case "text":
+			href, code := "", false
+			for _, mark := range n.Marks {
+				switch mark.Type {
+				case "link":
+					if mark.Attrs != nil {
+						href = mark.Attrs.Href
+					}
+				case "code":
+					code = true
+				}
+			}
+			switch {
+			case href != "":
+				// Text split by formatting inside one link is one link.
+				if last := len(*links) - 1; last >= 0 && (*links)[last].url == href && n.Text != "" {
+					(*links)[last].text += n.Text
+					continue
+				}
+				*links = append(*links, docLink{text: n.Text, url: href, issueKey: m.issueKeyFromURL(href)})
+			case !code:
+				for _, key := range issueKeysIn(n.Text) {
+					*links = append(*links, docLink{text: key, issueKey: key})
+				}
+			}
+		}
+		m.collectLinks(n.Content, links)
+	}
+}
+
+// issueKeysIn finds the issue keys mentioned in prose. Keys are upper case
+// there, which keeps words like "utf-8" out.
+func issueKeysIn(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+	var keys []string
+	for _, w := range words {
+		w = strings.Trim(w, "-")
+		if issueKeyPattern.MatchString(w) && w == strings.ToUpper(w) {
+			keys = append(keys, w)
+		}
+	}
+	return keys
+}
+
+// issueKeyFromURL returns the issue a browse link on this site points at.
+func (m model) issueKeyFromURL(url string) string {
+	prefix := m.browseURL("")
+	if prefix == "" {
+		return ""
+	}
+	rest, ok := strings.CutPrefix(url, prefix)
+	if !ok {
+		return ""
+	}
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		rest = rest[:i]
+	}
+	if !issueKeyPattern.MatchString(rest) {
+		return ""
+	}
+	return strings.ToUpper(rest)
+}
+
+// focusedLinks are the links of the focused text: the description, or the
+// comment under the cursor.
+func (m model) focusedLinks() ([]docLink, bool) {
+	if m.activeIssue == nil {
+		return nil, false
+	}
+	switch m.focusedSection {
+	case descriptionSection:
+		return m.docLinks(m.activeIssue.Description), true
+	case commentsSection:
+		if m.commentsCursor < 0 || m.commentsCursor >= len(m.activeIssue.Comments) {
+			return nil, false
+		}
+		return m.docLinks(m.activeIssue.Comments[m.commentsCursor].Body), true
+	}
+	return nil, false
+}
+
+// currentLinkScope is the scope of the focused text.
+func (m model) currentLinkScope() linkScope {
+	scope := linkScope{section: m.focusedSection, comment: -1}
+	if m.activeIssue != nil {
+		scope.issueKey = m.activeIssue.Key
+	}
+	if m.focusedSection == commentsSection {
+		scope.comment = m.commentsCursor
+	}
+	return scope
+}
+
+// linkSelected reports whether linkCursor points into the focused text.
+func (m model) linkSelected() bool {
+	return m.linkScope.issueKey != "" && m.linkScope == m.currentLinkScope()
+}
+
+// highlightedLink is the text of section and the index of its selected link.
+// Unlike selectedLink it holds while another section is focused, so the
+// highlight doesn't come and go with the focus.
+func (m model) highlightedLink(section focusedSection) ([]docLink, int, bool) {
+	if m.activeIssue == nil || m.linkScope.issueKey != m.activeIssue.Key || m.linkScope.section != section {
+		return nil, 0, false
+	}
+	var doc *jira.ContentDoc
+	switch section {
+	case descriptionSection:
+		doc = m.activeIssue.Description
+	case commentsSection:
+		if m.linkScope.comment != m.commentsCursor || m.commentsCursor < 0 || m.commentsCursor >= len(m.activeIssue.Comments) {
+			return nil, 0, false
+		}
+		doc = m.activeIssue.Comments[m.commentsCursor].Body
+	}
+	links := m.docLinks(doc)
+	if m.linkCursor < 0 || m.linkCursor >= len(links) {
+		return nil, 0, false
+	}
+	return links, m.linkCursor, true
+}
+
+// selectedLink is the link picked in the focused text, if any.
+func (m model) selectedLink() (docLink, bool) {
+	if !m.linkSelected() {
+		return docLink{}, false
+	}
+	links, _ := m.focusedLinks()
+	if m.linkCursor < 0 || m.linkCursor >= len(links) {
+		return docLink{}, false
+	}
+	return links[m.linkCursor], true
+}
+
+// cycleLink selects the next (dir 1) or previous (dir -1) link of the
+// focused text, scrolls it into view and names it in the status bar.
+func (m model) cycleLink(dir int) (tea.Model, tea.Cmd) {
+	links, ok := m.focusedLinks()
+	if !ok {
+		return m, nil
+	}
+	if len(links) == 0 {
+		m.setInfo("No links here")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+
+	switch {
+	case m.linkSelected():
+		m.linkCursor = (m.linkCursor + dir + len(links)) % len(links)
+	case dir < 0:
+		m.linkCursor = len(links) - 1
+	default:
+		m.linkCursor = 0
+	}
+	m.linkScope = m.currentLinkScope()
+	m.refreshADFContent()
+	m.scrollToLink(links, m.linkCursor)
+
+	link := links[m.linkCursor]
+	target := link.url
+	if link.issueKey != "" {
+		target = link.issueKey
+	}
+	m.setInfo(fmt.Sprintf("Link %d/%d: %s · enter to open", m.linkCursor+1, len(links), target))
+	return m, nil
+}
+
+// scrollToLink brings the line showing links[index] into the viewport of
+// the focused text.
+func (m *model) scrollToLink(links []docLink, index int) {
+	switch m.focusedSection {
+	case descriptionSection:
+		content := m.buildDescriptionContent(m.detailLayout.leftColumnWidth)
+		if line, _, ok := linkPos(content, 0, links, index); ok {
+			scrollIntoView(&m.descViewport, line)
+		}
+	case commentsSection:
+		from := m.getCommentCursorLine()
+		content := m.buildCommentsContent(m.detailLayout.leftColumnWidth)
+		if line, _, ok := linkPos(content, from, links, index); ok {
+			scrollIntoView(&m.commentsViewport, line)
+		}
+	}
+}
+
+// linkPos finds where content, from line from on, shows links[index]: the
+// line and byte offset (in the unstyled line) of the occurrence of its first
+// word that follows the ones in the links before it. Wrapping can split a
+// link, but not its first word.
+func linkPos(content string, from int, links []docLink, index int) (line, col int, ok bool) {
+	word := firstWord(links[index].text)
+	if word == "" {
+		return 0, 0, false
+	}
+	nth := 0
+	for _, l := range links[:index] {
+		nth += strings.Count(l.text, word)
+	}
+
+	lines := strings.Split(ansi.Strip(content), "\n")
+	for i := from; i < len(lines); i++ {
+		count := strings.Count(lines[i], word)
+		if nth < count {
+			end := 0
+			for ; nth >= 0; nth-- {
+				end += strings.Index(lines[i][end:], word) + len(word)
+			}
+			return i, end - len(word), true
+		}
+		nth -= count
+	}
+	return 0, 0, false
+}
+
+// highlightLink shows links[index] reversed in content, following it onto
+// the next lines when it wraps.
+func highlightLink(content string, links []docLink, index int) string {
+	line, col, ok := linkPos(content, 0, links, index)
+	if !ok {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	text := strings.TrimSpace(links[index].text)
+	for ; line < len(lines) && text != ""; line++ {
+		plain := ansi.Strip(lines[line])
+		if col < 0 {
+			// A wrapped link goes on at the start of the next line's text.
+			col = len(plain) - len(strings.TrimLeft(plain, " "))
+		}
+		n := commonPrefixLen(plain[col:], text)
+		if n == 0 {
+			break
+		}
+		start := ansi.StringWidth(plain[:col])
+		end := start + ansi.StringWidth(plain[col:col+n])
+		lines[line] = ansi.Cut(lines[line], 0, start) + ui.SelectedLinkStyle.Render(plain[col:col+n]) + ansi.TruncateLeft(lines[line], end, "")
+		text = strings.TrimLeft(text[n:], " ")
+		col = -1
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commonPrefixLen is the length in bytes of the longest prefix a and b share,
+// in whole runes.
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) {
+		ra, size := utf8.DecodeRuneInString(a[n:])
+		if rb, _ := utf8.DecodeRuneInString(b[n:]); ra != rb {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// scrollIntoView scrolls vp to line unless it's already visible.
+func scrollIntoView(vp *viewport.Model, line int) {
+	if line < vp.YOffset() || line >= vp.YOffset()+vp.Height() {
+		vp.SetYOffset(line)
+	}
+}
+
+func firstWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// followLink opens the selected link: issues in the detail view, remembering
+// the current one for navBackKey, anything else in the browser.
+func (m model) followLink() (tea.Model, tea.Cmd) {
+	link, ok := m.selectedLink()
+	if !ok {
+		return m, nil
+	}
+	if link.issueKey == "" {
+		m.setInfo("Opening " + link.url + " in browser")
+		return m, tea.Batch(openInBrowserCmd(link.url), m.clearStatusAfter(clearMsgTimeout))
+	}
+	if strings.EqualFold(link.issueKey, m.activeIssue.Key) {
+		return m, nil
+	}
+	m.pushNavHistory()
+	return m.openDetail(link.issueKey)
+}
+
+// pushNavHistory remembers the active issue for navBackKey before another
+// one replaces it in the detail view.
+func (m *model) pushNavHistory() {
+	if m.activeIssue != nil {
+		m.navHistory = append(m.navHistory, m.activeIssue.Key)
+	}
+}
+
+// navigateBack returns to the issue the detail view showed before the
+// current one.
+func (m model) navigateBack() (tea.Model, tea.Cmd) {
+	if len(m.navHistory) == 0 {
+		return m, nil
+	}
+	key := m.navHistory[len(m.navHistory)-1]
+	m.navHistory = m.navHistory[:len(m.navHistory)-1]
+	return m.openDetail(key)
+}
+
+// openDetail loads issueKey into the detail view.
+func (m model) openDetail(issueKey string) (tea.Model, tea.Cmd) {
+	m.linkScope = linkScope{}
+	m.focusedSection = metadataSection
+	m.setInfo("Opening " + issueKey)
+	m.loadingCount++
+	return m, m.fetchIssueDetailCmd(issueKey)
+}
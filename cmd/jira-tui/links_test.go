@@ -0,0 +1,207 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+func linkText(text, href string) jira.ContentNode {
+	return jira.ContentNode{Type: "text", Text: text, Marks: []jira.Mark{{Type: "link", Attrs: &jira.MarkAttrs{Href: href}}}}
+}
+
+// newLinksModel is a detail view of DEV-1 with its description focused.
+func newLinksModel(t *testing.T) model {
+	t.Helper()
+	m := newTabModel([]Tab{{}}, 0)
+	m.client, _ = jira.NewClient("http://jira.invalid", "user@example.com", "token", "", "")
+	m.mode = detailView
+	m.focusedSection = descriptionSection
+	m.activeIssue = &jira.Issue{
+		Key: "DEV-1",
+		Description: &jira.ContentDoc{Content: []jira.ContentNode{
+			{Type: "paragraph", Content: []jira.ContentNode{
+				{Type: "text", Text: "Blocked by OPS-12, see "},
+				linkText("the docs", "https://example.com/docs"),
+				{Type: "text", Text: "."},
+			}},
+		}},
+	}
+	m.detailLayout = m.calculateDetailLayout()
+	m.descViewport.SetWidth(m.detailLayout.leftColumnWidth)
+	m.descViewport.SetHeight(m.detailLayout.descHeight)
+	m.refreshADFContent()
+	return m
+}
+
+func TestIssueKeysIn(t *testing.T) {
+	got := issueKeysIn("Fixed in DEV-12 (and OPS-3), not utf-8 or PROJ- or -4.")
+	if want := []string{"DEV-12", "OPS-3"}; !slices.Equal(got, want) {
+		t.Errorf("issueKeysIn = %v, want %v", got, want)
+	}
+}
+
+func TestDocLinks(t *testing.T) {
+	m := newTabModel([]Tab{{}}, 0)
+	m.client, _ = jira.NewClient("http://jira.invalid", "user@example.com", "token", "", "")
+	doc := &jira.ContentDoc{Content: []jira.ContentNode{
+		{Type: "paragraph", Content: []jira.ContentNode{
+			{Type: "text", Text: "See DEV-2 and "},
+			linkText("the ", "https://example.com"),
+			{Type: "text", Text: "guide", Marks: []jira.Mark{{Type: "strong"}, {Type: "link", Attrs: &jira.MarkAttrs{Href: "https://example.com"}}}},
+			{Type: "text", Text: " and CODE-1", Marks: []jira.Mark{{Type: "code"}}},
+			{Type: "inlineCard", Attrs: &jira.NodeAttrs{URL: "http://jira.invalid/browse/ops-7?focusedId=1"}},
+		}},
+		{Type: "codeBlock", Content: []jira.ContentNode{{Type: "text", Text: "SKIP-1"}}},
+	}}
+
+	want := []docLink{
+		{text: "DEV-2", issueKey: "DEV-2"},
+		{text: "the guide", url: "https://example.com"},
+		{text: "http://jira.invalid/browse/ops-7?focusedId=1", url: "http://jira.invalid/browse/ops-7?focusedId=1", issueKey: "OPS-7"},
+	}
+	if got := m.docLinks(doc); !slices.Equal(got, want) {
+		t.Errorf("docLinks = %+v, want %+v", got, want)
+	}
+}
+
+func TestCycleLinks(t *testing.T) {
+	m := newLinksModel(t)
+
+	next, _ := m.updateDetailView(keyPress(nextLinkKey))
+	m = next.(model)
+	if m.linkCursor != 0 || !strings.Contains(m.statusMessage.content, "Link 1/2: OPS-12") {
+		t.Errorf("cursor = %d, status = %q, want the issue key first", m.linkCursor, m.statusMessage.content)
+	}
+	next, _ = m.updateDetailView(keyPress(nextLinkKey))
+	if m = next.(model); m.linkCursor != 1 {
+		t.Errorf("cursor = %d, want the URL next", m.linkCursor)
+	}
+	next, _ = m.updateDetailView(keyPress(nextLinkKey))
+	if m = next.(model); m.linkCursor != 0 {
+		t.Errorf("cursor = %d, want it to wrap around", m.linkCursor)
+	}
+	next, _ = m.updateDetailView(keyPress(prevLinkKey))
+	if m = next.(model); m.linkCursor != 1 {
+		t.Errorf("cursor = %d, want it to wrap back", m.linkCursor)
+	}
+
+	// Another section starts over.
+	m.focusedSection = commentsSection
+	if _, ok := m.selectedLink(); ok {
+		t.Error("the selection should not carry over to another section")
+	}
+}
+
+func TestFollowLinkAndBack(t *testing.T) {
+	m := newLinksModel(t)
+	if _, cmd := m.followLink(); cmd != nil {
+		t.Error("nothing should open before a link is selected")
+	}
+
+	next, _ := m.cycleLink(1)
+	next, cmd := next.(model).updateDetailView(keyPress(followLinkKey))
+	m = next.(model)
+	if cmd == nil || m.loadingCount != 1 {
+		t.Fatal("expected the linked issue to be fetched")
+	}
+	if !slices.Equal(m.navHistory, []string{"DEV-1"}) {
+		t.Errorf("navHistory = %v, want DEV-1 remembered", m.navHistory)
+	}
+	if m.linkSelected() || m.focusedSection != metadataSection {
+		t.Error("the new issue should open without a link selected")
+	}
+
+	// Pretend OPS-12 loaded, then go back.
+	m.activeIssue = &jira.Issue{Key: "OPS-12"}
+	next, cmd = m.updateDetailView(keyPress(navBackKey))
+	m = next.(model)
+	if cmd == nil || len(m.navHistory) != 0 {
+		t.Errorf("navHistory = %v, want DEV-1 popped and fetched", m.navHistory)
+	}
+	if _, cmd = m.navigateBack(); cmd != nil {
+		t.Error("an empty history should not go anywhere")
+	}
+}
+
+func TestFollowURLOpensBrowser(t *testing.T) {
+	m := newLinksModel(t)
+	next, _ := m.cycleLink(-1)
+	next, cmd := next.(model).followLink()
+	m = next.(model)
+	if cmd == nil || len(m.navHistory) != 0 {
+		t.Error("a URL should open in the browser, not the detail view")
+	}
+	if !strings.Contains(m.statusMessage.content, "https://example.com/docs") {
+		t.Errorf("status = %q", m.statusMessage.content)
+	}
+}
+
+func TestIssueToIssueNavigationIsRemembered(t *testing.T) {
+	m := newLinksModel(t)
+	m.activeIssue.Parent = &jira.Parent{Key: "DEV-0"}
+	m.activeIssue.SubTasks = []jira.Issue{{Key: "DEV-2"}}
+	m.activeIssue.IssueLinks = []jira.IssueLink{{OutwardIssue: &jira.LinkedIssue{Key: "OPS-3"}}}
+	start := m.activeIssue
+
+	visit := func(section focusedSection, keys ...string) model {
+		t.Helper()
+		m := m
+		m.activeIssue = start
+		m.focusedSection = section
+		for _, k := range keys {
+			next, _ := m.updateDetailView(keyPress(k))
+			m = next.(model)
+		}
+		if !slices.Equal(m.navHistory, []string{"DEV-1"}) {
+			t.Errorf("%v: navHistory = %v, want DEV-1 remembered", keys, m.navHistory)
+		}
+		return m
+	}
+	visit(metadataSection, "g", "p")
+	visit(subTasksSection, "enter")
+	m = visit(issueLinksSection, "enter")
+
+	if _, cmd := m.navigateBack(); cmd == nil {
+		t.Error("backspace should return to DEV-1")
+	}
+}
+
+func TestHighlightLink(t *testing.T) {
+	links := []docLink{{text: "DEV-2", issueKey: "DEV-2"}, {text: "the docs", url: "https://example.com"}, {text: "DEV-2", issueKey: "DEV-2"}}
+	content := "DEV-2 and see the\n  docs now, DEV-2"
+	hl := ui.SelectedLinkStyle.Render
+
+	if got, want := highlightLink(content, links, 1), "DEV-2 and see "+hl("the")+"\n  "+hl("docs")+" now, DEV-2"; got != want {
+		t.Errorf("wrapped link = %q, want %q", got, want)
+	}
+	if got, want := highlightLink(content, links, 2), "DEV-2 and see the\n  docs now, "+hl("DEV-2"); got != want {
+		t.Errorf("second mention = %q, want %q", got, want)
+	}
+}
+
+func TestSelectedLinkIsHighlighted(t *testing.T) {
+	m := newLinksModel(t)
+	plain := m.descViewport.GetContent()
+
+	next, _ := m.cycleLink(-1)
+	m = next.(model)
+	got := m.descViewport.GetContent()
+	if !strings.Contains(got, ui.SelectedLinkStyle.Render("the docs")) {
+		t.Errorf("description = %q, want the selected link highlighted", got)
+	}
+	if ansi.Strip(got) != ansi.Strip(plain) {
+		t.Error("highlighting should not change the text")
+	}
+
+	// The selection stays shown while another section is focused.
+	m.focusedSection = commentsSection
+	if !strings.Contains(m.buildDescriptionContent(m.detailLayout.leftColumnWidth), ui.SelectedLinkStyle.Render("the docs")) {
+		t.Error("the highlight should not depend on the focus")
+	}
+}
@@ -110,7 +110,7 @@ func (m model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if !ok {
 				return m, nil
 			}
-			textToCopy := "https://layer7.atlassian.net/browse/" + issue.Key
+			textToCopy := m.browseURL(issue.Key)
 			yankToClipboard(textToCopy)
 			m.setInfo("URL yanked to clipboard")
 			cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
@@ -130,6 +130,12 @@ func (m model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		if m.currentGrouping() == groupColumns {
+			if next, cmd, ok := m.updateColumnsKeys(keyPressMsg.String()); ok {
+				return next, cmd
+			}
+		}
+
 		switch keyPressMsg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
@@ -263,6 +269,7 @@ func (m model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.pendingIssue = m.selectedIssue
 			m.previousMode = m.mode
 			m.mode = transitionView
+			m.movingCard = false
 			m.transitionCursor = 0
 
 			if m.selectedIssue != nil {
@@ -295,21 +302,36 @@ func (m model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.mode = userSearchView
 			m.userSelectionMode = assignUser
 			if m.usersCache != nil {
-				m.loadingCount++
 				m.searchUserData = NewSearchUserFormData(m.usersCache)
 				cmds = append(cmds, m.searchUserData.Form.Init())
 			}
 			return m, tea.Batch(cmds...)
 
 			// priorities
+		case "M":
+			return m.openSprintPicker()
+
+		case "K":
+			return m.rankSelected(-1)
+
+		case "J":
+			return m.rankSelected(+1)
+
 		case "p":
 			m.pendingIssue = m.selectedIssue
 			m.previousMode = m.mode
 			m.mode = priorityView
 			m.priorityData = NewPriorityFormData(m.priorities, m.pendingIssue.Priority.Name)
-			m.loadingCount++
 			return m, m.priorityData.Form.Init()
 
+		// open in browser
+		case "o":
+			issue, ok := m.currentIssue()
+			if !ok {
+				return m, nil
+			}
+			return m, m.openIssueInBrowser(issue.Key)
+
 		case "ctrl+r":
 			var cmds []tea.Cmd
 			if m.loadingCount > 0 {
@@ -385,7 +407,11 @@ func (m model) renderListView() string {
 	statusBar := m.renderStatusBar()
 	infoPanel := m.renderInfoPanel()
 
-	body := m.renderListColumnsHeader() + "\n" + m.listViewport.View()
+	header := m.renderListColumnsHeader()
+	if m.currentGrouping() == groupColumns {
+		header = m.renderColumnsHeader()
+	}
+	body := header + "\n" + m.listViewport.View()
 
 	return m.renderTabBar() + "\n" + infoPanel + "\n" + ui.PanelActiveStyle.Render(body) + "\n" + statusBar
 }
@@ -22,6 +22,7 @@ type listColumn struct {
 }
 
 // listColumns is the ordered, single source of truth for the list layout.
+// Configured custom fields are appended after them (see visibleListColumns).
 var listColumns = []listColumn{
 	{
 		header: "TYPE",
@@ -106,34 +107,42 @@ func summaryCell(m model, i jira.Issue, selected, dimmed bool) string {
 	return m.columnWidths.RenderSummary(summaryText, selected, dimmed)
 }
 
-// rowPrefix is the 2-cell cursor gutter. Both states are exactly 2 cells wide so
-// columns never shift horizontally between selected and unselected rows.
-func rowPrefix(selected bool) string {
+// rowPrefix is the 2-cell gutter: the cursor, then the pending marker for rows
+// with an optimistic change in flight. Every state is exactly 2 cells wide so
+// columns never shift horizontally between rows.
+func rowPrefix(selected, pending bool) string {
+	cursor, marker := " ", " "
 	if selected {
-		return ui.IconCursor + " "
+		cursor = ui.IconCursor
 	}
-	return "  "
+	if pending {
+		marker = ui.PendingMarkerStyle.Render(ui.IconPending)
+	}
+	return cursor + marker
 }
 
 // renderIssueRow builds one data row from the column model.
 func (m model) renderIssueRow(i jira.Issue, selected, dimmed bool) string {
-	cells := make([]string, len(listColumns))
-	for ci, col := range listColumns {
+	cols := m.visibleListColumns()
+	cells := make([]string, len(cols))
+	for ci, col := range cols {
 		cells[ci] = ui.PadCell(col.cell(m, i, selected, dimmed), col.width(m.columnWidths))
 	}
 	line := strings.Join(cells, " ")
+	pending := m.isPending(i.Key)
 	if selected {
-		return rowPrefix(true) + ui.SelectedRowStyle.Render(line)
+		return rowPrefix(true, pending) + ui.SelectedRowStyle.Render(line)
 	}
-	return rowPrefix(false) + ui.NormalRowStyle.Render(line)
+	return rowPrefix(false, pending) + ui.NormalRowStyle.Render(line)
 }
 
 // renderListColumnsHeader builds the pinned header: the labels aligned to the
 // same widths as the rows, plus a separator rule spanning the full row width.
 func (m model) renderListColumnsHeader() string {
-	cells := make([]string, len(listColumns))
-	for ci, col := range listColumns {
-		cells[ci] = ui.PadCell(ui.ColumnHeaderStyle.Render(col.header), col.width(m.columnWidths))
+	cols := m.visibleListColumns()
+	cells := make([]string, len(cols))
+	for ci, col := range cols {
+		cells[ci] = ui.PadCell(ui.ColumnHeaderStyle.Render(ui.TruncateLongString(col.header, col.width(m.columnWidths))), col.width(m.columnWidths))
 	}
 	header := "  " + strings.Join(cells, " ")
 	rule := ui.ColumnHeaderRuleStyle.Render(strings.Repeat("─", lipgloss.Width(header)))
@@ -3,6 +3,7 @@ package main
 import (
 	"testing"
 
+	"github.com/oliverjhernandez/jira-tui/internal/config"
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
 )
 
@@ -39,11 +40,12 @@ func TestExtractLoggedTime(t *testing.T) {
 
 func TestSortIssuesByStatus(t *testing.T) {
 	issues := []jira.Issue{
-		{Key: "c", Status: "Done"},       // order 5
-		{Key: "a", Status: "Trabajando"}, // order 1
-		{Key: "b", Status: "To Do"},      // order 2
+		{Key: "c", Status: "Done"},
+		{Key: "a", Status: "Trabajando"},
+		{Key: "b", Status: "To Do"},
 	}
-	sortIssuesByStatus(issues)
+	m := model{workflow: config.Workflow{StatusOrder: []string{"Trabajando", "To Do", "Done"}}}
+	m.sortIssuesByStatus(issues)
 	got := []string{issues[0].Key, issues[1].Key, issues[2].Key}
 	want := []string{"a", "b", "c"}
 	for i := range want {
@@ -62,7 +64,8 @@ func TestSortSectionsIssuesStatusThenPriority(t *testing.T) {
 			},
 		},
 	}
-	sortSectionsIssues(sections)
+	m := model{priorities: []jira.Priority{{Name: "High"}, {Name: "Low"}}}
+	m.sortSectionsIssues(sections)
 	if sections[0].Issues[0].Key != "high" {
 		t.Errorf("expected higher priority first, got %q", sections[0].Issues[0].Key)
 	}
@@ -108,7 +111,7 @@ func TestIsCancelTransition(t *testing.T) {
 		{"In Progress", false},
 	}
 	for _, tt := range tests {
-		if got := isCancelTransition(jira.Transition{Name: tt.name}); got != tt.want {
+		if got := isCancelTransition(jira.Transition{Name: tt.name}, config.Workflow{}); got != tt.want {
 			t.Errorf("isCancelTransition(%q) = %v, want %v", tt.name, got, tt.want)
 		}
 	}
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log/slog"
 	"maps"
@@ -14,14 +15,16 @@ import (
 	"charm.land/bubbles/v2/textarea"
 	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
+	uv "github.com/charmbracelet/ultraviolet"
 
+	"github.com/oliverjhernandez/jira-tui/internal/cache"
 	"github.com/oliverjhernandez/jira-tui/internal/config"
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/outbox"
+	"github.com/oliverjhernandez/jira-tui/internal/termimg"
 	"github.com/oliverjhernandez/jira-tui/internal/ui"
 )
 
-const jiraURL = "https://layer7.atlassian.net/browse/"
-
 const clearMsgTimeout = 5 * time.Second
 
 type viewMode int
@@ -47,6 +50,14 @@ const (
 	searchView
 	projectPickerView
 	helpView
+	outboxView
+	undoConfirmView
+	sprintPickerView
+	customFieldPickerView
+	customFieldView
+	listFieldView
+	attachmentUploadView
+	watchersView
 )
 
 func (v viewMode) String() string {
@@ -91,6 +102,22 @@ func (v viewMode) String() string {
 		return "projectPickerView"
 	case helpView:
 		return "helpView"
+	case outboxView:
+		return "outboxView"
+	case undoConfirmView:
+		return "undoConfirmView"
+	case sprintPickerView:
+		return "sprintPickerView"
+	case customFieldPickerView:
+		return "customFieldPickerView"
+	case customFieldView:
+		return "customFieldView"
+	case listFieldView:
+		return "listFieldView"
+	case attachmentUploadView:
+		return "attachmentUploadView"
+	case watchersView:
+		return "watchersView"
 	default:
 		return "unknown"
 	}
@@ -112,6 +139,7 @@ type userSelectionMode int
 const (
 	assignUser userSelectionMode = iota
 	insertMention
+	addWatcher
 )
 
 type issueSelectionMode int
@@ -130,6 +158,8 @@ const (
 	worklogsSection
 	issueLinksSection
 	subTasksSection
+	attachmentsSection
+	historySection
 )
 
 func (f focusedSection) String() string {
@@ -142,6 +172,10 @@ func (f focusedSection) String() string {
 		return "worklogsSection"
 	case subTasksSection:
 		return "subTasksSection"
+	case attachmentsSection:
+		return "attachmentsSection"
+	case historySection:
+		return "historySection"
 	default:
 		return "unknown"
 	}
@@ -160,6 +194,8 @@ type Section struct {
 type model struct {
 	// Core
 	client       *jira.Client
+	cache        *cache.Store // nil with --no-cache
+	outbox       *outbox.Outbox
 	mode         viewMode
 	previousMode viewMode
 	baseView     viewMode
@@ -167,7 +203,10 @@ type model struct {
 	// one-shot (consumed and reset to listView) so opening an issue from search
 	// results returns to the results rather than the board.
 	detailReturnView viewMode
-	err              error
+	// navHistory holds the issues left by moving to another one from the
+	// detail view, most recent last, for navBackKey.
+	navHistory []string
+	err        error
 
 	// Tabs
 	tabs      []Tab
@@ -176,16 +215,18 @@ type model struct {
 
 	windowWidth int
 	// Window & Layout
-	windowHeight       int
-	detailLayout       detailLayout
-	listLayout         listLayout
-	columnWidths       ui.ColumnWidths
-	listViewport       viewport.Model
-	descViewport       viewport.Model
-	commentsViewport   viewport.Model
-	worklogsViewport   viewport.Model
-	issueLinksViewport viewport.Model
-	subTasksViewport   viewport.Model
+	windowHeight        int
+	detailLayout        detailLayout
+	listLayout          listLayout
+	columnWidths        ui.ColumnWidths
+	listViewport        viewport.Model
+	descViewport        viewport.Model
+	commentsViewport    viewport.Model
+	worklogsViewport    viewport.Model
+	issueLinksViewport  viewport.Model
+	subTasksViewport    viewport.Model
+	attachmentsViewport viewport.Model
+	historyViewport     viewport.Model
 
 	// User Data
 	myself *jira.User
@@ -205,13 +246,41 @@ type model struct {
 	filteredSections []Section
 	statuses         map[string][]jira.Status
 	priorities       []jira.Priority
-
+	workflow         config.Workflow
+	// downloadDir is where attachments are saved (config download_dir).
+	downloadDir string
+	// images draws screenshots in descriptions and comments (see images.go).
+	images imageState
+
+	// Custom fields: the profile's declarations, Jira's field metadata and
+	// the declarations resolved against it (see customFields.go).
+	customFieldConfig []config.CustomField
+	fields            []jira.Field
+	customFields      []customField
+
+	// listOptions caches the labels, components and versions offered by
+	// the list field modal, keyed by listOptionsKey.
+	listOptions map[string][]string
 	// Worklogs
 	worklogTotals map[string]int
 
+	// pendingIssues counts in-flight optimistic changes per issue key; those
+	// rows carry a pending marker.
+	pendingIssues map[string]int
+
+	// cacheFresh records the kinds restoreCache found within their TTL; Init
+	// skips fetching those.
+	cacheFresh map[cache.Kind]bool
+
 	// Transitions
 	// transitions       map[string][]jira.Transition
 	pendingTransition *jira.Transition
+	// pendingMoveStatus is the kanban column a card is being moved to while
+	// its transitions load (see moveCard).
+	pendingMoveStatus string
+	// movingCard is set while a card move goes through the transition flow,
+	// which then returns to the board (see transitionDoneView).
+	movingCard bool
 	// transitionCache is keyed issueKey -> status -> transitions. Transitions are
 	// issue-specific (they depend on the issue's workflow), so this must never be
 	// keyed by project: a transition id valid for one issue can be invalid for
@@ -224,14 +293,24 @@ type model struct {
 	issueSelectionMode issueSelectionMode
 
 	// Navigation & Cursors
-	cursor           int
-	sectionCursor    int
-	transitionCursor int
-	userCursor       int
-	commentsCursor   int
-	worklogsCursor   int
-	IssueLinksCursor int
-	subTasksCursor   int
+	cursor            int
+	sectionCursor     int
+	transitionCursor  int
+	userCursor        int
+	commentsCursor    int
+	worklogsCursor    int
+	IssueLinksCursor  int
+	subTasksCursor    int
+	attachmentsCursor int
+	watchersCursor    int
+	// linkCursor is the link selected in the text linkScope names.
+	linkCursor int
+	linkScope  linkScope
+	// historyFilter is the field the history section is limited to; empty
+	// shows every change.
+	historyFilter string
+	// historyLoading is the issue whose remaining changelog is being fetched.
+	historyLoading string
 
 	// Input Components
 	textInput textinput.Model
@@ -261,6 +340,11 @@ type model struct {
 	searchUserData        *SearchUserFormData
 	savedBoardData        *SavedBoardFormData
 	projectPickerData     *ProjectPickerFormData
+	sprintPickerData      *SprintPickerFormData
+	customFieldPickerData *CustomFieldPickerFormData
+	customFieldData       *CustomFieldFormData
+	listFieldData         *ListFieldEditData
+	attachmentUploadData  *AttachmentUploadFormData
 
 	// UI Elements
 	spinner       spinner.Model
@@ -286,29 +370,32 @@ type model struct {
 
 	// Help
 	helpViewport viewport.Model
+
+	// Outbox
+	outboxCursor int
+	replaying    bool
+
+	// Undo journal, oldest first (see undo.go)
+	undoJournal []undoEntry
 }
 
 func (m model) Init() tea.Cmd {
 
 	var cmds []tea.Cmd
 
-	cmds = append(cmds, tea.Tick(time.Minute, func(t time.Time) tea.Msg {
+	cmds = append(cmds, tea.Tick(pollInterval, func(t time.Time) tea.Msg {
 		return myIssuesPollMsg{}
 	}))
 
 	// Single perpetual detail-poll chain: it refreshes whatever detail is active
 	// (see issueDetailPollMsg). Started once here so exactly one chain exists.
-	cmds = append(cmds, tea.Tick(time.Minute, func(t time.Time) tea.Msg {
+	cmds = append(cmds, tea.Tick(pollInterval, func(t time.Time) tea.Msg {
 		return issueDetailPollMsg{}
 	}))
 
 	cmds = append(cmds, m.spinner.Tick)
-	cmds = append(cmds, m.fetchMySelfCmd())
-	cmds = append(cmds, m.fetchProjectsCmd())
-	cmds = append(cmds, m.fetchMyIssuesCmd())
-	cmds = append(cmds, m.fetchPrioritiesCmd())
-	cmds = append(cmds, m.fetchAllUsersCmd())
-	cmds = append(cmds, m.fetchIssueTypesCmd())
+	cmds = append(cmds, m.queryImageSupportCmd())
+	cmds = append(cmds, m.startupFetchCmds()...)
 
 	return tea.Batch(cmds...)
 }
@@ -321,6 +408,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if !nm.mode.isModal() {
 		nm.baseView = nm.mode
 	}
+	nm, sixelCmd := nm.syncSixels()
+	cmd = tea.Batch(cmd, sixelCmd)
 	// (Re)start the spinner animation when a load begins and the tick loop isn't
 	// already running.
 	if nm.loadingCount > 0 && !nm.spinning {
@@ -360,6 +449,12 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m.toggleTabGrouping()
 			case "?":
 				return m.openHelp()
+			case "O":
+				return m.openOutbox()
+			case "u":
+				return m.openUndoConfirm()
+			case "S":
+				return m.openActiveSprint()
 			}
 		}
 	}
@@ -368,7 +463,7 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case myselfLoadedMsg:
 		m.loadingCount--
 		m.myself = msg.me
-		return m, nil
+		return m, m.saveCacheCmd(cache.Myself, "", msg.me)
 
 	case issuesLoadedMsg:
 		m.loadingCount--
@@ -377,6 +472,9 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil // tab was closed; drop
 		}
 
+		m.tabs[idx].board.lastPoll = msg.fetchedAt
+		m.tabs[idx].board.lastFullLoad = msg.fetchedAt
+
 		aps := activeProjectsFor(msg.issues, m.projects)
 		if idx == m.activeTab {
 			m.issues = msg.issues
@@ -386,7 +484,10 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.tabs[idx].board.activeProjects = aps
 		}
 
-		var cmds []tea.Cmd
+		cmds := []tea.Cmd{
+			m.saveCacheCmd(cache.Issues, m.tabs[idx].board.jql, msg.issues),
+			m.maybeReplayOutbox(),
+		}
 		if len(m.projects) > 0 {
 			m.loadingCount++
 			cmds = append(cmds, m.fetchStatusesCmd(aps, msg.tabID))
@@ -397,6 +498,9 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, tea.Batch(cmds...)
 
+	case historyLoadedMsg:
+		return m.handleHistoryLoaded(msg)
+
 	case subTasksLoadedMsg:
 		m.loadingCount--
 		idx, ok := m.tabIndexByID(msg.tabID)
@@ -433,12 +537,18 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case prioritiesLoadedMsg:
 		m.loadingCount--
 		m.priorities = msg.priorities
-		return m, nil
+		// The board was sorted without them.
+		var selectedKey string
+		if m.selectedIssue != nil {
+			selectedKey = m.selectedIssue.Key
+		}
+		m.refreshBoard(selectedKey)
+		return m, m.saveCacheCmd(cache.Priorities, "", msg.priorities)
 
 	case projectsLoadedMsg:
 		m.loadingCount--
 		m.projects = msg.projects
-		var cmds []tea.Cmd
+		cmds := []tea.Cmd{m.saveCacheCmd(cache.Projects, "", msg.projects)}
 
 		if len(m.projects) > 0 && len(m.issues) > 0 {
 			m.activeProjects = activeProjectsFor(m.issues, m.projects)
@@ -465,6 +575,10 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		var cmds []tea.Cmd
+		// A refresh of the same issue (polling, ctrl+r) keeps the cursors
+		// and filters of the sections that don't track their own.
+		sameIssue := m.activeIssue != nil && msg.detail != nil && m.activeIssue.Key == msg.detail.Key
+		keepLoadedHistory(m.activeIssue, msg.detail)
 		m.activeIssue = msg.detail
 		m.detailLayout = m.calculateDetailLayout()
 		m.previousMode = m.mode
@@ -488,6 +602,18 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.issueLinksViewport.SetHeight(m.detailLayout.issueLinksHeight)
 		m.issueLinksViewport.SetContent(issueLinksContent)
 
+		if !sameIssue {
+			m.attachmentsCursor = 0
+			m.attachmentsViewport.SetYOffset(0)
+			m.historyFilter = ""
+			m.historyViewport.SetYOffset(0)
+		}
+		m.setAttachmentsContent()
+		if m.activeIssue != nil {
+			m.setHistoryContent()
+			cmds = append(cmds, m.fetchRestOfHistoryCmd())
+		}
+
 		m.loadingCount++
 		worklogsCmd := m.fetchWorkLogsCmd(m.activeIssue.ID)
 		cmds = append(cmds, worklogsCmd)
@@ -495,6 +621,7 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loadingCount++
 		subTasksCmd := m.fetchSubTasksCmd(m.activeIssue.Key)
 		cmds = append(cmds, subTasksCmd)
+		cmds = append(cmds, m.fetchThumbnailsCmd())
 
 		return m, tea.Batch(cmds...)
 
@@ -533,7 +660,7 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case issueTypesLoadedMsg:
 		m.loadingCount--
 		m.issueTypes = msg.issueTypes
-		return m, nil
+		return m, m.saveCacheCmd(cache.IssueTypes, "", msg.issueTypes)
 
 	case transitionsLoadedMsg:
 		m.loadingCount--
@@ -541,6 +668,9 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.transitionCache[msg.issueKey] = make(map[string][]jira.Transition)
 		}
 		m.transitionCache[msg.issueKey][msg.status] = msg.transitions
+		if m.pendingMoveStatus != "" && m.pendingIssue != nil && m.pendingIssue.Key == msg.issueKey {
+			return m.resumeCardMove(msg.transitions)
+		}
 		if m.mode == transitionView {
 			m.transitionData = NewTransitionFormData(msg.transitions)
 			return m, m.transitionData.Form.Init()
@@ -553,11 +683,12 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statuses = make(map[string][]jira.Status)
 		}
 		maps.Copy(m.statuses, msg.statuses) // shared cache across boards
+		saveCmd := m.saveCacheCmd(cache.Statuses, "", maps.Clone(m.statuses))
 
 		idx, ok := m.tabIndexByID(msg.tabID)
 		if !ok || idx != m.activeTab {
 			// Inactive tab: classification is deferred to loadActiveTab.
-			return m, nil
+			return m, saveCmd
 		}
 
 		m.sections = m.sectionsFor(m.issues)
@@ -573,14 +704,19 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		return m, nil
+		return m, saveCmd
 
 	case transitionPostedMsg:
 		m.loadingCount--
 		m.setSuccess("Issue transitioned")
 		var cmds []tea.Cmd
 		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
-		m.mode = detailView
+		m.mode = m.transitionDoneView()
+		if m.movingCard || m.activeIssue == nil {
+			// A kanban card move: the card already shows the new status.
+			m.movingCard = false
+			return m, tea.Batch(cmds...)
+		}
 		switch m.focusedSection {
 		case metadataSection:
 			m.loadingCount++
@@ -674,9 +810,18 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.fetchIssueDetailCmd(m.activeIssue.Key))
 		return m, tea.Batch(cmds...)
 
+	case issueLinkDeletedMsg:
+		m.loadingCount--
+		m.setSuccess("Issue link removed (u to undo)")
+		var cmds []tea.Cmd
+		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
+		m.loadingCount++
+		cmds = append(cmds, m.fetchIssueDetailCmd(m.activeIssue.Key))
+		return m, tea.Batch(cmds...)
+
 	case commentDeletedMsg:
 		m.loadingCount--
-		m.setSuccess("Comment deleted successfully")
+		m.setSuccess("Comment deleted (u to undo)")
 		var cmds []tea.Cmd
 		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
 		m.mode = detailView
@@ -710,7 +855,7 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case workLogDeletedMsg:
 		m.loadingCount--
-		m.setSuccess("Worklog deleted successfully")
+		m.setSuccess("Worklog deleted (u to undo)")
 		var cmds []tea.Cmd
 		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
 		m.mode = detailView
@@ -737,7 +882,7 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case usersLoadedMsg:
 		m.loadingCount--
 		m.usersCache = msg.users
-		return m, nil
+		return m, m.saveCacheCmd(cache.Users, "", msg.users)
 
 	case searchResultsLoadedMsg:
 		m.loadingCount--
@@ -756,28 +901,117 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case myIssuesPollMsg:
 		var cmds []tea.Cmd
-		m.loadingCount++
-		cmds = append(cmds, m.fetchMyIssuesCmd())
-		cmds = append(cmds, tea.Tick(time.Minute, func(t time.Time) tea.Msg {
+		now := time.Now()
+		for _, t := range m.tabs {
+			m.loadingCount++
+			cmds = append(cmds, m.pollBoardCmd(t, now))
+		}
+		cmds = append(cmds, tea.Tick(pollInterval, func(t time.Time) tea.Msg {
 			return myIssuesPollMsg{}
 		}))
-		m.setInfo("Fetching my issues...")
+		m.setInfo("Checking for updates...")
 		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
 
 		return m, tea.Batch(cmds...)
 
+	case issuesUpdatedMsg:
+		m.loadingCount--
+		nm, cmd := m.applyIssueUpdates(msg)
+		replay := nm.maybeReplayOutbox()
+		return nm, tea.Batch(cmd, replay)
+
+	case optimisticDoneMsg:
+		return m.handleOptimisticDone(msg)
+
+	case optimisticFailedMsg:
+		return m.handleOptimisticFailed(msg)
+
+	case journaledMsg:
+		return m.handleJournaled(msg)
+
+	case undoneMsg:
+		return m.handleUndone(msg)
+
+	case undoFailedMsg:
+		return m.handleUndoFailed(msg)
+
+	case activeSprintLoadedMsg:
+		return m.handleActiveSprintLoaded(msg)
+
+	case sprintsLoadedMsg:
+		return m.handleSprintsLoaded(msg)
+
+	case movedToSprintMsg:
+		return m.handleMovedToSprint(msg)
+
+	case issueRankedMsg:
+		m.loadingCount--
+		return m, nil
+
+	case rankFailedMsg:
+		return m.handleRankFailed(msg)
+
+	case fieldsLoadedMsg:
+		return m.handleFieldsLoaded(msg)
+
+	case fieldOptionsLoadedMsg:
+		return m.handleFieldOptionsLoaded(msg)
+
+	case customFieldUpdatedMsg:
+		return m.handleCustomFieldUpdated(msg)
+
+	case listOptionsLoadedMsg:
+		return m.handleListOptionsLoaded(msg)
+
+	case listFieldUpdatedMsg:
+		return m.handleListFieldUpdated(msg)
+
+	case attachmentDownloadedMsg:
+		return m.handleAttachmentDownloaded(msg)
+
+	case attachmentUploadedMsg:
+		return m.handleAttachmentUploaded(msg)
+
+	case watcherUpdatedMsg:
+		return m.handleWatcherUpdated(msg)
+
+	case editorFinishedMsg:
+		return m.handleEditorFinished(msg)
+
+	case thumbnailLoadedMsg:
+		return m.handleThumbnailLoaded(msg)
+
+	case sixelDrawMsg:
+		return m.handleSixelDraw(msg)
+
+	case uv.PrimaryDeviceAttributesEvent:
+		return m.handleDeviceAttributes(msg)
+
+	case uv.CellSizeEvent:
+		return m.handleCellSize(msg)
+
+	case opQueuedMsg:
+		return m.handleOpQueued(msg)
+
+	case outboxReplayedMsg:
+		return m.handleOutboxReplayed(msg)
+
+	case issueDetailUnchangedMsg:
+		m.loadingCount--
+		return m, nil
+
 	case issueDetailPollMsg:
 		var cmds []tea.Cmd
 
 		// Perpetual single chain: always reschedule, refresh whichever detail
 		// is currently active.
-		cmds = append(cmds, tea.Tick(time.Minute, func(t time.Time) tea.Msg {
+		cmds = append(cmds, tea.Tick(pollInterval, func(t time.Time) tea.Msg {
 			return issueDetailPollMsg{}
 		}))
 
 		if m.mode == detailView && m.activeIssue != nil {
 			m.loadingCount++
-			cmds = append(cmds, m.fetchIssueDetailCmd(m.activeIssue.Key))
+			cmds = append(cmds, m.pollIssueDetailCmd(m.activeIssue))
 			m.setInfo("Fetching issue details...")
 			cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
 		}
@@ -812,13 +1046,15 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.commentsViewport.SetWidth(m.detailLayout.leftColumnWidth)
 			m.commentsViewport.SetHeight(m.detailLayout.commentsHeight)
 			m.commentsViewport.SetContent(commentsContent)
+
+			m.setHistoryContent()
 		}
 
 		m.listLayout = m.calculateListLayout()
 		m.listViewport.SetWidth(m.listLayout.panelContentWidth)
 		m.listViewport.SetHeight(m.listLayout.listHeight)
 
-		m.columnWidths = ui.CalculateColumnWidths(msg.Width)
+		m.columnWidths = m.listColumnWidths(msg.Width)
 		m.listViewport.SetContent(m.buildListContent())
 
 		return m, nil
@@ -831,6 +1067,15 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMessage.content = ""
 		return m, nil
 
+	case retryNoticeMsg:
+		// Only fill an empty status bar or replace an earlier retry notice so
+		// a background retry never hides (or clears) another message.
+		if m.statusMessage.content != "" && !m.statusMessage.retry {
+			return m, nil
+		}
+		m.statusMessage = statusMessage{content: retryStatusMessage(msg.notice), msgType: infoStatusBarMsg, retry: true}
+		return m, m.clearStatusAfter(msg.notice.Wait + time.Second)
+
 	case errMsg:
 		var cmds []tea.Cmd
 
@@ -867,6 +1112,22 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		tmpModel, viewCmd = m.updateProjectPickerView(msg)
 	case helpView:
 		tmpModel, viewCmd = m.updateHelpView(msg)
+	case outboxView:
+		tmpModel, viewCmd = m.updateOutboxView(msg)
+	case undoConfirmView:
+		tmpModel, viewCmd = m.updateUndoConfirmView(msg)
+	case sprintPickerView:
+		tmpModel, viewCmd = m.updateSprintPickerView(msg)
+	case customFieldPickerView:
+		tmpModel, viewCmd = m.updateCustomFieldPickerView(msg)
+	case customFieldView:
+		tmpModel, viewCmd = m.updateCustomFieldView(msg)
+	case listFieldView:
+		tmpModel, viewCmd = m.updateListFieldView(msg)
+	case attachmentUploadView:
+		tmpModel, viewCmd = m.updateAttachmentUploadView(msg)
+	case watchersView:
+		tmpModel, viewCmd = m.updateWatchersView(msg)
 	case priorityView:
 		tmpModel, viewCmd = m.updateEditPriorityView(msg)
 	case transitionView:
@@ -896,10 +1157,20 @@ func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) View() tea.View {
+	content := m.renderView()
+	if m.images.protocol == termimg.Sixel {
+		// The markers only tell syncSixels where to draw.
+		content = termimg.StripMarkers(content)
+	}
+	return altScreenView(content)
+}
+
+// renderView renders the current view as a full-screen frame.
+func (m model) renderView() string {
 	var content string
 
 	if m.err != nil {
-		return altScreenView(fmt.Sprintf("Error: %v\n\nPress 'q' to quit.\n", m.err))
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit.\n", m.err)
 	}
 
 	switch m.mode {
@@ -923,6 +1194,22 @@ func (m model) View() tea.View {
 		content = m.renderProjectPickerView()
 	case helpView:
 		content = m.renderHelpView()
+	case outboxView:
+		content = m.renderOutboxView()
+	case undoConfirmView:
+		content = m.renderUndoConfirmView()
+	case sprintPickerView:
+		content = m.renderSprintPickerView()
+	case customFieldPickerView:
+		content = m.renderCustomFieldPickerView()
+	case customFieldView:
+		content = m.renderCustomFieldView()
+	case listFieldView:
+		content = m.renderListFieldView()
+	case attachmentUploadView:
+		content = m.renderAttachmentUploadView()
+	case watchersView:
+		content = m.renderWatchersView()
 	case priorityView:
 		content = m.renderEditPriorityView()
 	case commentView:
@@ -947,7 +1234,7 @@ func (m model) View() tea.View {
 		content = "Unknown view\n"
 	}
 
-	return altScreenView(content)
+	return content
 }
 
 // altScreenView renders content on the alternate screen buffer (full-window
@@ -976,12 +1263,31 @@ func main() {
 		}
 	}
 
-	cfg, err := config.LoadConfig()
+	configPath := flag.String("config", config.DefaultPath(), "path to the config file")
+	profile := flag.String("profile", "", "config profile to use (default: $JIRA_TUI_PROFILE or the file's default_profile)")
+	noCache := flag.Bool("no-cache", false, "don't read or write the on-disk cache")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig(*configPath, *profile)
 	if err != nil {
-		panic(err)
+		fmt.Fprintf(os.Stderr, "jira-tui: %v\n", err)
+		os.Exit(1)
 	}
 
-	client, _ := jira.NewClient(cfg.JiraURL, cfg.JIraEmail, cfg.JiraToken, cfg.TempoURL, cfg.TempoToken)
+	// p is assigned before Run starts any request, so the retry callback
+	// never sees it nil in practice; the check only guards early startup.
+	var p *tea.Program
+	client, _ := jira.NewClient(
+		cfg.JiraURL, cfg.JiraEmail, cfg.JiraToken, cfg.TempoURL, cfg.TempoToken,
+		jira.WithFlavor(jira.Flavor(cfg.JiraFlavor)),
+		jira.WithWorklogBackend(cfg.WorklogBackend),
+		jira.WithMiddleware(jira.LoggingMiddleware(nil)),
+		jira.WithRetryNotify(func(n jira.RetryNotice) {
+			if p != nil {
+				p.Send(retryNoticeMsg{n})
+			}
+		}),
+	)
 
 	logFile, err := os.OpenFile("debug.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err != nil {
@@ -1001,21 +1307,33 @@ func main() {
 
 	spinner := spinner.New()
 
-	p := tea.NewProgram(model{
-		mode:            listView,
-		baseView:        listView,
-		client:          client,
-		textInput:       textInput,
-		windowWidth:     80,
-		windowHeight:    24,
-		spinner:         spinner,
-		spinning:        true, // Init starts the tick loop
-		worklogTotals:   make(map[string]int),
-		columnWidths:    ui.CalculateColumnWidths(80),
-		loadingCount:    6, // Init cmds
-		transitionCache: make(map[string]map[string][]jira.Transition, 0),
-		activeTab:       0,
-		nextTabID:       1,
+	var store *cache.Store
+	if !*noCache {
+		store = cache.New(cache.DefaultDir(cfg.Profile))
+	}
+
+	queue := openOutbox(outbox.DefaultPath(cfg.Profile))
+
+	m := model{
+		mode:              listView,
+		baseView:          listView,
+		client:            client,
+		cache:             store,
+		outbox:            queue,
+		workflow:          cfg.Workflow,
+		customFieldConfig: cfg.CustomFields,
+		downloadDir:       cfg.DownloadDir,
+		images:            newImageState(cfg.InlineImages),
+		textInput:         textInput,
+		windowWidth:       80,
+		windowHeight:      24,
+		spinner:           spinner,
+		spinning:          true, // Init starts the tick loop
+		worklogTotals:     make(map[string]int),
+		columnWidths:      ui.CalculateColumnWidths(80),
+		transitionCache:   make(map[string]map[string][]jira.Transition, 0),
+		activeTab:         0,
+		nextTabID:         1,
 		tabs: []Tab{{
 			id:       0,
 			title:    "My Issues",
@@ -1023,7 +1341,11 @@ func main() {
 			baseView: listView,
 			board:    boardState{jql: myIssuesJQL},
 		}},
-	})
+	}
+	m.restoreCache()
+	m.loadingCount = len(m.startupFetchCmds()) // Init cmds
+
+	p = tea.NewProgram(m)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("error: %v\n", err)
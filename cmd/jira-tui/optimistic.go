@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+// Optimistic updates: transitions, assignee and priority changes are applied
+// to every loaded copy of the issue as soon as they are submitted, the row is
+// marked pending (see rowPrefix) and, if the request fails, the previous
+// values are put back.
+
+// optimisticDoneMsg wraps the result of a request whose change was already
+// applied; the wrapped msg is then handled as usual.
+type optimisticDoneMsg struct {
+	issueKey string
+	msg      tea.Msg
+}
+
+// optimisticFailedMsg reports a rejected request; restore undoes its change.
+type optimisticFailedMsg struct {
+	issueKey string
+	restore  func(*jira.Issue)
+	err      error
+}
+
+// applyOptimistic applies change to every loaded copy of the issue and wraps
+// cmd so its outcome settles (or rolls back) the pending row.
+func (m *model) applyOptimistic(issueKey string, change, restore func(*jira.Issue), cmd tea.Cmd) tea.Cmd {
+	if m.pendingIssues == nil {
+		m.pendingIssues = make(map[string]int)
+	}
+	m.pendingIssues[issueKey]++
+	m.patchIssue(issueKey, change)
+
+	return func() tea.Msg {
+		msg := cmd()
+		if e, ok := msg.(errMsg); ok {
+			return optimisticFailedMsg{issueKey: issueKey, restore: restore, err: e.err}
+		}
+		return optimisticDoneMsg{issueKey: issueKey, msg: msg}
+	}
+}
+
+// patchIssue applies fn to the issue with the given key on every board and in
+// the detail view, then redraws the active board. Slices are copied rather
+// than written in place because tabs and pending cache writes share them.
+func (m *model) patchIssue(issueKey string, fn func(*jira.Issue)) {
+	var selectedKey string
+	if m.selectedIssue != nil {
+		selectedKey = m.selectedIssue.Key
+	}
+
+	if issues, ok := patchIssues(m.issues, issueKey, fn); ok {
+		m.issues = issues
+		m.refreshBoard(selectedKey)
+	}
+	for i := range m.tabs {
+		if i == m.activeTab {
+			continue
+		}
+		if issues, ok := patchIssues(m.tabs[i].board.issues, issueKey, fn); ok {
+			m.tabs[i].board.issues = issues
+		}
+	}
+	if m.activeIssue != nil && m.activeIssue.Key == issueKey {
+		ai := *m.activeIssue
+		fn(&ai)
+		m.activeIssue = &ai
+	}
+}
+
+// patchIssues returns a copy of issues with fn applied to issueKey, or false
+// when the issue isn't there.
+func patchIssues(issues []jira.Issue, issueKey string, fn func(*jira.Issue)) ([]jira.Issue, bool) {
+	for i := range issues {
+		if issues[i].Key == issueKey {
+			patched := append([]jira.Issue(nil), issues...)
+			fn(&patched[i])
+			return patched, true
+		}
+	}
+	return issues, false
+}
+
+// settlePending clears one in-flight change from the issue's pending marker.
+func (m *model) settlePending(issueKey string) {
+	if m.pendingIssues[issueKey] <= 1 {
+		delete(m.pendingIssues, issueKey)
+	} else {
+		m.pendingIssues[issueKey]--
+	}
+}
+
+func (m model) isPending(issueKey string) bool {
+	return m.pendingIssues[issueKey] > 0
+}
+
+// currentIssueFields returns the loaded copy of the issue, preferring the
+// detail view's, so restore closures capture what the user actually saw.
+func (m model) currentIssueFields(issueKey string) (jira.Issue, bool) {
+	if m.activeIssue != nil && m.activeIssue.Key == issueKey {
+		return *m.activeIssue, true
+	}
+	for _, is := range m.issues {
+		if is.Key == issueKey {
+			return is, true
+		}
+	}
+	return jira.Issue{}, false
+}
+
+// optimisticTransition posts a transition with the issue already shown in the
+// target status.
+func (m *model) optimisticTransition(issueKey string, t jira.Transition, cmd tea.Cmd) tea.Cmd {
+	before, ok := m.currentIssueFields(issueKey)
+	if !ok || t.Name == "" {
+		return cmd
+	}
+	return m.applyOptimistic(issueKey,
+		func(is *jira.Issue) { is.Status = t.Name },
+		func(is *jira.Issue) { is.Status = before.Status },
+		cmd,
+	)
+}
+
+// optimisticAssignee posts an assignee change with the new assignee already
+// shown.
+func (m *model) optimisticAssignee(issueKey string, user jira.User, cmd tea.Cmd) tea.Cmd {
+	before, ok := m.currentIssueFields(issueKey)
+	if !ok {
+		return cmd
+	}
+	return m.applyOptimistic(issueKey,
+		func(is *jira.Issue) { is.Assignee = user.Name },
+		func(is *jira.Issue) { is.Assignee = before.Assignee },
+		cmd,
+	)
+}
+
+// optimisticPriority posts a priority change with the new priority already
+// shown.
+func (m *model) optimisticPriority(issueKey, priorityName string, cmd tea.Cmd) tea.Cmd {
+	before, ok := m.currentIssueFields(issueKey)
+	if !ok {
+		return cmd
+	}
+	priority := jira.Priority{Name: priorityName}
+	for _, p := range m.priorities {
+		if p.Name == priorityName {
+			priority = p
+		}
+	}
+	return m.applyOptimistic(issueKey,
+		func(is *jira.Issue) { is.Priority = priority },
+		func(is *jira.Issue) { is.Priority = before.Priority },
+		cmd,
+	)
+}
+
+// optimisticCustomField posts a custom field change with raw, the new value
+// as Jira returns it, already shown. A nil raw clears the field.
+func (m *model) optimisticCustomField(issueKey, fieldID string, raw json.RawMessage, cmd tea.Cmd) tea.Cmd {
+	before, ok := m.currentIssueFields(issueKey)
+	if !ok {
+		return cmd
+	}
+	set := func(value json.RawMessage) func(*jira.Issue) {
+		return func(is *jira.Issue) {
+			fields := maps.Clone(is.CustomFields)
+			if fields == nil {
+				fields = make(map[string]json.RawMessage)
+			}
+			if value == nil {
+				delete(fields, fieldID)
+			} else {
+				fields[fieldID] = value
+			}
+			is.CustomFields = fields
+		}
+	}
+	return m.applyOptimistic(issueKey, set(raw), set(before.CustomFields[fieldID]), cmd)
+}
+
+func (m model) handleOptimisticDone(msg optimisticDoneMsg) (tea.Model, tea.Cmd) {
+	m.settlePending(msg.issueKey)
+	if msg.msg == nil {
+		return m, nil
+	}
+	return m.update(msg.msg)
+}
+
+func (m model) handleOptimisticFailed(msg optimisticFailedMsg) (tea.Model, tea.Cmd) {
+	m.settlePending(msg.issueKey)
+	m.patchIssue(msg.issueKey, msg.restore)
+	return m.update(errMsg{fmt.Errorf("%s reverted: %w", msg.issueKey, msg.err)})
+}
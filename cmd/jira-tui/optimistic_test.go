@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func newOptimisticModel() model {
+	m := newTabModel([]Tab{
+		{id: 0, board: boardState{jql: "a"}},
+		{id: 1, board: boardState{jql: "b", issues: []jira.Issue{{Key: "DEV-1", Status: "To Do"}}}},
+	}, 0)
+	m.statuses = map[string][]jira.Status{
+		"P": {
+			{Name: "To Do", StatusCategory: jira.StatusCategory{Key: "new"}},
+			{Name: "In Progress", StatusCategory: jira.StatusCategory{Key: "indeterminate"}},
+		},
+	}
+	m.priorities = []jira.Priority{{ID: "2", Name: "High"}, {ID: "3", Name: "Medium"}}
+	m.issues = []jira.Issue{
+		{Key: "DEV-1", Status: "To Do", Assignee: "Ana", Priority: jira.Priority{ID: "3", Name: "Medium"}, Project: jira.Project{ID: "P"}},
+		{Key: "DEV-2", Status: "To Do", Project: jira.Project{ID: "P"}},
+	}
+	m.refreshBoard("DEV-1")
+	return m
+}
+
+func sectionOf(m model, key string) string {
+	for _, s := range m.sections {
+		for _, is := range s.Issues {
+			if is.Key == key {
+				return s.CategoryKey
+			}
+		}
+	}
+	return ""
+}
+
+func TestOptimisticTransitionAppliesImmediately(t *testing.T) {
+	m := newOptimisticModel()
+	original := m.issues
+
+	cmd := m.optimisticTransition("DEV-1", jira.Transition{ID: "21", Name: "In Progress"}, func() tea.Msg {
+		return transitionPostedMsg{}
+	})
+
+	if m.issues[0].Status != "In Progress" {
+		t.Errorf("status = %q, want In Progress", m.issues[0].Status)
+	}
+	if got := sectionOf(m, "DEV-1"); got != "indeterminate" {
+		t.Errorf("DEV-1 should move to the in-progress section, is in %q", got)
+	}
+	if m.tabs[1].board.issues[0].Status != "In Progress" {
+		t.Errorf("other tabs should see the change too")
+	}
+	if original[0].Status != "To Do" {
+		t.Errorf("the previous slice must not be modified in place")
+	}
+	if !m.isPending("DEV-1") || m.isPending("DEV-2") {
+		t.Errorf("only DEV-1 should be pending")
+	}
+
+	msg, ok := cmd().(optimisticDoneMsg)
+	if !ok {
+		t.Fatalf("success should produce optimisticDoneMsg")
+	}
+	if _, ok := msg.msg.(transitionPostedMsg); !ok {
+		t.Errorf("wrapped msg = %T, want transitionPostedMsg", msg.msg)
+	}
+}
+
+func TestOptimisticFailureRollsBack(t *testing.T) {
+	m := newOptimisticModel()
+	m.activeIssue = &jira.Issue{Key: "DEV-1", Assignee: "Ana", Priority: jira.Priority{Name: "Medium"}}
+
+	cmd := m.optimisticAssignee("DEV-1", jira.User{ID: "u2", Name: "Bo"}, func() tea.Msg {
+		return errMsg{errors.New("403 forbidden")}
+	})
+	m.loadingCount++
+	if m.issues[0].Assignee != "Bo" || m.activeIssue.Assignee != "Bo" {
+		t.Fatalf("assignee not applied: list %q, detail %q", m.issues[0].Assignee, m.activeIssue.Assignee)
+	}
+
+	failed, ok := cmd().(optimisticFailedMsg)
+	if !ok {
+		t.Fatalf("failure should produce optimisticFailedMsg")
+	}
+	next, _ := m.Update(failed)
+	nm := next.(model)
+
+	if nm.issues[0].Assignee != "Ana" || nm.activeIssue.Assignee != "Ana" {
+		t.Errorf("assignee not rolled back: list %q, detail %q", nm.issues[0].Assignee, nm.activeIssue.Assignee)
+	}
+	if nm.isPending("DEV-1") {
+		t.Error("pending marker should clear after the failure")
+	}
+	if nm.statusMessage.msgType != errStatusBarMsg {
+		t.Errorf("expected an error in the status bar, got %q", nm.statusMessage.content)
+	}
+}
+
+func TestOptimisticPriorityUsesKnownPriority(t *testing.T) {
+	m := newOptimisticModel()
+
+	m.optimisticPriority("DEV-1", "High", func() tea.Msg { return priorityPostedMsg{} })
+
+	if p := m.issues[0].Priority; p.ID != "2" || p.Name != "High" {
+		t.Errorf("priority = %+v, want High (id 2)", p)
+	}
+}
+
+func TestOptimisticDoneSettlesPending(t *testing.T) {
+	m := newOptimisticModel()
+	m.mode = detailView
+	m.activeIssue = &jira.Issue{Key: "DEV-1"}
+	m.optimisticPriority("DEV-1", "High", nil)
+	m.optimisticPriority("DEV-1", "Medium", nil)
+	m.loadingCount = 1
+
+	next, _ := m.Update(optimisticDoneMsg{issueKey: "DEV-1", msg: priorityPostedMsg{}})
+	nm := next.(model)
+	if !nm.isPending("DEV-1") {
+		t.Error("one of two changes is still in flight")
+	}
+
+	next, _ = nm.Update(optimisticDoneMsg{issueKey: "DEV-1", msg: priorityPostedMsg{}})
+	if next.(model).isPending("DEV-1") {
+		t.Error("pending marker should clear once every change settled")
+	}
+}
+
+func TestRowPrefixWidth(t *testing.T) {
+	for _, tc := range []struct{ selected, pending bool }{{false, false}, {true, false}, {false, true}, {true, true}} {
+		if w := lipgloss.Width(rowPrefix(tc.selected, tc.pending)); w != 2 {
+			t.Errorf("rowPrefix(%v, %v) is %d cells, want 2", tc.selected, tc.pending, w)
+		}
+	}
+}
+
+func TestOpeningPriorityAndAssigneeDoesNotCountAsLoading(t *testing.T) {
+	for _, key := range []string{"p", "a"} {
+		m := newOptimisticModel()
+		m.usersCache = []jira.User{{ID: "u1", Name: "Ana"}}
+
+		next, _ := m.Update(keyPress(key))
+		m = next.(model)
+		if m.mode != priorityView && m.mode != userSearchView {
+			t.Fatalf("%s: mode = %v, want the picker open", key, m.mode)
+		}
+		if m.loadingCount != 0 {
+			t.Errorf("%s: loadingCount = %d after opening the picker, want 0", key, m.loadingCount)
+		}
+		next, _ = m.Update(keyPress("esc"))
+		if n := next.(model).loadingCount; n != 0 {
+			t.Errorf("%s: loadingCount = %d after cancelling, want 0", key, n)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/outbox"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+const (
+	outboxModalWScale = 0.6
+	outboxModalHScale = 0.6
+)
+
+// opQueuedMsg reports a write that failed for lack of connectivity and was
+// saved to the outbox instead.
+type opQueuedMsg struct {
+	op outbox.Op
+}
+
+// outboxReplayedMsg reports a replay run. err is the connectivity failure that
+// stopped it, if any; the failed op and everything after it stay queued.
+// dropped are the ops Jira rejected, which were taken off the queue.
+type outboxReplayedMsg struct {
+	sent    int
+	dropped []droppedOp
+	err     error
+}
+
+// droppedOp is a queued write Jira refused on replay.
+type droppedOp struct {
+	op  outbox.Op
+	err error
+}
+
+// isOfflineErr reports whether err looks like Jira was unreachable (a
+// network failure or a gateway error) rather than a rejection, a bad
+// certificate or URL, or a local bug, all of which would fail again on replay.
+func isOfflineErr(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *jira.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return false
+	}
+
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	// The connection dropped before Jira answered.
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && (errors.Is(urlErr.Err, io.EOF) || errors.Is(urlErr.Err, io.ErrUnexpectedEOF))
+}
+
+// openOutbox opens the queue at path. A file that can't be read or decoded
+// is moved aside to path.bad and the queue starts empty, so it never keeps
+// the app from starting; nil means not even that worked and writes aren't
+// queued this session.
+func openOutbox(path string) *outbox.Outbox {
+	queue, err := outbox.Open(path)
+	if err == nil {
+		return queue
+	}
+	bad := path + ".bad"
+	slog.Warn("outbox unreadable, starting with an empty queue", "path", path, "moved_to", bad, "err", err)
+	if err := os.Rename(path, bad); err != nil {
+		slog.Error("moving the outbox aside", "err", err)
+		return nil
+	}
+	queue, err = outbox.Open(path)
+	if err != nil {
+		slog.Error("opening the outbox", "err", err)
+		return nil
+	}
+	return queue
+}
+
+// queueOrFail turns a failed write into opQueuedMsg when it failed for lack of
+// connectivity, and into the usual errMsg otherwise.
+func (m model) queueOrFail(op outbox.Op, err error) tea.Msg {
+	if m.outbox == nil || !isOfflineErr(err) {
+		return errMsg{err}
+	}
+	queued, qerr := m.outbox.Add(op)
+	if qerr != nil {
+		return errMsg{fmt.Errorf("%w (queueing for later also failed: %v)", err, qerr)}
+	}
+	return opQueuedMsg{queued}
+}
+
+// pendingTransitionName names the transition being posted, for the outbox
+// list; the post commands only carry its id.
+func (m model) pendingTransitionName() string {
+	if m.pendingTransition == nil {
+		return ""
+	}
+	return m.pendingTransition.Name
+}
+
+// issueKeyByID finds the key of a loaded issue. Worklogs are posted by issue
+// id, but the outbox lists them by key.
+func (m model) issueKeyByID(id string) string {
+	if m.activeIssue != nil && m.activeIssue.ID == id {
+		return m.activeIssue.Key
+	}
+	for _, is := range m.issues {
+		if is.ID == id {
+			return is.Key
+		}
+	}
+	return id
+}
+
+// sendOp performs a queued write.
+func (m model) sendOp(ctx context.Context, op outbox.Op) error {
+	switch op.Kind {
+	case outbox.Comment:
+		return m.client.PostComment(ctx, op.IssueKey, op.Body, m.usersCache)
+	case outbox.Worklog:
+		return m.client.PostWorkLog(ctx, op.IssueID, op.StartDate, op.AccountID, op.Description, op.Seconds)
+	case outbox.Transition:
+		return m.client.PostTransition(ctx, op.IssueKey, op.TransitionID, op.Fields, op.Body, op.WorklogTime)
+	default:
+		return fmt.Errorf("unknown queued operation %q", op.Kind)
+	}
+}
+
+// replayOutboxCmd sends queued writes oldest first. It stops when Jira is
+// unreachable again, so later writes never overtake earlier ones (a comment
+// queued after a transition may refer to it). Writes Jira rejects would fail
+// forever and hold up the rest, so they're dropped and reported instead.
+func (m model) replayOutboxCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return outboxReplayedMsg{err: fmt.Errorf("jira client not initialized")}
+		}
+
+		var msg outboxReplayedMsg
+		for _, op := range m.outbox.List() {
+			if err := m.sendOp(context.Background(), op); err != nil {
+				if isOfflineErr(err) {
+					_ = m.outbox.MarkFailed(op.ID, err)
+					msg.err = err
+					return msg
+				}
+				msg.dropped = append(msg.dropped, droppedOp{op: op, err: err})
+			} else {
+				msg.sent++
+			}
+			if err := m.outbox.Remove(op.ID); err != nil {
+				msg.err = err
+				return msg
+			}
+		}
+		return msg
+	}
+}
+
+// maybeReplayOutbox starts a replay when writes are queued and none is
+// running. It is called after successful fetches, i.e. when Jira is reachable.
+func (m *model) maybeReplayOutbox() tea.Cmd {
+	if m.replaying || m.outbox.Len() == 0 {
+		return nil
+	}
+	m.replaying = true
+	m.loadingCount++
+	return m.replayOutboxCmd()
+}
+
+func (m model) handleOpQueued(msg opQueuedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.mode = m.baseView
+	m.setInfo(fmt.Sprintf("Offline: %s queued (%d pending)", msg.op.Summary(), m.outbox.Len()))
+	return m, m.clearStatusAfter(clearMsgTimeout)
+}
+
+func (m model) handleOutboxReplayed(msg outboxReplayedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.replaying = false
+
+	var cmds []tea.Cmd
+	switch {
+	case len(msg.dropped) > 0:
+		for _, d := range msg.dropped {
+			slog.Error("dropping rejected queued change", "op", d.op.Summary(), "err", d.err)
+		}
+		d := msg.dropped[0]
+		text := fmt.Sprintf("Dropped queued %s: %s", d.op.Summary(), humanizeError(d.err))
+		if more := len(msg.dropped) - 1; more > 0 {
+			text += fmt.Sprintf(" (and %d more)", more)
+		}
+		m.statusMessage = statusMessage{content: text, msgType: errStatusBarMsg}
+		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
+	case msg.err != nil && !isOfflineErr(msg.err):
+		m.setError("sending queued changes", msg.err)
+		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
+	case msg.sent > 0:
+		m.setSuccess(fmt.Sprintf("Sent %d queued change(s)", msg.sent))
+		cmds = append(cmds, m.clearStatusAfter(clearMsgTimeout))
+	}
+
+	if msg.sent > 0 {
+		m.loadingCount++
+		cmds = append(cmds, m.fetchMyIssuesCmd())
+		if m.activeIssue != nil {
+			m.loadingCount++
+			cmds = append(cmds, m.fetchIssueDetailCmd(m.activeIssue.Key))
+		}
+	}
+	if m.mode == outboxView {
+		m.clampOutboxCursor()
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) openOutbox() (tea.Model, tea.Cmd) {
+	m.previousMode = m.mode
+	m.mode = outboxView
+	m.outboxCursor = 0
+	m.lastKey = ""
+	return m, nil
+}
+
+func (m *model) clampOutboxCursor() {
+	n := m.outbox.Len()
+	if m.outboxCursor >= n {
+		m.outboxCursor = n - 1
+	}
+	if m.outboxCursor < 0 {
+		m.outboxCursor = 0
+	}
+}
+
+func (m model) updateOutboxView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	kp, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return m, nil
+	}
+
+	ops := m.outbox.List()
+	key := kp.String()
+	switch key {
+	case "esc", "q", "O":
+		m.mode = m.previousMode
+		m.lastKey = ""
+		return m, nil
+	case "j", "down":
+		if m.outboxCursor < len(ops)-1 {
+			m.outboxCursor++
+		}
+	case "k", "up":
+		if m.outboxCursor > 0 {
+			m.outboxCursor--
+		}
+	case "r":
+		if cmd := m.maybeReplayOutbox(); cmd != nil {
+			m.setInfo(fmt.Sprintf("Sending %d queued change(s)...", len(ops)))
+			return m, tea.Batch(cmd, m.clearStatusAfter(clearMsgTimeout))
+		}
+	case "d":
+		if m.lastKey != "d" {
+			m.lastKey = "d"
+			return m, nil
+		}
+		m.lastKey = ""
+		if m.outboxCursor < len(ops) {
+			op := ops[m.outboxCursor]
+			if err := m.outbox.Remove(op.ID); err != nil {
+				m.setError("discarding queued change", err)
+			} else {
+				m.setSuccess("Discarded " + op.Summary())
+			}
+			m.clampOutboxCursor()
+			return m, m.clearStatusAfter(clearMsgTimeout)
+		}
+	}
+	m.lastKey = ""
+	return m, nil
+}
+
+func (m model) renderOutboxView() string {
+	width := ui.GetModalWidth(m.windowWidth, outboxModalWScale) - ui.PanelOverheadWidth
+	ops := m.outbox.List()
+
+	var b strings.Builder
+	if len(ops) == 0 {
+		b.WriteString(ui.DimTextStyle.Render("Nothing queued.") + "\n")
+	}
+	for i, op := range ops {
+		when := ui.PadCell(op.QueuedAt.Format("Jan 02 15:04"), 13)
+		row := when + " " + ui.PadCell(op.Summary(), max(width-16, 10))
+		if i == m.outboxCursor {
+			b.WriteString(ui.IconCursor + ui.SelectedRowStyle.Render(row) + "\n")
+		} else {
+			b.WriteString("  " + ui.NormalRowStyle.Render(row) + "\n")
+		}
+	}
+
+	if m.outboxCursor < len(ops) {
+		op := ops[m.outboxCursor]
+		b.WriteString("\n")
+		if text := op.Body + op.Description; text != "" {
+			b.WriteString(ui.DimTextStyle.Render(ui.TruncateLongString(text, width)) + "\n")
+		}
+		if op.LastError != "" {
+			failed := fmt.Sprintf("%s Failed %d time(s): %s", ui.IconError, op.Attempts, op.LastError)
+			b.WriteString(lipgloss.NewStyle().Foreground(ui.ThemeError).Render(ui.TruncateLongString(failed, width)) + "\n")
+		}
+	}
+
+	footer := ui.StatusBarInfoStyle.Render("  j/k move · r send now · dd discard · esc close")
+	return m.renderModal("Outbox", b.String()+"\n"+footer, outboxModalWScale, outboxModalHScale)
+}
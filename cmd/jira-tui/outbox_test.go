@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/outbox"
+)
+
+func newOutboxModel(t *testing.T) model {
+	t.Helper()
+	o, err := outbox.Open(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.outbox = o
+	return m
+}
+
+// offlineErr is how a write fails with the network down.
+var offlineErr = fmt.Errorf("failed to execute request: %w", &url.Error{Op: "Post", URL: "http://jira.invalid", Err: &net.OpError{Op: "dial", Err: errors.New("i/o timeout")}})
+
+func TestIsOfflineErr(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network", fmt.Errorf("wrapped: %w", &net.OpError{Op: "dial", Err: errors.New("no route to host")}), true},
+		{"gateway", &jira.APIError{StatusCode: 503}, true},
+		{"rejected", &jira.APIError{StatusCode: 400}, false},
+		{"forbidden", fmt.Errorf("posting: %w", &jira.APIError{StatusCode: 403}), false},
+		{"dropped", fmt.Errorf("failed to execute request: %w", &url.Error{Op: "Post", URL: "http://jira", Err: io.EOF}), true},
+		{"dns", &url.Error{Op: "Post", URL: "http://jira", Err: &net.DNSError{Err: "no such host", Name: "jira"}}, true},
+		{"certificate", &url.Error{Op: "Post", URL: "https://jira", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}}, false},
+		{"bad url", &url.Error{Op: "Post", URL: "jira", Err: errors.New("unsupported protocol scheme \"\"")}, false},
+		{"marshal", fmt.Errorf("failed to marshal request: %w", errors.New("json: unsupported type")), false},
+		{"no client", fmt.Errorf("jira client not initialized"), false},
+		{"canceled", context.Canceled, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := isOfflineErr(tt.err); got != tt.want {
+			t.Errorf("%s: isOfflineErr = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQueueOrFail(t *testing.T) {
+	m := newOutboxModel(t)
+	op := outbox.Op{Kind: outbox.Comment, IssueKey: "DEV-1", Body: "typed while offline"}
+
+	msg := m.queueOrFail(op, offlineErr)
+	queued, ok := msg.(opQueuedMsg)
+	if !ok {
+		t.Fatalf("offline failure: got %T, want opQueuedMsg", msg)
+	}
+	if queued.op.ID == "" || m.outbox.Len() != 1 {
+		t.Errorf("op not persisted: %+v, len %d", queued.op, m.outbox.Len())
+	}
+
+	msg = m.queueOrFail(op, &jira.APIError{StatusCode: 400})
+	if _, ok := msg.(errMsg); !ok {
+		t.Errorf("rejected write: got %T, want errMsg", msg)
+	}
+	if m.outbox.Len() != 1 {
+		t.Errorf("rejected write must not be queued")
+	}
+}
+
+func TestOpQueuedClosesModal(t *testing.T) {
+	m := newOutboxModel(t)
+	m.baseView = detailView
+	m.mode = commentView
+	m.loadingCount = 1
+
+	next, _ := m.Update(opQueuedMsg{outbox.Op{Kind: outbox.Comment, IssueKey: "DEV-1"}})
+	nm := next.(model)
+
+	if nm.mode != detailView {
+		t.Errorf("mode = %v, want detailView", nm.mode)
+	}
+	if nm.loadingCount != 0 {
+		t.Errorf("loadingCount = %d, want 0", nm.loadingCount)
+	}
+	if nm.statusMessage.content == "" {
+		t.Error("expected a status message about the queued change")
+	}
+}
+
+func TestOutboxViewDiscard(t *testing.T) {
+	m := newOutboxModel(t)
+	first, _ := m.outbox.Add(outbox.Op{Kind: outbox.Comment, IssueKey: "DEV-1"})
+	second, _ := m.outbox.Add(outbox.Op{Kind: outbox.Comment, IssueKey: "DEV-2"})
+
+	next, _ := m.openOutbox()
+	m = next.(model)
+	next, _ = m.Update(keyPress("j"))
+	m = next.(model)
+
+	// A single d only arms the discard.
+	next, _ = m.Update(keyPress("d"))
+	m = next.(model)
+	if m.outbox.Len() != 2 {
+		t.Fatalf("single d discarded an op")
+	}
+
+	next, _ = m.Update(keyPress("d"))
+	m = next.(model)
+	ops := m.outbox.List()
+	if len(ops) != 1 || ops[0].ID != first.ID {
+		t.Errorf("dd should discard %s, left %+v", second.ID, ops)
+	}
+	if m.outboxCursor != 0 {
+		t.Errorf("cursor = %d, want clamped to 0", m.outboxCursor)
+	}
+
+	next, _ = m.Update(keyPress("esc"))
+	if next.(model).mode != listView {
+		t.Errorf("esc should close the outbox")
+	}
+}
+
+func TestReplayedMsgClearsReplaying(t *testing.T) {
+	m := newOutboxModel(t)
+	m.replaying = true
+	m.loadingCount = 1
+
+	next, _ := m.Update(outboxReplayedMsg{err: offlineErr})
+	nm := next.(model)
+
+	if nm.replaying {
+		t.Error("replaying should reset after a run")
+	}
+	if nm.statusMessage.content != "" {
+		t.Errorf("still offline should be silent, got %q", nm.statusMessage.content)
+	}
+}
+
+func TestReplayDropsRejectedOps(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "DEV-1") {
+			http.Error(w, `{"errorMessages":["Issue does not exist"]}`, http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	m := newOutboxModel(t)
+	m.client, _ = jira.NewClient(srv.URL, "user@example.com", "token", "", "")
+	_, _ = m.outbox.Add(outbox.Op{Kind: outbox.Comment, IssueKey: "DEV-1", Body: "rejected"})
+	_, _ = m.outbox.Add(outbox.Op{Kind: outbox.Comment, IssueKey: "DEV-2", Body: "behind it"})
+
+	msg := m.replayOutboxCmd()().(outboxReplayedMsg)
+	if msg.sent != 1 || len(msg.dropped) != 1 || msg.err != nil {
+		t.Fatalf("replay = %+v, want DEV-1 dropped and DEV-2 sent", msg)
+	}
+	if m.outbox.Len() != 0 {
+		t.Errorf("outbox still holds %d op(s)", m.outbox.Len())
+	}
+
+	m.replaying = true
+	m.loadingCount = 1
+	next, _ := m.Update(msg)
+	nm := next.(model)
+	if nm.statusMessage.msgType != errStatusBarMsg || !strings.Contains(nm.statusMessage.content, "comment on DEV-1") {
+		t.Errorf("status = %q, want the dropped op reported", nm.statusMessage.content)
+	}
+}
+
+func TestOpenOutboxMovesACorruptFileAside(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	queue := openOutbox(path)
+	if queue == nil || queue.Len() != 0 {
+		t.Fatal("a corrupt outbox should leave an empty queue")
+	}
+	if raw, err := os.ReadFile(path + ".bad"); err != nil || string(raw) != "{not json" {
+		t.Errorf("the corrupt file should be kept aside, got %q (%v)", raw, err)
+	}
+	if _, err := queue.Add(outbox.Op{Kind: outbox.Comment, IssueKey: "DEV-1", Body: "x"}); err != nil {
+		t.Errorf("the fresh queue should take writes: %v", err)
+	}
+}
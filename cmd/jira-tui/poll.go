@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/cache"
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+const (
+	pollInterval = time.Minute
+	// fullReloadInterval is how often a board is re-run in full. Incremental
+	// polls only see issues that still match the JQL, so this is what notices
+	// issues that left the board (resolved, reassigned, ...).
+	fullReloadInterval = 10 * time.Minute
+	// pollOverlap widens each incremental window to cover clock skew between
+	// us and Jira and JQL's minute resolution. Re-merging an issue is harmless.
+	pollOverlap = time.Minute
+)
+
+var orderByPattern = regexp.MustCompile(`(?i)\border\s+by\b`)
+
+// issuesUpdatedMsg carries the issues of a board that changed since its last
+// poll. polledAt is when the request started, so nothing updated while it was
+// in flight can fall between two windows.
+type issuesUpdatedMsg struct {
+	issues   []jira.Issue
+	tabID    int
+	polledAt time.Time
+}
+
+// issueDetailUnchangedMsg reports that a detail poll found the issue as we
+// last fetched it.
+type issueDetailUnchangedMsg struct{}
+
+// incrementalJQL narrows a board's JQL to issues updated within the last
+// since, keeping its ORDER BY. The window is relative ("-5m") so it doesn't
+// depend on the Jira user's time zone.
+func incrementalJQL(jql string, since time.Duration) string {
+	minutes := int(math.Ceil((since + pollOverlap).Minutes()))
+	window := fmt.Sprintf("updated >= -%dm", minutes)
+
+	base, order := jql, ""
+	if loc := orderByPattern.FindStringIndex(jql); loc != nil {
+		base, order = jql[:loc[0]], " "+jql[loc[0]:]
+	}
+	base = strings.TrimSpace(base)
+	order = strings.TrimRight(order, " ")
+
+	if base == "" {
+		return window + order
+	}
+	return "(" + base + ") AND " + window + order
+}
+
+// mergeIssues replaces issues in existing with their updated copies by key and
+// appends the ones that are new to the board.
+func mergeIssues(existing, updated []jira.Issue) []jira.Issue {
+	byKey := make(map[string]int, len(existing))
+	merged := make([]jira.Issue, len(existing), len(existing)+len(updated))
+	copy(merged, existing)
+	for i, issue := range merged {
+		byKey[issue.Key] = i
+	}
+	for _, issue := range updated {
+		if i, ok := byKey[issue.Key]; ok {
+			merged[i] = issue
+			continue
+		}
+		byKey[issue.Key] = len(merged)
+		merged = append(merged, issue)
+	}
+	return merged
+}
+
+// pollBoardCmd refreshes one tab's board: a full reload when it has never
+// loaded or fullReloadInterval has passed, otherwise only what changed since
+// its last poll.
+func (m model) pollBoardCmd(tab Tab, now time.Time) tea.Cmd {
+	b := tab.board
+	if b.lastPoll.IsZero() || now.Sub(b.lastFullLoad) >= fullReloadInterval {
+		return m.fetchBoardIssuesCmd(b.jql, tab.id)
+	}
+
+	jql := incrementalJQL(b.jql, now.Sub(b.lastPoll))
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		issues, err := m.client.SearchIssuesJql(context.Background(), jql)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return issuesUpdatedMsg{issues: issues, tabID: tab.id, polledAt: now}
+	}
+}
+
+// pollIssueDetailCmd refetches the detail only when Jira reports the issue
+// changed since the copy we show.
+func (m model) pollIssueDetailCmd(issue *jira.Issue) tea.Cmd {
+	key, updated := issue.Key, issue.Updated
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		current, err := m.client.GetIssueUpdated(context.Background(), key)
+		if err != nil {
+			return errMsg{err}
+		}
+		if updated != "" && current == updated {
+			return issueDetailUnchangedMsg{}
+		}
+
+		return m.fetchIssueDetailCmd(key)()
+	}
+}
+
+// applyIssueUpdates merges a poll result into its tab. Only the changed
+// issues get their worklog totals refetched, and statuses only for projects
+// the board didn't have before.
+func (m model) applyIssueUpdates(msg issuesUpdatedMsg) (model, tea.Cmd) {
+	idx, ok := m.tabIndexByID(msg.tabID)
+	if !ok {
+		return m, nil
+	}
+	m.tabs[idx].board.lastPoll = msg.polledAt
+	if len(msg.issues) == 0 {
+		return m, nil
+	}
+
+	var issues []jira.Issue
+	if idx == m.activeTab {
+		var selectedKey string
+		if m.selectedIssue != nil {
+			selectedKey = m.selectedIssue.Key
+		}
+		m.issues = mergeIssues(m.issues, msg.issues)
+		m.activeProjects = activeProjectsFor(m.issues, m.projects)
+		m.refreshBoard(selectedKey)
+		issues = m.issues
+	} else {
+		b := &m.tabs[idx].board
+		b.issues = mergeIssues(b.issues, msg.issues)
+		b.activeProjects = activeProjectsFor(b.issues, m.projects)
+		issues = b.issues
+	}
+
+	cmds := []tea.Cmd{m.saveCacheCmd(cache.Issues, m.tabs[idx].board.jql, issues)}
+
+	var missing []jira.Project
+	for _, p := range activeProjectsFor(msg.issues, m.projects) {
+		if _, ok := m.statuses[p.ID]; !ok {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		m.loadingCount++
+		cmds = append(cmds, m.fetchStatusesCmd(missing, msg.tabID))
+	}
+
+	m.loadingCount++
+	cmds = append(cmds, m.fetchAllWorklogsTotalCmd(msg.issues, msg.tabID))
+
+	return m, tea.Batch(cmds...)
+}
+
+// refreshBoard rebuilds the active board's sections after its issues changed
+// in place, keeping the cursor on the issue with selectedKey when it is still
+// listed.
+func (m *model) refreshBoard(selectedKey string) {
+	m.sections = m.sectionsFor(m.issues)
+	if m.filteredSections != nil {
+		m.filteredSections = filterSections(m.sections, m.textInput.Value())
+	}
+
+	secs := m.sections
+	if m.filteredSections != nil {
+		secs = m.filteredSections
+	}
+	for si := range secs {
+		for ii := range secs[si].Issues {
+			if secs[si].Issues[ii].Key == selectedKey {
+				m.sectionCursor, m.cursor = si, ii
+			}
+		}
+	}
+
+	if si, ok := m.currentIssue(); ok {
+		m.selectedIssue = si
+	} else {
+		m.selectedIssue = nil
+	}
+	m.listViewport.SetContent(m.buildListContent())
+}
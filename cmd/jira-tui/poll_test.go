@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func TestIncrementalJQL(t *testing.T) {
+	tests := []struct {
+		jql   string
+		since time.Duration
+		want  string
+	}{
+		{
+			jql:   "assignee = currentUser() AND resolution = Unresolved ORDER BY status DESC",
+			since: time.Minute,
+			want:  "(assignee = currentUser() AND resolution = Unresolved) AND updated >= -2m ORDER BY status DESC",
+		},
+		{
+			jql:   "project = DEV",
+			since: 90 * time.Second,
+			want:  "(project = DEV) AND updated >= -3m",
+		},
+		{
+			jql:   "parent = DEV-1 order by rank",
+			since: 0,
+			want:  "(parent = DEV-1) AND updated >= -1m order by rank",
+		},
+		{
+			jql:   "ORDER BY updated DESC",
+			since: time.Minute,
+			want:  "updated >= -2m ORDER BY updated DESC",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := incrementalJQL(tt.jql, tt.since); got != tt.want {
+			t.Errorf("incrementalJQL(%q, %v)\n got %q\nwant %q", tt.jql, tt.since, got, tt.want)
+		}
+	}
+}
+
+func TestMergeIssues(t *testing.T) {
+	existing := []jira.Issue{{Key: "A-1", Summary: "old"}, {Key: "A-2", Summary: "keep"}}
+	updated := []jira.Issue{{Key: "A-1", Summary: "new"}, {Key: "A-3", Summary: "added"}}
+
+	got := mergeIssues(existing, updated)
+
+	want := []string{"A-1:new", "A-2:keep", "A-3:added"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d issues, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if g := got[i].Key + ":" + got[i].Summary; g != w {
+			t.Errorf("issue %d = %s, want %s", i, g, w)
+		}
+	}
+	if existing[0].Summary != "old" {
+		t.Error("mergeIssues must not modify the existing slice")
+	}
+}
+
+func TestApplyIssueUpdatesActiveTabKeepsSelection(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.projects = []jira.Project{{ID: "P"}}
+	m.issues = []jira.Issue{
+		{Key: "A-1", Status: "In Progress", Project: jira.Project{ID: "P"}},
+		{Key: "A-2", Status: "In Progress", Project: jira.Project{ID: "P"}},
+	}
+	m.refreshBoard("A-2")
+	if m.selectedIssue == nil || m.selectedIssue.Key != "A-2" {
+		t.Fatalf("setup: selected = %+v", m.selectedIssue)
+	}
+
+	polledAt := time.Now()
+	nm, _ := m.applyIssueUpdates(issuesUpdatedMsg{
+		issues:   []jira.Issue{{Key: "A-0", Status: "In Progress", Project: jira.Project{ID: "P"}}, {Key: "A-2", Summary: "renamed", Status: "In Progress", Project: jira.Project{ID: "P"}}},
+		tabID:    0,
+		polledAt: polledAt,
+	})
+
+	if len(nm.issues) != 3 {
+		t.Errorf("issues = %d, want 3", len(nm.issues))
+	}
+	if nm.selectedIssue == nil || nm.selectedIssue.Key != "A-2" || nm.selectedIssue.Summary != "renamed" {
+		t.Errorf("selection should follow A-2, got %+v", nm.selectedIssue)
+	}
+	if !nm.tabs[0].board.lastPoll.Equal(polledAt) {
+		t.Errorf("lastPoll = %v, want %v", nm.tabs[0].board.lastPoll, polledAt)
+	}
+}
+
+func TestApplyIssueUpdatesInactiveTab(t *testing.T) {
+	m := newTabModel([]Tab{
+		{id: 10, board: boardState{jql: "a"}},
+		{id: 20, board: boardState{jql: "b", issues: []jira.Issue{{Key: "B-1", Summary: "old"}}}},
+	}, 0)
+
+	nm, _ := m.applyIssueUpdates(issuesUpdatedMsg{issues: []jira.Issue{{Key: "B-1", Summary: "new"}}, tabID: 20})
+
+	if len(nm.issues) != 0 {
+		t.Errorf("active board should be untouched, got %d issues", len(nm.issues))
+	}
+	b := nm.tabs[1].board.issues
+	if len(b) != 1 || b[0].Summary != "new" {
+		t.Errorf("inactive tab not merged: %+v", b)
+	}
+}
+
+func TestApplyIssueUpdatesEmptyOnlyAdvancesPoll(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	polledAt := time.Now()
+
+	nm, cmd := m.applyIssueUpdates(issuesUpdatedMsg{tabID: 0, polledAt: polledAt})
+
+	if cmd != nil || nm.loadingCount != 0 {
+		t.Errorf("no follow-up fetches expected, loadingCount = %d", nm.loadingCount)
+	}
+	if !nm.tabs[0].board.lastPoll.Equal(polledAt) {
+		t.Errorf("lastPoll not advanced")
+	}
+}
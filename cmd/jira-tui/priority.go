@@ -55,7 +55,8 @@ func (m model) updateEditPriorityView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mode = detailView
 		if m.pendingIssue != nil {
 			priority := m.priorityData.SelectedPriority
-			cmds = append(cmds, m.postPriorityCmd(m.pendingIssue.Key, priority))
+			m.loadingCount++
+			cmds = append(cmds, m.optimisticPriority(m.pendingIssue.Key, priority, m.postPriorityCmd(m.pendingIssue.Key, priority)))
 		}
 	}
 
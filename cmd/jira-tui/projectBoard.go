@@ -15,8 +15,9 @@ import (
 type listGrouping int
 
 const (
-	groupStatus listGrouping = iota // group by status category (default)
-	groupEpic                       // group tasks under their epic, in rank order
+	groupStatus  listGrouping = iota // group by status category (default)
+	groupEpic                        // group tasks under their epic, in rank order
+	groupColumns                     // kanban: a column per status (see kanban.go)
 )
 
 func groupingForKind(kind tabKind) listGrouping {
@@ -34,15 +35,29 @@ func (m model) currentGrouping() listGrouping {
 	return groupStatus
 }
 
-// sectionsFor builds the display sections for the active tab's grouping.
+// sectionsFor builds the display sections for the active tab's grouping,
+// sorted by status and priority (priority only in columns) unless the tab
+// keeps rank order. Sorting here rather than while rendering keeps the cursor
+// on the issue it points at.
 func (m *model) sectionsFor(issues []jira.Issue) []Section {
-	if m.currentGrouping() == groupEpic {
+	switch m.currentGrouping() {
+	case groupEpic:
 		return groupByEpic(issues)
+	case groupColumns:
+		columns := m.groupByStatusColumns(issues)
+		if !m.keepsRankOrder() {
+			m.sortSectionsIssuesByPriority(columns)
+		}
+		return columns
+	}
+	sections := m.classifyIssues(issues, m.statuses)
+	if !m.keepsRankOrder() {
+		m.sortSectionsIssues(sections)
 	}
-	return m.classifyIssues(issues, m.statuses)
+	return sections
 }
 
-// toggleTabGrouping switches the active tab between status- and epic-grouped
+// toggleTabGrouping cycles the active tab through the status, epic and kanban
 // views and rebuilds the list. Persisted on the tab, so it sticks across tab
 // switches.
 func (m model) toggleTabGrouping() (tea.Model, tea.Cmd) {
@@ -50,12 +65,16 @@ func (m model) toggleTabGrouping() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	if m.tabs[m.activeTab].grouping == groupEpic {
-		m.tabs[m.activeTab].grouping = groupStatus
-		m.setInfo("View: status")
-	} else {
+	switch m.tabs[m.activeTab].grouping {
+	case groupStatus:
 		m.tabs[m.activeTab].grouping = groupEpic
 		m.setInfo("View: epics")
+	case groupEpic:
+		m.tabs[m.activeTab].grouping = groupColumns
+		m.setInfo("View: columns")
+	default:
+		m.tabs[m.activeTab].grouping = groupStatus
+		m.setInfo("View: status")
 	}
 
 	m.sections = m.sectionsFor(m.issues)
@@ -154,7 +173,7 @@ func (m model) buildEpicListContent() string {
 
 		for ii, issue := range s.Issues {
 			selected := m.sectionCursor == si && m.cursor == ii
-			dimmed := closureStatuses[issue.Status]
+			dimmed := m.isClosed(issue)
 			b.WriteString(m.renderIssueRow(issue, selected, dimmed) + "\n")
 		}
 		b.WriteString("\n\n")
@@ -71,13 +71,17 @@ func (m model) updateSearchUserView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.mode = commentView
 			return m, nil
 
+		case addWatcher:
+			return m.addSelectedWatcher(user)
+
 		case assignUser:
 			if m.pendingIssue == nil {
 				m.mode = m.previousMode
 				m.searchUserData = nil
 				return m, nil
 			}
-			cmds = append(cmds, m.postAssigneeCmd(m.pendingIssue.Key, user.ID))
+			m.loadingCount++
+			cmds = append(cmds, m.optimisticAssignee(m.pendingIssue.Key, user, m.postAssigneeCmd(m.pendingIssue.Key, user.ID)))
 			if m.focusedSection == metadataSection {
 				m.loadingCount++
 				cmds = append(cmds, m.fetchIssueDetailCmd(m.pendingIssue.Key))
@@ -115,6 +119,8 @@ func (m model) renderSearchUserView() string {
 	var label string
 	if m.userSelectionMode == assignUser && m.pendingIssue != nil {
 		label = "Assign " + m.pendingIssue.Key
+	} else if m.userSelectionMode == addWatcher {
+		label = "Add watcher"
 	} else {
 		label = "Mention User"
 	}
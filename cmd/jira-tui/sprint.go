@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Sprint tabs (S) show a project's active sprint grouped by status, in rank
+// order; J/K re-rank the selected issue and M moves an issue to another sprint.
+
+// activeSprintLoadedMsg carries the sprint to open a tab for.
+type activeSprintLoadedMsg struct {
+	sprint jira.Sprint
+}
+
+// sprintsLoadedMsg carries the sprints an issue can be moved to.
+type sprintsLoadedMsg struct {
+	issueKey string
+	sprints  []jira.Sprint
+}
+
+type movedToSprintMsg struct {
+	issueKey string
+	sprint   string
+}
+
+type issueRankedMsg struct{}
+
+// rankFailedMsg reports a rejected rank change; the board is reloaded to put
+// the issue back where Jira has it.
+type rankFailedMsg struct {
+	err error
+}
+
+func sprintJQL(sprintID int) string {
+	return fmt.Sprintf("sprint = %d ORDER BY Rank ASC", sprintID)
+}
+
+// currentSprint is the active tab's sprint, or nil when it isn't a sprint tab.
+func (m model) currentSprint() *jira.Sprint {
+	if m.activeTab >= 0 && m.activeTab < len(m.tabs) {
+		return m.tabs[m.activeTab].sprint
+	}
+	return nil
+}
+
+// keepsRankOrder reports whether the active tab lists issues in Jira's rank
+// order, which is what J/K change. Only sprint and project board tabs query
+// ORDER BY Rank; ranking against any other order would scramble the backlog.
+func (m model) keepsRankOrder() bool {
+	if m.activeTab < 0 || m.activeTab >= len(m.tabs) {
+		return false
+	}
+	kind := m.tabs[m.activeTab].kind
+	return kind == tabSprint || kind == tabProjectBoard
+}
+
+// projectKeyForSprints picks the project to look up boards in: the focused
+// issue's, or the board's only project.
+func (m model) projectKeyForSprints() string {
+	if m.mode == detailView && m.activeIssue != nil {
+		return m.activeIssue.Project.Key
+	}
+	if issue, ok := m.currentIssue(); ok && issue.Project.Key != "" {
+		return issue.Project.Key
+	}
+	if len(m.activeProjects) == 1 {
+		return m.activeProjects[0].Key
+	}
+	return ""
+}
+
+// projectSprints collects the sprints in the given states across a project's
+// scrum boards. Boards can share sprints, so they are de-duplicated.
+func (m model) projectSprints(ctx context.Context, projectKey string, states ...string) ([]jira.Sprint, error) {
+	boards, err := m.client.GetBoards(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var sprints []jira.Sprint
+	seen := make(map[int]bool)
+	for _, b := range boards {
+		if b.Type != "scrum" {
+			continue
+		}
+		bs, err := m.client.GetSprints(ctx, b.ID, states...)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range bs {
+			if !seen[s.ID] {
+				seen[s.ID] = true
+				sprints = append(sprints, s)
+			}
+		}
+	}
+	return sprints, nil
+}
+
+func (m model) fetchActiveSprintCmd(projectKey string) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		sprints, err := m.projectSprints(context.Background(), projectKey, jira.SprintActive)
+		if err != nil {
+			return errMsg{err}
+		}
+		if len(sprints) == 0 {
+			return errMsg{fmt.Errorf("no active sprint in %s", projectKey)}
+		}
+		return activeSprintLoadedMsg{sprint: sprints[0]}
+	}
+}
+
+func (m model) fetchSprintsCmd(projectKey, issueKey string) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		sprints, err := m.projectSprints(context.Background(), projectKey, jira.SprintActive, jira.SprintFuture)
+		if err != nil {
+			return errMsg{err}
+		}
+		if len(sprints) == 0 {
+			return errMsg{fmt.Errorf("no open sprints in %s", projectKey)}
+		}
+		return sprintsLoadedMsg{issueKey: issueKey, sprints: sprints}
+	}
+}
+
+func (m model) moveToSprintCmd(issueKey string, sprint jira.Sprint) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		if err := m.client.MoveIssuesToSprint(context.Background(), sprint.ID, issueKey); err != nil {
+			return errMsg{err}
+		}
+		return movedToSprintMsg{issueKey: issueKey, sprint: sprint.Name}
+	}
+}
+
+func (m model) rankIssueCmd(issueKey, beforeKey, afterKey string) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return rankFailedMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		if err := m.client.RankIssues(context.Background(), []string{issueKey}, beforeKey, afterKey); err != nil {
+			return rankFailedMsg{err}
+		}
+		return issueRankedMsg{}
+	}
+}
+
+func (m model) openActiveSprint() (tea.Model, tea.Cmd) {
+	projectKey := m.projectKeyForSprints()
+	if projectKey == "" {
+		m.setErrorMsg("Select an issue to open its project's sprint")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+	m.loadingCount++
+	m.setInfo("Looking up the active sprint of " + projectKey + "...")
+	return m, m.fetchActiveSprintCmd(projectKey)
+}
+
+func (m model) handleActiveSprintLoaded(msg activeSprintLoadedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.statusMessage = statusMessage{}
+
+	next, cmd := m.openBoardTab(msg.sprint.Name, sprintJQL(msg.sprint.ID), tabSprint)
+	m = next.(model)
+	sprint := msg.sprint
+	m.tabs[m.activeTab].sprint = &sprint
+	return m, cmd
+}
+
+type SprintPickerFormData struct {
+	IssueKey string
+	Sprints  []jira.Sprint
+	Selected int
+	Form     *huh.Form
+}
+
+func NewSprintPickerFormData(issueKey string, sprints []jira.Sprint) *SprintPickerFormData {
+	options := make([]huh.Option[int], len(sprints))
+	for i, s := range sprints {
+		label := s.Name
+		if s.State == jira.SprintActive {
+			label += " (active)"
+		}
+		options[i] = huh.NewOption(label, i)
+	}
+
+	d := &SprintPickerFormData{IssueKey: issueKey, Sprints: sprints}
+	d.Form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title("Move " + issueKey + " to sprint").
+				Options(options...).
+				Value(&d.Selected),
+		),
+	).WithWidth(50)
+
+	return d
+}
+
+// openSprintPicker loads the open sprints of the focused issue's project; the
+// picker opens once they arrive.
+func (m model) openSprintPicker() (tea.Model, tea.Cmd) {
+	var issue *jira.Issue
+	if m.mode == detailView {
+		issue = m.activeIssue
+	} else if si, ok := m.currentIssue(); ok {
+		issue = si
+	}
+	if issue == nil || issue.Project.Key == "" {
+		return m, nil
+	}
+	m.loadingCount++
+	return m, m.fetchSprintsCmd(issue.Project.Key, issue.Key)
+}
+
+func (m model) handleSprintsLoaded(msg sprintsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	if m.mode.isModal() {
+		return m, nil // the user moved on to something else
+	}
+	m.previousMode = m.mode
+	m.sprintPickerData = NewSprintPickerFormData(msg.issueKey, msg.sprints)
+	m.mode = sprintPickerView
+	return m, m.sprintPickerData.Form.Init()
+}
+
+func (m model) updateSprintPickerView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	if keyPressMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyPressMsg.String() {
+		case "esc":
+			m.mode = m.previousMode
+			m.sprintPickerData = nil
+			return m, nil
+		}
+	}
+
+	form, cmd := m.sprintPickerData.Form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.sprintPickerData.Form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.sprintPickerData.Form.State == huh.StateCompleted {
+		d := m.sprintPickerData
+		m.sprintPickerData = nil
+		m.mode = m.previousMode
+		if d.Selected >= 0 && d.Selected < len(d.Sprints) {
+			m.loadingCount++
+			cmds = append(cmds, m.moveToSprintCmd(d.IssueKey, d.Sprints[d.Selected]))
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) renderSprintPickerView() string {
+	var content string
+	if m.sprintPickerData != nil {
+		content = m.sprintPickerData.Form.View()
+	}
+	return m.renderModal("Move to Sprint", content, 0.3, 0.3)
+}
+
+func (m model) handleMovedToSprint(msg movedToSprintMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.setSuccess(fmt.Sprintf("Moved %s to %s", msg.issueKey, msg.sprint))
+	m.loadingCount++
+	return m, tea.Batch(m.clearStatusAfter(clearMsgTimeout), m.fetchMyIssuesCmd())
+}
+
+// rankSelected moves the selected issue one place up (dir -1) or down (dir +1)
+// within its section: it is shown there right away and ranked before the
+// issue above it, or after the one below it.
+func (m model) rankSelected(dir int) (tea.Model, tea.Cmd) {
+	if !m.keepsRankOrder() || m.filteredSections != nil {
+		return m, nil
+	}
+	secs := m.navSections()
+	if m.sectionCursor < 0 || m.sectionCursor >= len(secs) {
+		return m, nil
+	}
+	issues := secs[m.sectionCursor].Issues
+	target := m.cursor + dir
+	if m.cursor >= len(issues) || target < 0 || target >= len(issues) {
+		return m, nil
+	}
+	key, neighbor := issues[m.cursor].Key, issues[target].Key
+
+	var beforeKey, afterKey string
+	if dir < 0 {
+		beforeKey = neighbor
+	} else {
+		afterKey = neighbor
+	}
+	m.issues = moveIssue(m.issues, key, neighbor, dir > 0)
+	m.refreshBoard(key)
+
+	m.loadingCount++
+	return m, m.rankIssueCmd(key, beforeKey, afterKey)
+}
+
+// moveIssue returns a copy of issues with key moved right before (or, with
+// after, right after) neighbor.
+func moveIssue(issues []jira.Issue, key, neighbor string, after bool) []jira.Issue {
+	from := slices.IndexFunc(issues, func(is jira.Issue) bool { return is.Key == key })
+	if from < 0 {
+		return issues
+	}
+	moved := issues[from]
+	out := slices.Delete(slices.Clone(issues), from, from+1)
+	to := slices.IndexFunc(out, func(is jira.Issue) bool { return is.Key == neighbor })
+	if to < 0 {
+		return issues
+	}
+	if after {
+		to++
+	}
+	return slices.Insert(out, to, moved)
+}
+
+func (m model) handleRankFailed(msg rankFailedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.setError("ranking issue", msg.err)
+	m.loadingCount++
+	return m, tea.Batch(m.clearStatusAfter(clearMsgTimeout), m.fetchMyIssuesCmd())
+}
+
+// sprintInfo is the info panel's summary of a sprint tab, e.g.
+// "Sprint 12 · Oct 05 – Oct 19 · 2d left".
+func sprintInfo(s *jira.Sprint, now time.Time) string {
+	info := ui.IconSprint + " " + s.Name
+	if !s.StartDate.IsZero() && !s.EndDate.IsZero() {
+		info += fmt.Sprintf(" · %s – %s", s.StartDate.Local().Format("Jan 02"), s.EndDate.Local().Format("Jan 02"))
+	}
+	if !s.EndDate.IsZero() {
+		info += " · " + formatRemaining(s.Remaining(now))
+	}
+	return info
+}
+
+// formatRemaining renders the time left in a sprint at day (or, on the last
+// day, hour) precision.
+func formatRemaining(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "ended"
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd left", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh left", int(d.Hours()))
+	default:
+		return "<1h left"
+	}
+}
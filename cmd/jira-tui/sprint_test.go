@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func newSprintModel() model {
+	m := newTabModel([]Tab{{id: 0, kind: tabSprint, board: boardState{jql: sprintJQL(7)}}}, 0)
+	m.issues = []jira.Issue{
+		{Key: "DEV-1", Status: "In Progress", Priority: jira.Priority{Name: "Low"}, Project: jira.Project{ID: "P", Key: "DEV"}},
+		{Key: "DEV-2", Status: "In Progress", Priority: jira.Priority{Name: "High"}, Project: jira.Project{ID: "P", Key: "DEV"}},
+		{Key: "DEV-3", Status: "In Progress", Project: jira.Project{ID: "P", Key: "DEV"}},
+	}
+	m.priorities = []jira.Priority{{Name: "High"}, {Name: "Low"}}
+	m.refreshBoard("DEV-1")
+	return m
+}
+
+func keysOf(issues []jira.Issue) string {
+	var keys []string
+	for _, is := range issues {
+		keys = append(keys, is.Key)
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestMoveIssue(t *testing.T) {
+	issues := []jira.Issue{{Key: "A"}, {Key: "B"}, {Key: "C"}}
+
+	if got := keysOf(moveIssue(issues, "C", "A", false)); got != "C,A,B" {
+		t.Errorf("before A: %s", got)
+	}
+	if got := keysOf(moveIssue(issues, "A", "B", true)); got != "B,A,C" {
+		t.Errorf("after B: %s", got)
+	}
+	if got := keysOf(moveIssue(issues, "A", "missing", true)); got != "A,B,C" {
+		t.Errorf("unknown neighbor should leave the order alone: %s", got)
+	}
+	if keysOf(issues) != "A,B,C" {
+		t.Error("the input slice must not be modified")
+	}
+}
+
+func TestSprintTabKeepsRankOrder(t *testing.T) {
+	m := newSprintModel()
+	m.buildListContent()
+	if got := keysOf(m.sections[0].Issues); got != "DEV-1,DEV-2,DEV-3" {
+		t.Errorf("sprint tab should not re-sort by priority: %s", got)
+	}
+}
+
+func TestStatusTabSortsWhenBuilt(t *testing.T) {
+	m := newSprintModel()
+	m.tabs[0].kind = tabMyIssues
+	m.refreshBoard("DEV-1")
+	if got := keysOf(m.sections[0].Issues); got != "DEV-2,DEV-1,DEV-3" {
+		t.Errorf("sections = %s, want sorted by priority", got)
+	}
+	if m.sections[0].Issues[m.cursor].Key != "DEV-1" {
+		t.Errorf("cursor %d should still point at DEV-1", m.cursor)
+	}
+
+	m.buildListContent()
+	if got := keysOf(m.sections[0].Issues); got != "DEV-2,DEV-1,DEV-3" {
+		t.Errorf("rendering should not reorder sections: %s", got)
+	}
+
+	// Priorities arriving after the issues re-sort the board.
+	m.priorities = nil
+	m.refreshBoard("DEV-1")
+	m.loadingCount = 1
+	next, _ := m.Update(prioritiesLoadedMsg{priorities: []jira.Priority{{Name: "Low"}, {Name: "High"}}})
+	nm := next.(model)
+	if got := keysOf(nm.sections[0].Issues); got != "DEV-1,DEV-2,DEV-3" {
+		t.Errorf("sections = %s after priorities loaded", got)
+	}
+	if nm.selectedIssue == nil || nm.selectedIssue.Key != "DEV-1" {
+		t.Error("selection should survive the re-sort")
+	}
+}
+
+func TestRankSelectedDown(t *testing.T) {
+	m := newSprintModel()
+
+	next, cmd := m.Update(keyPress("J"))
+	nm := next.(model)
+	if cmd == nil {
+		t.Fatal("expected a rank request")
+	}
+	if got := keysOf(nm.issues); got != "DEV-2,DEV-1,DEV-3" {
+		t.Errorf("issues = %s, want DEV-1 moved below DEV-2", got)
+	}
+	if nm.selectedIssue == nil || nm.selectedIssue.Key != "DEV-1" || nm.cursor != 1 {
+		t.Errorf("selection should follow the ranked issue, cursor %d", nm.cursor)
+	}
+
+	// At the top already: nothing to do.
+	m = newSprintModel()
+	next, cmd = m.Update(keyPress("K"))
+	if cmd != nil || keysOf(next.(model).issues) != "DEV-1,DEV-2,DEV-3" {
+		t.Error("ranking the first issue up should be a no-op")
+	}
+}
+
+func TestRankOnlyInRankOrderedTabs(t *testing.T) {
+	m := newSprintModel()
+	m.tabs[0].kind = tabSavedBoard
+	_, cmd := m.Update(keyPress("J"))
+	if cmd != nil {
+		t.Error("status-sorted boards don't show rank, so J shouldn't rank")
+	}
+
+	// Grouping by epic doesn't make a tab's JQL rank ordered.
+	m = newSprintModel()
+	m.tabs[0].kind = tabMyIssues
+	m.tabs[0].grouping = groupEpic
+	if _, cmd := m.Update(keyPress("J")); cmd != nil {
+		t.Error("epic-grouped My Issues is sorted by status, so J shouldn't rank")
+	}
+
+	m = newSprintModel()
+	m.tabs[0].kind = tabProjectBoard
+	if _, cmd := m.Update(keyPress("J")); cmd == nil {
+		t.Error("project boards are rank ordered, so J should rank")
+	}
+}
+
+func TestRankFailedReloads(t *testing.T) {
+	m := newSprintModel()
+	m.loadingCount = 1
+	next, cmd := m.Update(rankFailedMsg{errors.New("boom")})
+	nm := next.(model)
+	if cmd == nil || nm.loadingCount != 1 {
+		t.Errorf("a rejected rank should reload the board (loadingCount %d)", nm.loadingCount)
+	}
+	if nm.statusMessage.msgType != errStatusBarMsg {
+		t.Errorf("expected an error, got %q", nm.statusMessage.content)
+	}
+}
+
+func TestActiveSprintOpensTab(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.loadingCount = 1
+	sprint := jira.Sprint{ID: 7, Name: "Sprint 7"}
+
+	next, _ := m.Update(activeSprintLoadedMsg{sprint: sprint})
+	nm := next.(model)
+
+	if len(nm.tabs) != 2 || nm.activeTab != 1 {
+		t.Fatalf("expected a new active tab, have %d tabs (active %d)", len(nm.tabs), nm.activeTab)
+	}
+	tab := nm.tabs[1]
+	if tab.kind != tabSprint || tab.title != "Sprint 7" || tab.board.jql != sprintJQL(7) {
+		t.Errorf("unexpected tab: kind %v, title %q, jql %q", tab.kind, tab.title, tab.board.jql)
+	}
+	if s := nm.currentSprint(); s == nil || s.ID != 7 {
+		t.Errorf("currentSprint = %+v", s)
+	}
+}
+
+func TestSprintInfo(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	s := &jira.Sprint{
+		Name:      "Sprint 7",
+		StartDate: time.Date(2026, 10, 5, 9, 0, 0, 0, time.Local),
+		EndDate:   time.Date(2026, 10, 19, 18, 0, 0, 0, time.Local),
+	}
+	got := sprintInfo(s, now)
+	if !strings.Contains(got, "Sprint 7 · Oct 05 – Oct 19 · 2d left") {
+		t.Errorf("sprintInfo = %q", got)
+	}
+
+	if got := sprintInfo(&jira.Sprint{Name: "Next"}, now); strings.Contains(got, "·") {
+		t.Errorf("a sprint without dates shows only its name, got %q", got)
+	}
+
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "ended"},
+		{30 * time.Minute, "<1h left"},
+		{5 * time.Hour, "5h left"},
+		{50 * time.Hour, "2d left"},
+	}
+	for _, tt := range tests {
+		if got := formatRemaining(tt.d); got != tt.want {
+			t.Errorf("formatRemaining(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
 )
@@ -24,6 +25,8 @@ const (
 type statusMessage struct {
 	content string
 	msgType messageType
+	// retry marks a retry notice, the only message a later notice replaces.
+	retry bool
 }
 
 // setError records a failure: it logs the full error detail (for debug.log)
@@ -99,3 +102,20 @@ func httpStatusMessage(code int) string {
 		return fmt.Sprintf("unexpected response (%d)", code)
 	}
 }
+
+// retryStatusMessage describes a transient failure the client is retrying,
+// e.g. "rate limited, retrying in 3s".
+func retryStatusMessage(n jira.RetryNotice) string {
+	wait := max(n.Wait.Round(time.Second), time.Second)
+
+	var reason string
+	switch {
+	case n.RateLimited():
+		reason = "rate limited"
+	case n.StatusCode != 0:
+		reason = httpStatusMessage(n.StatusCode)
+	default:
+		reason = "cannot reach Jira"
+	}
+	return fmt.Sprintf("%s, retrying in %s", reason, wait)
+}
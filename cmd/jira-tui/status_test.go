@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
 )
@@ -87,3 +88,51 @@ func TestAPIErrorIncludesBody(t *testing.T) {
 		}
 	}
 }
+
+func TestRetryStatusMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		notice jira.RetryNotice
+		want   string
+	}{
+		{"rate limited", jira.RetryNotice{StatusCode: 429, Wait: 3 * time.Second}, "rate limited, retrying in 3s"},
+		{"server error", jira.RetryNotice{StatusCode: 503, Wait: 1500 * time.Millisecond}, "Jira server error (503), retrying in 2s"},
+		{"network error", jira.RetryNotice{Err: errors.New("reset"), Wait: 200 * time.Millisecond}, "cannot reach Jira, retrying in 1s"},
+	}
+
+	for _, tt := range tests {
+		if got := retryStatusMessage(tt.notice); got != tt.want {
+			t.Errorf("%s: retryStatusMessage() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRetryNoticeOnlyReplacesRetryNotices(t *testing.T) {
+	notice := retryNoticeMsg{jira.RetryNotice{StatusCode: 429, Wait: time.Second}}
+
+	m := newTabModel([]Tab{{}}, 0)
+	next, cmd := m.Update(notice)
+	m = next.(model)
+	if cmd == nil || m.statusMessage.content != "rate limited, retrying in 1s" {
+		t.Fatalf("status = %q, want the notice shown and cleared later", m.statusMessage.content)
+	}
+	next, cmd = m.Update(retryNoticeMsg{jira.RetryNotice{StatusCode: 503, Wait: time.Second}})
+	if m = next.(model); cmd == nil || !strings.Contains(m.statusMessage.content, "503") {
+		t.Errorf("status = %q, want the earlier notice replaced", m.statusMessage.content)
+	}
+
+	for _, set := range []func(*model){
+		func(m *model) { m.setErrorMsg("Transition failed") },
+		func(m *model) { m.setInfo("Link 2/5: DEV-2 · enter to open") },
+	} {
+		m := newTabModel([]Tab{{}}, 0)
+		set(&m)
+		want := m.statusMessage
+		next, cmd := m.Update(notice)
+		if got := next.(model).statusMessage; cmd != nil || got != want {
+			t.Errorf("status = %q (clear scheduled: %v), want %q kept", got.content, cmd != nil, want.content)
+		}
+	}
+}
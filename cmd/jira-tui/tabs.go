@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
@@ -20,6 +21,7 @@ const (
 	tabEpicBoard
 	tabSavedBoard
 	tabProjectBoard
+	tabSprint
 )
 
 // boardState is the per-tab list/board state. sections and filteredSections are
@@ -35,6 +37,10 @@ type boardState struct {
 	cursor         int
 	sectionCursor  int
 	listYOffset    int
+	// lastPoll and lastFullLoad are when the last poll and the last full
+	// reload of this board started (see pollBoardCmd).
+	lastPoll     time.Time
+	lastFullLoad time.Time
 }
 
 // detailState is the per-tab drill-down state. activeIssue is a self-contained
@@ -42,17 +48,22 @@ type boardState struct {
 // a board), so storing the pointer is safe. Viewports are not stored; only their
 // scroll offsets, and they are rebuilt for the current window on load.
 type detailState struct {
-	activeIssue       *jira.Issue
-	focusedSection    focusedSection
-	commentsCursor    int
-	worklogsCursor    int
-	issueLinksCursor  int
-	subTasksCursor    int
-	descYOffset       int
-	commentsYOffset   int
-	worklogsYOffset   int
-	issueLinksYOffset int
-	subTasksYOffset   int
+	activeIssue        *jira.Issue
+	focusedSection     focusedSection
+	commentsCursor     int
+	worklogsCursor     int
+	issueLinksCursor   int
+	subTasksCursor     int
+	attachmentsCursor  int
+	historyFilter      string
+	descYOffset        int
+	commentsYOffset    int
+	worklogsYOffset    int
+	issueLinksYOffset  int
+	subTasksYOffset    int
+	attachmentsYOffset int
+	historyYOffset     int
+	navHistory         []string
 }
 
 type Tab struct {
@@ -63,6 +74,8 @@ type Tab struct {
 	baseView viewMode     // listView or detailView
 	board    boardState
 	detail   detailState
+	// sprint is set on sprint tabs, for the info panel.
+	sprint *jira.Sprint
 }
 
 // SavedBoard is a predefined board available from the saved-board picker (B).
@@ -76,6 +89,7 @@ var savedBoards = []SavedBoard{
 	{Title: "My Issues", JQL: myIssuesJQL},
 	{Title: "Reported by me", JQL: "reporter = currentUser() AND resolution = Unresolved ORDER BY updated DESC"},
 	{Title: "Updated recently", JQL: "assignee = currentUser() ORDER BY updated DESC"},
+	{Title: "Issues I watch", JQL: "watcher = currentUser() AND resolution = Unresolved ORDER BY updated DESC"},
 }
 
 func (m model) activeTabID() int {
@@ -118,19 +132,26 @@ func (m *model) saveActiveTab() {
 		cursor:         m.cursor,
 		sectionCursor:  m.sectionCursor,
 		listYOffset:    m.listViewport.YOffset(),
+		lastPoll:       t.board.lastPoll,
+		lastFullLoad:   t.board.lastFullLoad,
 	}
 	t.detail = detailState{
-		activeIssue:       m.activeIssue,
-		focusedSection:    m.focusedSection,
-		commentsCursor:    m.commentsCursor,
-		worklogsCursor:    m.worklogsCursor,
-		issueLinksCursor:  m.IssueLinksCursor,
-		subTasksCursor:    m.subTasksCursor,
-		descYOffset:       m.descViewport.YOffset(),
-		commentsYOffset:   m.commentsViewport.YOffset(),
-		worklogsYOffset:   m.worklogsViewport.YOffset(),
-		issueLinksYOffset: m.issueLinksViewport.YOffset(),
-		subTasksYOffset:   m.subTasksViewport.YOffset(),
+		activeIssue:        m.activeIssue,
+		focusedSection:     m.focusedSection,
+		commentsCursor:     m.commentsCursor,
+		worklogsCursor:     m.worklogsCursor,
+		issueLinksCursor:   m.IssueLinksCursor,
+		subTasksCursor:     m.subTasksCursor,
+		attachmentsCursor:  m.attachmentsCursor,
+		historyFilter:      m.historyFilter,
+		descYOffset:        m.descViewport.YOffset(),
+		commentsYOffset:    m.commentsViewport.YOffset(),
+		worklogsYOffset:    m.worklogsViewport.YOffset(),
+		issueLinksYOffset:  m.issueLinksViewport.YOffset(),
+		subTasksYOffset:    m.subTasksViewport.YOffset(),
+		attachmentsYOffset: m.attachmentsViewport.YOffset(),
+		historyYOffset:     m.historyViewport.YOffset(),
+		navHistory:         m.navHistory,
 	}
 }
 
@@ -183,6 +204,9 @@ func (m *model) loadActiveTab() tea.Cmd {
 	m.worklogsCursor = t.detail.worklogsCursor
 	m.IssueLinksCursor = t.detail.issueLinksCursor
 	m.subTasksCursor = t.detail.subTasksCursor
+	m.attachmentsCursor = t.detail.attachmentsCursor
+	m.historyFilter = t.detail.historyFilter
+	m.navHistory = t.detail.navHistory
 
 	if m.activeIssue != nil {
 		m.detailLayout = m.calculateDetailLayout()
@@ -212,12 +236,20 @@ func (m *model) loadActiveTab() tea.Cmd {
 		m.subTasksViewport.SetContent(m.buildSubTasksContent(m.detailLayout.rightColumnWidth - ui.PanelOverheadWidth))
 		m.subTasksViewport.SetYOffset(t.detail.subTasksYOffset)
 
+		m.setAttachmentsContent()
+		m.attachmentsViewport.SetYOffset(t.detail.attachmentsYOffset)
+
+		m.setHistoryContent()
+		m.historyViewport.SetYOffset(t.detail.historyYOffset)
+
 		// Refresh the detail's worklogs and subtasks for the now-active tab
 		// (also covers a detail that finished loading while backgrounded).
 		m.loadingCount += 2
 		return tea.Batch(
 			m.fetchWorkLogsCmd(m.activeIssue.ID),
 			m.fetchSubTasksCmd(m.activeIssue.Key),
+			m.fetchThumbnailsCmd(),
+			m.fetchRestOfHistoryCmd(),
 		)
 	}
 
@@ -303,6 +335,17 @@ func (m model) openBoardTab(title, jql string, kind tabKind) (tea.Model, tea.Cmd
 	m.activeIssue = nil
 	m.listViewport.SetContent("")
 
+	// Show the last-known board, if any, until the fetch replaces it.
+	if cached := m.cachedBoardIssues(jql); cached != nil {
+		m.issues = cached
+		m.activeProjects = activeProjectsFor(cached, m.projects)
+		m.sections = m.sectionsFor(cached)
+		m.listViewport.SetContent(m.buildListContent())
+		if si, ok := m.currentIssue(); ok {
+			m.selectedIssue = si
+		}
+	}
+
 	m.loadingCount++
 	return m, m.fetchBoardIssuesCmd(jql, id)
 }
@@ -231,8 +231,9 @@ func TestLayoutHeightReservation(t *testing.T) {
 	}
 
 	detail := m.calculateDetailLayout()
-	// leftColumnFreeHeight = H - (metadata 7 + statusBar 1 + tabBar + overhead*2)
-	wantDesc := (40 - (7 + 1 + tabBarHeight + ui.PanelOverheadHeight*2)) / 2
+	// leftColumnFreeHeight = H - (metadata 8 + statusBar 1 + tabBar + overhead*3),
+	// split between description, comments and history.
+	wantDesc := (40 - (9 + 1 + tabBarHeight + ui.PanelOverheadHeight*3)) / 3
 	if detail.descHeight != wantDesc {
 		t.Errorf("descHeight = %d, want %d (tab bar reserved)", detail.descHeight, wantDesc)
 	}
@@ -56,15 +56,6 @@ func NewCancelReasonFormData() *CancelReasonFormData {
 	return c
 }
 
-func isCancelTransition(t jira.Transition) bool {
-	name := strings.ToLower(t.Name)
-	return strings.Contains(name, "cancel") || strings.Contains(name, "cancelado")
-}
-
-func isBlockedTransition(t jira.Transition) bool {
-	return strings.Contains(strings.ToLower(t.Name), "bloq")
-}
-
 type BlockReasonFormData struct {
 	Reason string
 	Form   *huh.Form
@@ -108,22 +99,32 @@ func NewTransitionWorklogFormData() *TransitionWorklogFormData {
 	return w
 }
 
+// transitionDoneView is the view a transition flow returns to: the board
+// for a kanban card move, the detail view otherwise.
+func (m model) transitionDoneView() viewMode {
+	if m.movingCard {
+		return listView
+	}
+	return detailView
+}
+
 // routeTransition decides what happens once a transition is chosen: prompt for a
 // cancel/block reason, prompt for Time Spent when the transition's screen has a
 // worklog field, or post it directly.
 func (m model) routeTransition(t jira.Transition) (tea.Model, tea.Cmd) {
 	if m.pendingIssue == nil {
-		m.mode = detailView
+		m.mode = m.transitionDoneView()
 		return m, nil
 	}
 	m.pendingTransition = &t
+	wf := m.workflowFor(m.pendingIssue.Project.Key)
 
 	switch {
-	case isCancelTransition(t):
+	case isCancelTransition(t, wf):
 		m.cancelReasonData = NewCancelReasonFormData()
 		m.mode = cancelReasonView
 		return m, m.cancelReasonData.Form.Init()
-	case isBlockedTransition(t):
+	case isBlockedTransition(t, wf):
 		m.blockReasonData = NewBlockReasonFormData()
 		m.mode = blockReasonView
 		return m, m.blockReasonData.Form.Init()
@@ -134,10 +135,10 @@ func (m model) routeTransition(t jira.Transition) (tea.Model, tea.Cmd) {
 	}
 
 	m.pendingTransition = nil
-	m.mode = detailView
+	m.mode = m.transitionDoneView()
 	m.loadingCount++
 	m.setInfo("Transitioning...")
-	return m, m.postTransitionCmd(m.pendingIssue.Key, t.ID, "")
+	return m, m.trackedTransition(m.pendingIssue.Key, t, m.postTransitionCmd(m.pendingIssue.Key, t.ID, ""))
 }
 
 func (m model) updateTransitionView(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -216,14 +217,15 @@ func (m model) updatePostCancelReasonView(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	if m.cancelReasonData.Form.State == huh.StateCompleted {
-		m.mode = detailView
+		m.mode = m.transitionDoneView()
 		reason := m.cancelReasonData.Reason
 		if m.pendingTransition != nil && m.pendingIssue != nil {
 			transition := m.pendingTransition
 			m.pendingTransition = nil
 			m.loadingCount++
 			m.statusMessage.content = "Transitioning " + m.pendingIssue.Key
-			cmds = append(cmds, m.postTransitionWithReasonCmd(m.pendingIssue.Key, transition.ID, reason))
+			cmds = append(cmds, m.trackedTransition(m.pendingIssue.Key, *transition,
+				m.postTransitionWithReasonCmd(m.pendingIssue.Key, transition.ID, reason)))
 		}
 	}
 
@@ -262,14 +264,15 @@ func (m model) updateBlockReasonView(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	if m.blockReasonData.Form.State == huh.StateCompleted {
-		m.mode = detailView
+		m.mode = m.transitionDoneView()
 		reason := m.blockReasonData.Reason
 		if m.pendingTransition != nil && m.pendingIssue != nil {
 			transition := m.pendingTransition
 			m.pendingTransition = nil
 			m.loadingCount++
 			m.statusMessage.content = "Transitioning " + m.pendingIssue.Key
-			cmds = append(cmds, m.postBlockedTransitionCmd(m.pendingIssue.Key, transition.ID, reason))
+			cmds = append(cmds, m.trackedTransition(m.pendingIssue.Key, *transition,
+				m.postBlockedTransitionCmd(m.pendingIssue.Key, transition.ID, reason)))
 		}
 	}
 
@@ -308,14 +311,15 @@ func (m model) updateTransitionWorklogView(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	if m.transitionWorklogData.Form.State == huh.StateCompleted {
-		m.mode = detailView
+		m.mode = m.transitionDoneView()
 		timeSpent := strings.TrimSpace(m.transitionWorklogData.TimeSpent)
 		if m.pendingTransition != nil && m.pendingIssue != nil {
 			transition := m.pendingTransition
 			m.pendingTransition = nil
 			m.loadingCount++
 			m.setInfo("Transitioning " + m.pendingIssue.Key)
-			cmds = append(cmds, m.postTransitionCmd(m.pendingIssue.Key, transition.ID, timeSpent))
+			cmds = append(cmds, m.trackedTransition(m.pendingIssue.Key, *transition,
+				m.postTransitionCmd(m.pendingIssue.Key, transition.ID, timeSpent)))
 		}
 	}
 
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/oliverjhernandez/jira-tui/internal/config"
 	"github.com/oliverjhernandez/jira-tui/internal/jira"
 )
 
@@ -23,7 +24,7 @@ func TestIsBlockedTransition(t *testing.T) {
 		{"Cancelar", false},
 	}
 	for _, tt := range tests {
-		if got := isBlockedTransition(jira.Transition{Name: tt.name}); got != tt.want {
+		if got := isBlockedTransition(jira.Transition{Name: tt.name}, config.Workflow{}); got != tt.want {
 			t.Errorf("isBlockedTransition(%q) = %v, want %v", tt.name, got, tt.want)
 		}
 	}
@@ -89,3 +90,22 @@ func TestPostBlockedTransitionCmdPayload(t *testing.T) {
 		t.Errorf("block reason = %v, want %q", fields[blockReasonFieldID], "server is down")
 	}
 }
+
+func TestTransitionReturnsToDetailView(t *testing.T) {
+	m := newTabModel([]Tab{{}}, 0)
+	m.activeIssue = &jira.Issue{Key: "DEV-1"}
+	m.pendingIssue = m.activeIssue
+	m.baseView = listView
+	m.mode = transitionView
+	m.loadingCount = 1
+
+	next, _ := m.routeTransition(jira.Transition{ID: "21", Name: "In Progress"})
+	if mode := next.(model).mode; mode != detailView {
+		t.Errorf("mode = %v, want the detail view after posting", mode)
+	}
+
+	next, _ = m.Update(transitionPostedMsg{})
+	if mode := next.(model).mode; mode != detailView {
+		t.Errorf("mode = %v, want the detail view once posted", mode)
+	}
+}
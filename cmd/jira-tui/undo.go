@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Undo journal: deletes and transitions are recorded once Jira accepts them,
+// with enough of the original data to reverse them. `u` undoes the most
+// recent one after a confirmation.
+
+const undoJournalSize = 20
+
+type undoKind int
+
+const (
+	undoComment undoKind = iota
+	undoWorklog
+	undoIssueLink
+	undoTransition
+)
+
+type undoEntry struct {
+	kind     undoKind
+	issueKey string
+	issueID  string
+	at       time.Time
+
+	comment    jira.Comment
+	worklog    jira.Worklog
+	link       jira.IssueLink
+	fromStatus string
+	toStatus   string
+}
+
+// summary describes the recorded change, e.g. for the confirmation modal.
+func (e undoEntry) summary() string {
+	switch e.kind {
+	case undoComment:
+		return "Deleted comment on " + e.issueKey
+	case undoWorklog:
+		return fmt.Sprintf("Deleted %s worklog on %s", formatSecondsToString(e.worklog.Time), e.issueKey)
+	case undoIssueLink:
+		return fmt.Sprintf("Removed %s link %s ↔ %s", e.link.Type.Name, e.issueKey, linkedIssueKey(e.link))
+	case undoTransition:
+		return fmt.Sprintf("Moved %s from %s to %s", e.issueKey, e.fromStatus, e.toStatus)
+	default:
+		return e.issueKey
+	}
+}
+
+// action describes what undoing the change will do.
+func (e undoEntry) action() string {
+	switch e.kind {
+	case undoComment:
+		return "Re-post the comment on " + e.issueKey
+	case undoWorklog:
+		return "Re-create the worklog on " + e.issueKey
+	case undoIssueLink:
+		return "Re-link " + e.issueKey + " and " + linkedIssueKey(e.link)
+	case undoTransition:
+		return fmt.Sprintf("Transition %s back to %s", e.issueKey, e.fromStatus)
+	default:
+		return "Undo"
+	}
+}
+
+// journaledMsg wraps the result of a successful change to be recorded; the
+// wrapped msg is then handled as usual.
+type journaledMsg struct {
+	entry undoEntry
+	msg   tea.Msg
+}
+
+type undoneMsg struct {
+	entry undoEntry
+}
+
+// undoFailedMsg puts the entry back in the journal so the undo can be retried.
+type undoFailedMsg struct {
+	entry undoEntry
+	err   error
+}
+
+// journal wraps cmd so entry is recorded once the change went through. Failed
+// and queued (offline) changes are not recorded.
+func (m model) journal(entry undoEntry, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		msg := cmd()
+		switch msg.(type) {
+		case errMsg, opQueuedMsg:
+			return msg
+		}
+		entry.at = time.Now()
+		return journaledMsg{entry: entry, msg: msg}
+	}
+}
+
+// trackedTransition posts a transition optimistically and journals it so it
+// can be transitioned back.
+func (m *model) trackedTransition(issueKey string, t jira.Transition, cmd tea.Cmd) tea.Cmd {
+	if before, ok := m.currentIssueFields(issueKey); ok && before.Status != "" && t.Name != "" {
+		cmd = m.journal(undoEntry{
+			kind:       undoTransition,
+			issueKey:   issueKey,
+			fromStatus: before.Status,
+			toStatus:   t.Name,
+		}, cmd)
+	}
+	return m.optimisticTransition(issueKey, t, cmd)
+}
+
+func (m *model) recordUndo(e undoEntry) {
+	m.undoJournal = append(m.undoJournal, e)
+	if n := len(m.undoJournal); n > undoJournalSize {
+		m.undoJournal = append([]undoEntry(nil), m.undoJournal[n-undoJournalSize:]...)
+	}
+}
+
+// undoCmd reverses a journaled change.
+func (m model) undoCmd(e undoEntry) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return undoFailedMsg{e, fmt.Errorf("jira client not initialized")}
+		}
+
+		ctx := context.Background()
+		var err error
+		switch e.kind {
+		case undoComment:
+			err = m.client.PostComment(ctx, e.issueKey, jira.ADFToMarkdown(e.comment.Body), m.usersCache)
+		case undoWorklog:
+			w := e.worklog
+			err = m.client.PostWorkLog(ctx, e.issueID, w.StartDate, w.Author.AccountID, w.Description, w.Time)
+		case undoIssueLink:
+			inward, outward := restoredLinkEnds(e.issueKey, e.link)
+			err = m.client.PostIssueLinkByName(ctx, inward, outward, e.link.Type.Name)
+		case undoTransition:
+			err = m.transitionBack(ctx, e)
+		}
+		if err != nil {
+			return undoFailedMsg{e, err}
+		}
+		return undoneMsg{e}
+	}
+}
+
+// restoredLinkEnds returns the inward and outward keys for re-creating link as
+// it was seen from issueKey: the other issue keeps its side of the link.
+func restoredLinkEnds(issueKey string, link jira.IssueLink) (inward, outward string) {
+	if link.OutwardIssue != nil {
+		return issueKey, link.OutwardIssue.Key
+	}
+	if link.InwardIssue != nil {
+		return link.InwardIssue.Key, issueKey
+	}
+	return issueKey, ""
+}
+
+// transitionBack finds the transition that leads back to the previous status.
+// Workflows don't always have one, in which case the undo fails.
+func (m model) transitionBack(ctx context.Context, e undoEntry) error {
+	transitions, err := m.client.GetTransitions(ctx, e.issueKey)
+	if err != nil {
+		return err
+	}
+	if t, ok := transitionTo(transitions, e.fromStatus); ok {
+		return m.client.PostTransition(ctx, e.issueKey, t.ID, nil, "", "")
+	}
+	return fmt.Errorf("no transition from %s back to %s", e.toStatus, e.fromStatus)
+}
+
+func (m model) handleJournaled(msg journaledMsg) (tea.Model, tea.Cmd) {
+	m.recordUndo(msg.entry)
+	return m.update(msg.msg)
+}
+
+func (m model) openUndoConfirm() (tea.Model, tea.Cmd) {
+	if len(m.undoJournal) == 0 {
+		m.setInfo("Nothing to undo")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+	m.previousMode = m.mode
+	m.mode = undoConfirmView
+	return m, nil
+}
+
+func (m model) updateUndoConfirmView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	kp, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch kp.String() {
+	case "y", "enter":
+		m.mode = m.previousMode
+		n := len(m.undoJournal)
+		if n == 0 {
+			return m, nil
+		}
+		e := m.undoJournal[n-1]
+		m.undoJournal = m.undoJournal[:n-1]
+		m.loadingCount++
+		m.setInfo(e.action() + "...")
+		return m, m.undoCmd(e)
+	case "n", "esc", "q":
+		m.mode = m.previousMode
+	}
+	return m, nil
+}
+
+func (m model) handleUndone(msg undoneMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	e := msg.entry
+	m.setSuccess("Undone: " + e.summary())
+	cmds := []tea.Cmd{m.clearStatusAfter(clearMsgTimeout)}
+
+	if e.kind == undoTransition {
+		m.patchIssue(e.issueKey, func(is *jira.Issue) { is.Status = e.fromStatus })
+	}
+	if m.activeIssue != nil && m.activeIssue.Key == e.issueKey {
+		m.loadingCount++
+		cmds = append(cmds, m.fetchIssueDetailCmd(e.issueKey))
+		if e.kind == undoWorklog {
+			m.loadingCount++
+			cmds = append(cmds, m.fetchWorkLogsCmd(e.issueID))
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) handleUndoFailed(msg undoFailedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	m.recordUndo(msg.entry)
+	m.setErrorMsg("Undo failed: " + humanizeError(msg.err))
+	return m, m.clearStatusAfter(clearMsgTimeout)
+}
+
+func (m model) renderUndoConfirmView() string {
+	var b strings.Builder
+	if n := len(m.undoJournal); n > 0 {
+		e := m.undoJournal[n-1]
+		b.WriteString(ui.DetailHeaderStyle.Render(e.summary()) + "\n")
+		b.WriteString(ui.DimTextStyle.Render(e.at.Format("Jan 02 15:04")) + "\n\n")
+		b.WriteString(e.action() + "?\n")
+		if n > 1 {
+			b.WriteString(ui.DimTextStyle.Render(fmt.Sprintf("%d earlier change(s) can be undone after this.", n-1)) + "\n")
+		}
+	}
+
+	footer := ui.StatusBarInfoStyle.Render("  y undo · n cancel")
+	return m.renderModal("Undo", b.String()+"\n"+footer, 0.4, 0.25)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func TestJournalRecordsOnlyAppliedChanges(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	entry := undoEntry{kind: undoComment, issueKey: "DEV-1"}
+
+	msg := m.journal(entry, func() tea.Msg { return errMsg{errors.New("boom")} })()
+	if _, ok := msg.(errMsg); !ok {
+		t.Errorf("failed delete: got %T, want errMsg passed through", msg)
+	}
+
+	msg = m.journal(entry, func() tea.Msg { return commentDeletedMsg{} })()
+	journaled, ok := msg.(journaledMsg)
+	if !ok {
+		t.Fatalf("successful delete: got %T, want journaledMsg", msg)
+	}
+	if _, ok := journaled.msg.(commentDeletedMsg); !ok || journaled.entry.at.IsZero() {
+		t.Errorf("journaledMsg = %+v", journaled)
+	}
+
+	m.activeIssue = &jira.Issue{Key: "DEV-1"}
+	m.mode = detailView
+	m.loadingCount = 1
+	next, _ := m.Update(journaled)
+	if nm := next.(model); len(nm.undoJournal) != 1 {
+		t.Errorf("journal has %d entries, want 1", len(nm.undoJournal))
+	}
+}
+
+func TestTrackedTransitionJournalsPreviousStatus(t *testing.T) {
+	m := newOptimisticModel()
+
+	cmd := m.trackedTransition("DEV-1", jira.Transition{ID: "21", Name: "In Progress"}, func() tea.Msg {
+		return transitionPostedMsg{}
+	})
+
+	done, ok := cmd().(optimisticDoneMsg)
+	if !ok {
+		t.Fatalf("want optimisticDoneMsg")
+	}
+	journaled, ok := done.msg.(journaledMsg)
+	if !ok {
+		t.Fatalf("wrapped msg = %T, want journaledMsg", done.msg)
+	}
+	if e := journaled.entry; e.kind != undoTransition || e.fromStatus != "To Do" || e.toStatus != "In Progress" {
+		t.Errorf("entry = %+v", e)
+	}
+}
+
+func TestRecordUndoIsBounded(t *testing.T) {
+	var m model
+	for i := range undoJournalSize + 5 {
+		m.recordUndo(undoEntry{issueKey: "DEV-" + string(rune('A'+i))})
+	}
+	if len(m.undoJournal) != undoJournalSize {
+		t.Fatalf("journal has %d entries, want %d", len(m.undoJournal), undoJournalSize)
+	}
+	if m.undoJournal[0].issueKey != "DEV-F" {
+		t.Errorf("oldest entries should be dropped first, oldest is %s", m.undoJournal[0].issueKey)
+	}
+}
+
+func TestUndoConfirm(t *testing.T) {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+
+	next, _ := m.Update(keyPress("u"))
+	m = next.(model)
+	if m.mode != listView || m.statusMessage.content != "Nothing to undo" {
+		t.Fatalf("empty journal: mode %v, status %q", m.mode, m.statusMessage.content)
+	}
+
+	m.recordUndo(undoEntry{kind: undoComment, issueKey: "DEV-1"})
+	next, _ = m.Update(keyPress("u"))
+	m = next.(model)
+	if m.mode != undoConfirmView {
+		t.Fatalf("mode = %v, want undoConfirmView", m.mode)
+	}
+
+	next, _ = m.Update(keyPress("n"))
+	m = next.(model)
+	if m.mode != listView || len(m.undoJournal) != 1 {
+		t.Fatalf("cancel should keep the entry: mode %v, %d entries", m.mode, len(m.undoJournal))
+	}
+
+	next, _ = m.Update(keyPress("u"))
+	m = next.(model)
+	next, cmd := m.Update(keyPress("y"))
+	m = next.(model)
+	if cmd == nil || len(m.undoJournal) != 0 || m.mode != listView {
+		t.Fatalf("confirm should start the undo: mode %v, %d entries", m.mode, len(m.undoJournal))
+	}
+
+	// No client in tests, so the undo fails and the entry goes back.
+	next, _ = m.Update(undoFailedMsg{undoEntry{kind: undoComment, issueKey: "DEV-1"}, errors.New("offline")})
+	m = next.(model)
+	if len(m.undoJournal) != 1 || m.statusMessage.msgType != errStatusBarMsg {
+		t.Errorf("failed undo should be retryable: %d entries, status %q", len(m.undoJournal), m.statusMessage.content)
+	}
+}
+
+func TestUndoneTransitionRestoresStatus(t *testing.T) {
+	m := newOptimisticModel()
+	m.issues[0].Status = "In Progress"
+	m.loadingCount = 1
+
+	next, _ := m.Update(undoneMsg{undoEntry{kind: undoTransition, issueKey: "DEV-1", fromStatus: "To Do", toStatus: "In Progress"}})
+	nm := next.(model)
+	if nm.issues[0].Status != "To Do" {
+		t.Errorf("status = %q, want To Do", nm.issues[0].Status)
+	}
+	if nm.loadingCount != 0 {
+		t.Errorf("loadingCount = %d, want 0", nm.loadingCount)
+	}
+}
+
+func TestRestoredLinkEnds(t *testing.T) {
+	outward := jira.IssueLink{OutwardIssue: &jira.LinkedIssue{Key: "DEV-2"}}
+	if in, out := restoredLinkEnds("DEV-1", outward); in != "DEV-1" || out != "DEV-2" {
+		t.Errorf("outward link: got %s → %s", in, out)
+	}
+	inward := jira.IssueLink{InwardIssue: &jira.LinkedIssue{Key: "DEV-3"}}
+	if in, out := restoredLinkEnds("DEV-1", inward); in != "DEV-3" || out != "DEV-1" {
+		t.Errorf("inward link: got %s → %s", in, out)
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Watchers: listed with the votes in the metadata panel, W toggles watching
+// the active issue and ctrl+w opens a modal to add (through the user picker)
+// or remove other watchers. Every change is applied optimistically.
+
+const (
+	watchersModalWScale = 0.3
+	watchersModalHScale = 0.4
+)
+
+type watcherUpdatedMsg struct {
+	issueKey string
+	user     jira.User
+	added    bool
+}
+
+// renderWatchersRow is the metadata line with the issue's watchers (the
+// current user as "you") and votes, cut to width.
+func renderWatchersRow(issue jira.Issue, myself *jira.User, width int) string {
+	names := make([]string, len(issue.Watchers))
+	for i, w := range issue.Watchers {
+		names[i] = w.Name
+		if myself != nil && w.ID == myself.ID {
+			names[i] = "you"
+		}
+	}
+	watchers := ui.DimTextStyle.Render("—")
+	if len(names) > 0 {
+		watchers = strings.Join(names, ", ")
+	}
+
+	votes := fmt.Sprint(issue.Votes)
+	if issue.Voted {
+		votes += " (incl. you)"
+	}
+
+	row := ui.DetailLabelStyle.Render("Watchers: ") + watchers + "   " + ui.DetailLabelStyle.Render("Votes: ") + votes
+	return ansi.Truncate(row, width, "…")
+}
+
+// withWatcher and withoutWatcher return copies of watchers, leaving the
+// slice shared with other copies of the issue untouched.
+func withWatcher(watchers []jira.User, u jira.User) []jira.User {
+	if slices.ContainsFunc(watchers, func(w jira.User) bool { return w.ID == u.ID }) {
+		return watchers
+	}
+	return append(slices.Clone(watchers), u)
+}
+
+func withoutWatcher(watchers []jira.User, userID string) []jira.User {
+	return slices.DeleteFunc(slices.Clone(watchers), func(w jira.User) bool { return w.ID == userID })
+}
+
+// changeWatcher adds or removes user as a watcher of issueKey, showing the
+// change right away.
+func (m *model) changeWatcher(issueKey string, user jira.User, add bool) tea.Cmd {
+	current, _ := m.currentIssueFields(issueKey)
+	isMe := m.myself != nil && user.ID == m.myself.ID
+
+	change := func(is *jira.Issue) {
+		if add {
+			is.Watchers = withWatcher(is.Watchers, user)
+		} else {
+			is.Watchers = withoutWatcher(is.Watchers, user.ID)
+		}
+		if isMe {
+			is.Watching = add
+		}
+	}
+	restore := func(is *jira.Issue) {
+		is.Watchers = current.Watchers
+		is.Watching = current.Watching
+	}
+	return m.applyOptimistic(issueKey, change, restore, m.updateWatcherCmd(issueKey, user, add))
+}
+
+func (m model) updateWatcherCmd(issueKey string, user jira.User, add bool) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return errMsg{fmt.Errorf("jira client not initialized")}
+		}
+
+		var err error
+		if add {
+			err = m.client.AddWatcher(context.Background(), issueKey, user.ID)
+		} else {
+			err = m.client.RemoveWatcher(context.Background(), issueKey, user.ID)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return watcherUpdatedMsg{issueKey: issueKey, user: user, added: add}
+	}
+}
+
+func (m model) handleWatcherUpdated(msg watcherUpdatedMsg) (tea.Model, tea.Cmd) {
+	m.loadingCount--
+	isMe := m.myself != nil && msg.user.ID == m.myself.ID
+	switch {
+	case isMe && msg.added:
+		m.setSuccess("Watching " + msg.issueKey)
+	case isMe:
+		m.setSuccess("Stopped watching " + msg.issueKey)
+	case msg.added:
+		m.setSuccess(fmt.Sprintf("%s is now watching %s", msg.user.Name, msg.issueKey))
+	default:
+		m.setSuccess(fmt.Sprintf("%s no longer watches %s", msg.user.Name, msg.issueKey))
+	}
+	return m, m.clearStatusAfter(clearMsgTimeout)
+}
+
+// toggleWatch starts or stops the current user watching the active issue.
+func (m model) toggleWatch() (tea.Model, tea.Cmd) {
+	if m.activeIssue == nil {
+		return m, nil
+	}
+	if m.myself == nil {
+		m.setErrorMsg("Your Jira user hasn't loaded yet")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+
+	m.loadingCount++
+	return m, m.changeWatcher(m.activeIssue.Key, *m.myself, !m.activeIssue.Watching)
+}
+
+func (m model) openWatchers() (tea.Model, tea.Cmd) {
+	if m.activeIssue == nil {
+		return m, nil
+	}
+	m.watchersCursor = 0
+	m.mode = watchersView
+	return m, nil
+}
+
+func (m model) updateWatchersView(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyPressMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok || m.activeIssue == nil {
+		return m, nil
+	}
+	watchers := m.activeIssue.Watchers
+
+	switch keyPressMsg.String() {
+	case "esc", "q":
+		m.mode = detailView
+		return m, nil
+
+	case "j", "down":
+		m.watchersCursor = max(0, min(m.watchersCursor+1, len(watchers)-1))
+		return m, nil
+
+	case "k", "up":
+		m.watchersCursor = max(0, m.watchersCursor-1)
+		return m, nil
+
+	case "a":
+		if len(m.usersCache) == 0 {
+			m.setErrorMsg("Users haven't loaded yet")
+			return m, m.clearStatusAfter(clearMsgTimeout)
+		}
+		m.previousMode = watchersView
+		m.mode = userSearchView
+		m.userSelectionMode = addWatcher
+		m.searchUserData = NewSearchUserFormData(m.usersCache)
+		return m, m.searchUserData.Form.Init()
+
+	case "d":
+		if m.watchersCursor < 0 || m.watchersCursor >= len(watchers) {
+			return m, nil
+		}
+		user := watchers[m.watchersCursor]
+		m.loadingCount++
+		cmd := m.changeWatcher(m.activeIssue.Key, user, false)
+		m.watchersCursor = max(0, min(m.watchersCursor, len(m.activeIssue.Watchers)-1))
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// addSelectedWatcher is the user picker's completion in addWatcher mode: it
+// adds user and returns to the watchers modal.
+func (m model) addSelectedWatcher(user jira.User) (tea.Model, tea.Cmd) {
+	m.mode = watchersView
+	m.searchUserData = nil
+	m.userSelectionMode = 0
+	if m.activeIssue == nil || user.ID == "" {
+		return m, nil
+	}
+	if slices.ContainsFunc(m.activeIssue.Watchers, func(w jira.User) bool { return w.ID == user.ID }) {
+		m.setInfo(user.Name + " is already watching")
+		return m, m.clearStatusAfter(clearMsgTimeout)
+	}
+
+	m.loadingCount++
+	return m, m.changeWatcher(m.activeIssue.Key, user, true)
+}
+
+func (m model) renderWatchersView() string {
+	var b strings.Builder
+
+	watchers := m.activeIssue.Watchers
+	if len(watchers) == 0 {
+		b.WriteString(ui.DimTextStyle.Render("  Nobody is watching") + "\n")
+	}
+	for i, w := range watchers {
+		name := w.Name
+		if m.myself != nil && w.ID == m.myself.ID {
+			name += ui.DimTextStyle.Render(" (you)")
+		}
+		if i == m.watchersCursor {
+			b.WriteString(ui.IconCursor + ui.SelectedRowStyle.Render(" "+name) + "\n")
+		} else {
+			b.WriteString("  " + name + "\n")
+		}
+	}
+
+	b.WriteString("\n" + ui.StatusBarInfoStyle.Render("a add · d remove · esc close"))
+	return m.renderModal("Watchers "+m.activeIssue.Key, b.String(), watchersModalWScale, watchersModalHScale)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func newWatchersModel() model {
+	m := newTabModel([]Tab{{id: 0, board: boardState{jql: "a"}}}, 0)
+	m.myself = &jira.User{ID: "me", Name: "Me"}
+	m.issues = []jira.Issue{
+		{Key: "DEV-1", Status: "To Do", Watchers: []jira.User{{ID: "u1", Name: "Jane"}}},
+	}
+	m.refreshBoard("DEV-1")
+	m.activeIssue = &jira.Issue{Key: "DEV-1", Status: "To Do", Watchers: m.issues[0].Watchers, Votes: 2}
+	m.mode = detailView
+	return m
+}
+
+func TestRenderWatchersRow(t *testing.T) {
+	issue := jira.Issue{Watchers: []jira.User{{ID: "u1", Name: "Jane"}, {ID: "me", Name: "Me"}}, Votes: 2, Voted: true}
+	row := ansi.Strip(renderWatchersRow(issue, &jira.User{ID: "me"}, 200))
+	if !strings.Contains(row, "Jane, you") || !strings.Contains(row, "Votes: 2 (incl. you)") {
+		t.Errorf("row = %q", row)
+	}
+	if got := ansi.StringWidth(renderWatchersRow(issue, nil, 10)); got > 10 {
+		t.Errorf("row is %d wide, want it cut to 10", got)
+	}
+}
+
+func TestToggleWatchIsOptimistic(t *testing.T) {
+	m := newWatchersModel()
+
+	next, cmd := m.Update(keyPress("W"))
+	nm := next.(model)
+	if cmd == nil || !nm.activeIssue.Watching || len(nm.activeIssue.Watchers) != 2 {
+		t.Fatalf("expected to be watching right away, got %+v", nm.activeIssue)
+	}
+	if len(nm.issues[0].Watchers) != 2 || len(m.issues[0].Watchers) != 1 {
+		t.Error("the board copy should be patched without touching the shared slice")
+	}
+
+	next, _ = nm.Update(keyPress("W"))
+	nm = next.(model)
+	if nm.activeIssue.Watching || len(nm.activeIssue.Watchers) != 1 {
+		t.Errorf("expected to stop watching, got %+v", nm.activeIssue)
+	}
+}
+
+func TestToggleWatchNeedsCurrentUser(t *testing.T) {
+	m := newWatchersModel()
+	m.myself = nil
+
+	next, _ := m.Update(keyPress("W"))
+	nm := next.(model)
+	if nm.statusMessage.msgType != errStatusBarMsg || nm.isPending("DEV-1") {
+		t.Errorf("expected an error and no change, got %q", nm.statusMessage.content)
+	}
+}
+
+func TestWatchersModalRemoveAndRollback(t *testing.T) {
+	m := newWatchersModel()
+	next, _ := m.Update(keyPress("ctrl+w"))
+	m = next.(model)
+	if m.mode != watchersView {
+		t.Fatalf("mode = %v, want watchersView", m.mode)
+	}
+
+	user := m.activeIssue.Watchers[0]
+	cmd := m.changeWatcher("DEV-1", user, false)
+	m.loadingCount++
+	if len(m.activeIssue.Watchers) != 0 {
+		t.Fatalf("watcher not removed right away: %+v", m.activeIssue.Watchers)
+	}
+
+	// Without a client the request fails, which rolls the change back.
+	failed, ok := cmd().(optimisticFailedMsg)
+	if !ok {
+		t.Fatalf("failure should produce optimisticFailedMsg")
+	}
+	next, _ = m.Update(failed)
+	nm := next.(model)
+	if len(nm.activeIssue.Watchers) != 1 || nm.isPending("DEV-1") {
+		t.Errorf("watcher not restored: %+v", nm.activeIssue.Watchers)
+	}
+}
+
+func TestWatchersModalDeleteKey(t *testing.T) {
+	m := newWatchersModel()
+	m.mode = watchersView
+
+	next, cmd := m.Update(keyPress("d"))
+	nm := next.(model)
+	if cmd == nil || len(nm.activeIssue.Watchers) != 0 || !nm.isPending("DEV-1") {
+		t.Errorf("d should remove the selected watcher, got %+v", nm.activeIssue.Watchers)
+	}
+
+	next, _ = nm.Update(keyPress("esc"))
+	if next.(model).mode != detailView {
+		t.Error("esc should return to the detail view")
+	}
+}
+
+func TestAddWatcherFromPicker(t *testing.T) {
+	m := newWatchersModel()
+	m.mode = watchersView
+	m.usersCache = []jira.User{{ID: "u1", Name: "Jane"}, {ID: "u2", Name: "Ana"}}
+
+	next, _ := m.Update(keyPress("a"))
+	nm := next.(model)
+	if nm.mode != userSearchView || nm.userSelectionMode != addWatcher || nm.searchUserData == nil {
+		t.Fatalf("expected the user picker, mode %v", nm.mode)
+	}
+
+	next, cmd := nm.addSelectedWatcher(jira.User{ID: "u2", Name: "Ana"})
+	nm = next.(model)
+	if nm.mode != watchersView || cmd == nil || len(nm.activeIssue.Watchers) != 2 {
+		t.Errorf("expected Ana added and the modal back, got mode %v, watchers %+v", nm.mode, nm.activeIssue.Watchers)
+	}
+
+	next, _ = nm.addSelectedWatcher(jira.User{ID: "u1", Name: "Jane"})
+	nm = next.(model)
+	if len(nm.activeIssue.Watchers) != 2 || nm.statusMessage.msgType != infoStatusBarMsg {
+		t.Errorf("an existing watcher should not be added twice, got %+v", nm.activeIssue.Watchers)
+	}
+}
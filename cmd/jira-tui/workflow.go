@@ -0,0 +1,139 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/oliverjhernandez/jira-tui/internal/config"
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+// Transition-name substrings used when the workflow config doesn't list any.
+var (
+	defaultCancelTransitions  = []string{"cancel"}
+	defaultBlockedTransitions = []string{"block", "bloq"}
+)
+
+// categoryOrder ranks Jira's status categories for sorting when no
+// status_order is configured: work in progress first, finished work last.
+var categoryOrder = map[string]int{
+	"indeterminate": 1,
+	"new":           2,
+	"done":          3,
+}
+
+// workflowFor returns the workflow settings that apply to issues in projectKey.
+func (m model) workflowFor(projectKey string) config.Workflow {
+	return m.workflow.ForProject(projectKey)
+}
+
+// statusCategoryKey looks up status's category ("new", "indeterminate",
+// "done"), preferring the issue's own project and falling back to any project
+// that has a status with the same name.
+func statusCategoryKey(statuses map[string][]jira.Status, projectID, status string) string {
+	key := normalizeName(status)
+	for _, s := range statuses[projectID] {
+		if normalizeName(s.Name) == key {
+			return s.StatusCategory.Key
+		}
+	}
+	for _, projStatuses := range statuses {
+		for _, s := range projStatuses {
+			if normalizeName(s.Name) == key {
+				return s.StatusCategory.Key
+			}
+		}
+	}
+	return ""
+}
+
+// isInTransit reports whether issue's status is configured as in transit.
+func (m model) isInTransit(issue jira.Issue) bool {
+	return containsName(m.workflowFor(issue.Project.Key).InTransitStatuses, issue.Status)
+}
+
+// isClosed reports whether issue is finished and should be dimmed in lists.
+func (m model) isClosed(issue jira.Issue) bool {
+	wf := m.workflowFor(issue.Project.Key)
+	if len(wf.ClosedStatuses) > 0 {
+		return containsName(wf.ClosedStatuses, issue.Status)
+	}
+	return statusCategoryKey(m.statuses, issue.Project.ID, issue.Status) == "done"
+}
+
+// statusRank orders issues by status: configured status_order first, then by
+// status category.
+func (m model) statusRank(issue jira.Issue) int {
+	order := m.workflowFor(issue.Project.Key).StatusOrder
+	if i := indexOfName(order, issue.Status); i >= 0 {
+		return i
+	}
+
+	rank, ok := categoryOrder[statusCategoryKey(m.statuses, issue.Project.ID, issue.Status)]
+	if !ok {
+		rank = len(categoryOrder) + 1
+	}
+	return len(order) + rank
+}
+
+// priorityRank orders priorities highest first: configured priority_order,
+// otherwise the order Jira returns them in.
+func (m model) priorityRank(issue jira.Issue) int {
+	order := m.workflowFor(issue.Project.Key).PriorityOrder
+	if len(order) > 0 {
+		if i := indexOfName(order, issue.Priority.Name); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+
+	for i, p := range m.priorities {
+		if normalizeName(p.Name) == normalizeName(issue.Priority.Name) {
+			return i
+		}
+	}
+	return len(m.priorities)
+}
+
+// isCancelTransition reports whether t cancels the issue and needs a reason.
+func isCancelTransition(t jira.Transition, wf config.Workflow) bool {
+	patterns := wf.CancelTransitions
+	if len(patterns) == 0 {
+		patterns = defaultCancelTransitions
+	}
+	return nameContainsAny(t.Name, patterns)
+}
+
+// isBlockedTransition reports whether t flags the issue as blocked.
+func isBlockedTransition(t jira.Transition, wf config.Workflow) bool {
+	patterns := wf.BlockedTransitions
+	if len(patterns) == 0 {
+		patterns = defaultBlockedTransitions
+	}
+	return nameContainsAny(t.Name, patterns)
+}
+
+func normalizeName(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func indexOfName(names []string, name string) int {
+	name = normalizeName(name)
+	return slices.IndexFunc(names, func(n string) bool {
+		return normalizeName(n) == name
+	})
+}
+
+func containsName(names []string, name string) bool {
+	return indexOfName(names, name) >= 0
+}
+
+func nameContainsAny(name string, substrings []string) bool {
+	name = normalizeName(name)
+	for _, s := range substrings {
+		if s = normalizeName(s); s != "" && strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
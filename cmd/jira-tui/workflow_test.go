@@ -0,0 +1,115 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/oliverjhernandez/jira-tui/internal/config"
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func issueKeys(issues []jira.Issue) []string {
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		keys[i] = issue.Key
+	}
+	return keys
+}
+
+func TestSortSectionsIssues(t *testing.T) {
+	statuses := map[string][]jira.Status{
+		"10": {
+			{Name: "In Progress", StatusCategory: jira.StatusCategory{Key: "indeterminate"}},
+			{Name: "Backlog", StatusCategory: jira.StatusCategory{Key: "new"}},
+			{Name: "Shipped", StatusCategory: jira.StatusCategory{Key: "done"}},
+		},
+	}
+	priorities := []jira.Priority{{Name: "Blocker"}, {Name: "Major"}, {Name: "Minor"}}
+	project := jira.Project{ID: "10", Key: "DEV"}
+
+	issues := func() []jira.Issue {
+		return []jira.Issue{
+			{Key: "DEV-1", Status: "Shipped", Priority: jira.Priority{Name: "Blocker"}, Project: project},
+			{Key: "DEV-2", Status: "Backlog", Priority: jira.Priority{Name: "Minor"}, Project: project},
+			{Key: "DEV-3", Status: "Backlog", Priority: jira.Priority{Name: "Blocker"}, Project: project},
+			{Key: "DEV-4", Status: "In Progress", Priority: jira.Priority{Name: "Major"}, Project: project},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		workflow config.Workflow
+		want     []string
+	}{
+		{
+			name: "defaults from status category and priority order",
+			want: []string{"DEV-4", "DEV-3", "DEV-2", "DEV-1"},
+		},
+		{
+			name:     "configured status order",
+			workflow: config.Workflow{StatusOrder: []string{"backlog", "shipped"}},
+			want:     []string{"DEV-3", "DEV-2", "DEV-1", "DEV-4"},
+		},
+		{
+			name: "project priority order overrides Jira's",
+			workflow: config.Workflow{Projects: map[string]config.Workflow{
+				"DEV": {PriorityOrder: []string{"Minor", "Blocker"}},
+			}},
+			want: []string{"DEV-4", "DEV-2", "DEV-3", "DEV-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{statuses: statuses, priorities: priorities, workflow: tt.workflow}
+			sections := []Section{{Issues: issues()}}
+			m.sortSectionsIssues(sections)
+
+			got := issueKeys(sections[0].Issues)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("order = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsClosed(t *testing.T) {
+	statuses := map[string][]jira.Status{
+		"10": {
+			{Name: "Shipped", StatusCategory: jira.StatusCategory{Key: "done"}},
+			{Name: "Won't Do", StatusCategory: jira.StatusCategory{Key: "done"}},
+		},
+	}
+	project := jira.Project{ID: "10", Key: "DEV"}
+
+	m := model{statuses: statuses}
+	if !m.isClosed(jira.Issue{Status: "Shipped", Project: project}) {
+		t.Errorf("done-category status should be closed by default")
+	}
+
+	m.workflow = config.Workflow{ClosedStatuses: []string{"Won't Do"}}
+	if m.isClosed(jira.Issue{Status: "Shipped", Project: project}) {
+		t.Errorf("closed_statuses should replace the category default")
+	}
+	if !m.isClosed(jira.Issue{Status: "won't do", Project: project}) {
+		t.Errorf("closed_statuses should match case-insensitively")
+	}
+}
+
+func TestConfiguredTransitionPatterns(t *testing.T) {
+	wf := config.Workflow{
+		CancelTransitions:  []string{"abandon"},
+		BlockedTransitions: []string{"on hold"},
+	}
+
+	if !isCancelTransition(jira.Transition{Name: "Abandon work"}, wf) {
+		t.Errorf("configured cancel pattern not matched")
+	}
+	if isCancelTransition(jira.Transition{Name: "Cancel"}, wf) {
+		t.Errorf("configured patterns should replace the defaults")
+	}
+	if !isBlockedTransition(jira.Transition{Name: "Put On Hold"}, wf) {
+		t.Errorf("configured blocked pattern not matched")
+	}
+}
@@ -0,0 +1,99 @@
+package adf
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// highlight colors code by its language's tokens and hard-wraps it to
+// width. ok is false when there's no lexer for language ("", "none" or
+// something chroma doesn't know) so the caller can show the code plain.
+func highlight(code, language string, width int) (string, bool) {
+	if language == "" || language == "none" {
+		return "", false
+	}
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		return "", false
+	}
+	// Tabs have no width of their own to wrap by; lipgloss would expand them
+	// to four spaces anyway.
+	code = strings.ReplaceAll(code, "\t", "    ")
+	tokens, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return "", false
+	}
+
+	w := wrapper{width: width}
+	for t := tokens(); t != chroma.EOF; t = tokens() {
+		w.write(t.Value, tokenStyle(t.Type))
+	}
+	return strings.TrimSuffix(w.String(), "\n"), true
+}
+
+// tokenStyle maps chroma's token classes onto the theme's syntax colors.
+func tokenStyle(t chroma.TokenType) lipgloss.Style {
+	switch {
+	case t.InCategory(chroma.Comment):
+		return ui.SyntaxCommentStyle
+	case t == chroma.KeywordType, t == chroma.NameClass, t == chroma.NameNamespace, t == chroma.NameBuiltinPseudo:
+		return ui.SyntaxTypeStyle
+	case t == chroma.KeywordConstant, t == chroma.NameConstant:
+		return ui.SyntaxNumberStyle
+	case t.InCategory(chroma.Keyword), t == chroma.NameTag:
+		return ui.SyntaxKeywordStyle
+	case t == chroma.NameFunction, t == chroma.NameFunctionMagic, t == chroma.NameBuiltin, t == chroma.NameAttribute:
+		return ui.SyntaxFunctionStyle
+	case t.InSubCategory(chroma.LiteralString):
+		return ui.SyntaxStringStyle
+	case t.InSubCategory(chroma.LiteralNumber):
+		return ui.SyntaxNumberStyle
+	case t.InCategory(chroma.Operator):
+		return ui.SyntaxOperatorStyle
+	default:
+		return ui.SyntaxTextStyle
+	}
+}
+
+// wrapper lays styled token text out in lines of at most width cells,
+// styling each line's piece of a token on its own so no style spans a line
+// break.
+type wrapper struct {
+	strings.Builder
+	width int
+	col   int
+}
+
+func (w *wrapper) write(text string, style lipgloss.Style) {
+	var run strings.Builder
+	flush := func() {
+		if run.Len() > 0 {
+			w.WriteString(style.Render(run.String()))
+			run.Reset()
+		}
+	}
+
+	for _, r := range text {
+		if r == '\n' {
+			flush()
+			w.WriteString("\n")
+			w.col = 0
+			continue
+		}
+		cw := ansi.StringWidth(string(r))
+		if w.width > 0 && w.col+cw > w.width && w.col > 0 {
+			flush()
+			w.WriteString("\n")
+			w.col = 0
+		}
+		run.WriteRune(r)
+		w.col += cw
+	}
+	flush()
+}
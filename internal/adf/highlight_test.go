@@ -0,0 +1,71 @@
+package adf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+func TestHighlightColorsTokens(t *testing.T) {
+	code := "func main() {\n\t// hi\n\treturn \"x\"\n}"
+	got, ok := highlight(code, "go", 80)
+	if !ok {
+		t.Fatal("go should have a lexer")
+	}
+	if ansi.Strip(got) != strings.ReplaceAll(code, "\t", "    ") {
+		t.Errorf("highlighting changed the text: %q", ansi.Strip(got))
+	}
+	for _, want := range []string{
+		ui.SyntaxKeywordStyle.Render("func"),
+		ui.SyntaxCommentStyle.Render("// hi"),
+		ui.SyntaxStringStyle.Render(`"x"`),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in %q", want, got)
+		}
+	}
+}
+
+func TestHighlightUnknownLanguage(t *testing.T) {
+	for _, lang := range []string{"", "none", "not-a-language"} {
+		if _, ok := highlight("x := 1", lang, 80); ok {
+			t.Errorf("%q should fall back to plain text", lang)
+		}
+	}
+}
+
+func TestHighlightWraps(t *testing.T) {
+	code := `fmt.Println("a fairly long string that will not fit")` + "\nx := 1"
+	got, _ := highlight(code, "go", 20)
+	lines := strings.Split(ansi.Strip(got), "\n")
+	for _, l := range lines {
+		if w := ansi.StringWidth(l); w > 20 {
+			t.Errorf("line %q is %d wide", l, w)
+		}
+	}
+	if strings.Join(lines, "") != strings.ReplaceAll(code, "\n", "") || lines[len(lines)-1] != "x := 1" {
+		t.Errorf("wrapped lines = %q", lines)
+	}
+	// Each line closes its own styles.
+	for _, l := range strings.Split(got, "\n") {
+		if strings.Count(l, "\x1b[") > 0 && !strings.HasSuffix(l, "\x1b[m") {
+			t.Errorf("line %q leaves a style open", l)
+		}
+	}
+}
+
+func TestRenderCodeBlockLanguage(t *testing.T) {
+	doc := parse(t, `{"content":[
+		{"type":"codeBlock","attrs":{"language":"Python"},"content":[{"type":"text","text":"def f(): pass"}]},
+		{"type":"codeBlock","attrs":{"language":"klingon"},"content":[{"type":"text","text":"qapla"}]}]}`)
+	got := Render(doc, Options{Width: 80})
+	if !strings.Contains(got, ui.SyntaxKeywordStyle.Render("def")) {
+		t.Errorf("python keywords not highlighted: %q", got)
+	}
+	if !strings.Contains(ansi.Strip(got), "qapla") {
+		t.Errorf("unknown language lost its code: %q", got)
+	}
+}
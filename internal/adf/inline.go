@@ -0,0 +1,190 @@
+package adf
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// emojiShortcodes covers the shortcodes Jira's picker offers most; anything
+// else with neither text nor a codepoint ID is shown as its shortcode.
+var emojiShortcodes = map[string]string{
+	":smile:":            "😄",
+	":slight_smile:":     "🙂",
+	":grinning:":         "😀",
+	":joy:":              "😂",
+	":wink:":             "😉",
+	":thinking:":         "🤔",
+	":disappointed:":     "😞",
+	":thumbsup:":         "👍",
+	":+1:":               "👍",
+	":thumbsdown:":       "👎",
+	":-1:":               "👎",
+	":clap:":             "👏",
+	":pray:":             "🙏",
+	":eyes:":             "👀",
+	":heart:":            "❤️",
+	":tada:":             "🎉",
+	":rocket:":           "🚀",
+	":fire:":             "🔥",
+	":bulb:":             "💡",
+	":star:":             "⭐",
+	":warning:":          "⚠️",
+	":white_check_mark:": "✅",
+	":check_mark:":       "✅",
+	":x:":                "❌",
+	":cross_mark:":       "❌",
+	":info:":             "ℹ️",
+	":question:":         "❓",
+	":exclamation:":      "❗",
+	":100:":              "💯",
+}
+
+func (r renderer) inlines(nodes []jira.ContentNode) string {
+	var text strings.Builder
+	for _, node := range nodes {
+		text.WriteString(r.inline(node))
+	}
+	return text.String()
+}
+
+func (r renderer) inline(node jira.ContentNode) string {
+	switch node.Type {
+	case "mention":
+		return r.mention(node)
+	case "inlineCard":
+		url := ""
+		if node.Attrs != nil {
+			url = node.Attrs.URL
+		}
+		text := ui.LinkStyle.Render(url)
+		if url != "" {
+			text = ui.Osc8(url, text)
+		}
+		return text
+	case "hardBreak":
+		return "\n"
+	case "emoji":
+		return emoji(node.Attrs)
+	case "date":
+		return date(node.Attrs)
+	case "status":
+		return lozenge(node.Attrs)
+	case "placeholder":
+		if node.Attrs == nil {
+			return ""
+		}
+		return ui.DimTextStyle.Render(node.Attrs.Text)
+	case "mediaInline":
+		return strings.TrimSuffix(formatMedia(jira.ContentNode{Content: []jira.ContentNode{{Type: "media", Attrs: node.Attrs}}}), "\n")
+	}
+
+	text := node.Text
+
+	for _, mark := range node.Marks {
+		switch mark.Type {
+		case "strong":
+			text = ui.BoldStyle.Render(text)
+		case "em":
+			text = ui.ItalicStyle.Render(text)
+		case "code":
+			text = ui.InlineCodeStyle.Render(text)
+		case "strike":
+			text = ui.StrikeStyle.Render(text)
+		case "underline":
+			text = ui.UnderlineStyle.Render(text)
+		case "textColor":
+			if mark.Attrs != nil && mark.Attrs.Color != "" {
+				text = lipgloss.NewStyle().Foreground(lipgloss.Color(mark.Attrs.Color)).Render(text)
+			}
+		case "link":
+			text = ui.LinkStyle.Render(text)
+			if mark.Attrs != nil && mark.Attrs.Href != "" {
+				text = ui.Osc8(mark.Attrs.Href, text)
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		text += r.inline(child)
+	}
+
+	return text
+}
+
+// mention shows the user's current display name when the ID is known, else
+// the text stored with the mention.
+func (r renderer) mention(node jira.ContentNode) string {
+	if node.Attrs == nil {
+		return ""
+	}
+	name := node.Attrs.Text
+	if known, ok := r.users[node.Attrs.ID]; ok && known != "" {
+		name = "@" + known
+	}
+	if name == "" {
+		name = node.Attrs.ID
+	}
+	return ui.MentionStyle.Render(name)
+}
+
+// emoji prefers the stored character, then the ID when it spells codepoints
+// ("1f44d" or "1f44d-1f3fb"), then the known shortcodes.
+func emoji(attrs *jira.NodeAttrs) string {
+	if attrs == nil {
+		return ""
+	}
+	if attrs.Text != "" {
+		return attrs.Text
+	}
+	if s, ok := codepoints(attrs.ID); ok {
+		return s
+	}
+	if s, ok := emojiShortcodes[attrs.ShortName]; ok {
+		return s
+	}
+	return attrs.ShortName
+}
+
+func codepoints(id string) (string, bool) {
+	if id == "" {
+		return "", false
+	}
+	var s strings.Builder
+	for part := range strings.SplitSeq(id, "-") {
+		cp, err := strconv.ParseUint(part, 16, 32)
+		if err != nil || cp < 0x80 {
+			return "", false
+		}
+		s.WriteRune(rune(cp))
+	}
+	return s.String(), true
+}
+
+// date renders a date node's day. The timestamp is midnight UTC of that day.
+func date(attrs *jira.NodeAttrs) string {
+	if attrs == nil {
+		return ""
+	}
+	ms, err := strconv.ParseInt(attrs.Timestamp, 10, 64)
+	if err != nil {
+		return attrs.Timestamp
+	}
+	return ui.DateStyle.Render(time.UnixMilli(ms).UTC().Format("Jan 2, 2006"))
+}
+
+func lozenge(attrs *jira.NodeAttrs) string {
+	if attrs == nil || attrs.Text == "" {
+		return ""
+	}
+	color, ok := ui.AdfLozengeColors[attrs.Color]
+	if !ok {
+		color = ui.AdfLozengeColors["neutral"]
+	}
+	return ui.LozengeStyle.Background(color).Render(strings.ToUpper(attrs.Text))
+}
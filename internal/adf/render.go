@@ -0,0 +1,303 @@
+// Package adf renders Atlassian Document Format (ADF) documents, the rich
+// text of descriptions and comments, as styled terminal text.
+package adf
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+// Options controls how a document is rendered.
+type Options struct {
+	// Width is the width, in cells, text is wrapped to.
+	Width int
+	// Users resolves mention IDs to display names.
+	Users []jira.User
+	// Image draws an embedded file as a picture at most width cells wide,
+	// shown above its name. It returns "" for files it can't draw.
+	Image func(media jira.NodeAttrs, width int) string
+}
+
+type renderer struct {
+	users map[string]string
+	image func(media jira.NodeAttrs, width int) string
+}
+
+// Render renders doc's blocks one after the other, wrapped to opts.Width.
+// Node types it doesn't know degrade to their text.
+func Render(doc *jira.ContentDoc, opts Options) string {
+	if doc == nil {
+		return ""
+	}
+
+	r := renderer{users: make(map[string]string, len(opts.Users)), image: opts.Image}
+	for _, u := range opts.Users {
+		r.users[u.ID] = u.Name
+	}
+
+	var text strings.Builder
+	for _, node := range doc.Content {
+		text.WriteString(r.block(node, opts.Width) + "\n")
+	}
+	return text.String()
+}
+
+func (r renderer) block(node jira.ContentNode, width int) string {
+	switch node.Type {
+	case "heading":
+		return r.heading(node)
+	case "paragraph":
+		return r.paragraph(node, width)
+	case "codeBlock":
+		return r.codeBlock(node, width)
+	case "bulletList":
+		return r.bulletList(node, 0, width)
+	case "orderedList":
+		return r.orderedList(node, 0, width)
+	case "taskList":
+		return r.taskList(node, width)
+	case "decisionList":
+		return r.decisionList(node, width)
+	case "table":
+		return r.table(node, width)
+	case "panel":
+		return r.panel(node, width)
+	case "blockquote":
+		return r.blockquote(node, width)
+	case "expand", "nestedExpand":
+		return r.expand(node, width)
+	case "blockCard", "embedCard":
+		return r.inline(jira.ContentNode{Type: "inlineCard", Attrs: node.Attrs}) + "\n"
+	case "mediaSingle", "mediaGroup":
+		return r.media(node, width)
+	case "rule":
+		return "─────────────────────"
+	default:
+		return ansi.Wrap(r.inlines(node.Content), width, "") + "\n"
+	}
+}
+
+// blocks renders nodes as a block sequence, without the trailing blank
+// lines, for nesting inside panels, quotes and expands.
+func (r renderer) blocks(nodes []jira.ContentNode, width int) string {
+	var text strings.Builder
+	for _, node := range nodes {
+		text.WriteString(r.block(node, width) + "\n")
+	}
+	return strings.TrimRight(text.String(), "\n")
+}
+
+func (r renderer) heading(node jira.ContentNode) string {
+	return ui.HeadingStyle.Render("# " + r.inlines(node.Content))
+}
+
+func (r renderer) paragraph(node jira.ContentNode, width int) string {
+	return ansi.Wrap(r.inlines(node.Content), width, "") + "\n"
+}
+
+// codeBlock highlights the code when its language is known, and shows it
+// plain otherwise.
+func (r renderer) codeBlock(node jira.ContentNode, width int) string {
+	code := r.inlines(node.Content)
+	language := ""
+	if node.Attrs != nil {
+		language = strings.ToLower(node.Attrs.Language)
+	}
+	if highlighted, ok := highlight(code, language, width); ok {
+		return ui.CodeBlockStyle.Render(highlighted)
+	}
+	return ui.CodeBlockStyle.Render(ansi.Wrap(code, width, ""))
+}
+
+func (r renderer) orderedList(node jira.ContentNode, indent int, width int) string {
+	var items strings.Builder
+	num := 1
+	for _, item := range node.Content {
+		if item.Type != "listItem" {
+			continue
+		}
+		marker := fmt.Sprintf("%d. ", num)
+		itemText := r.listItem(item, indent, width-lipgloss.Width(marker))
+		items.WriteString(strings.Repeat("  ", indent) + marker + itemText + "\n")
+		num++
+	}
+	return items.String()
+}
+
+func (r renderer) bulletList(node jira.ContentNode, indent int, width int) string {
+	var items strings.Builder
+	for _, item := range node.Content {
+		if item.Type == "listItem" {
+			bullet := ui.IconBullet + " "
+			itemText := r.listItem(item, indent, width-lipgloss.Width(bullet))
+			items.WriteString(strings.Repeat("  ", indent) + bullet + itemText + "\n")
+		}
+	}
+	return items.String()
+}
+
+func (r renderer) listItem(node jira.ContentNode, indent int, width int) string {
+	var text strings.Builder
+	for _, child := range node.Content {
+		switch child.Type {
+		case "paragraph":
+			text.WriteString(r.paragraph(child, width))
+		case "bulletList":
+			text.WriteString(r.bulletList(child, indent+1, width))
+		case "orderedList":
+			text.WriteString(r.orderedList(child, indent+1, width))
+		default:
+			text.WriteString(r.block(child, width) + "\n")
+		}
+	}
+	return text.String()
+}
+
+// taskList renders items as checkboxes; a taskList inside a taskList is a
+// nested level.
+func (r renderer) taskList(node jira.ContentNode, width int) string {
+	var items strings.Builder
+	for _, item := range node.Content {
+		switch item.Type {
+		case "taskItem":
+			done := item.Attrs != nil && item.Attrs.State == "DONE"
+			box, text := "[ ] ", r.inlines(item.Content)
+			if done {
+				box = ui.TaskDoneStyle.Render("[x]") + " "
+				text = ui.TaskDoneStyle.Render(text)
+			}
+			items.WriteString(hangingIndent(box, ansi.Wrap(text, width-4, "")) + "\n")
+		case "taskList":
+			items.WriteString(indentLines("    ", strings.TrimRight(r.taskList(item, width-4), "\n")) + "\n")
+		}
+	}
+	return items.String()
+}
+
+func (r renderer) decisionList(node jira.ContentNode, width int) string {
+	var items strings.Builder
+	for _, item := range node.Content {
+		if item.Type != "decisionItem" {
+			continue
+		}
+		marker := ui.DecisionMarkerStyle.Render("◆") + " "
+		items.WriteString(hangingIndent(marker, ansi.Wrap(r.inlines(item.Content), width-2, "")) + "\n")
+	}
+	return items.String()
+}
+
+// panel boxes its content in a border colored by panelType, with the type's
+// icon in front.
+func (r renderer) panel(node jira.ContentNode, width int) string {
+	panelType := "info"
+	if node.Attrs != nil && node.Attrs.PanelType != "" {
+		panelType = node.Attrs.PanelType
+	}
+	color, ok := ui.AdfPanelColors[panelType]
+	if !ok {
+		color = ui.ThemeBorder
+	}
+	icon := ui.AdfPanelIcons[panelType]
+	if icon == "" {
+		icon = ui.AdfPanelIcons["info"]
+	}
+
+	// Border and padding take two cells a side, the icon two more.
+	body := r.blocks(node.Content, width-6)
+	body = hangingIndent(lipgloss.NewStyle().Foreground(color).Render(icon)+" ", body)
+
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		Padding(0, 1)
+	if width > 6 {
+		style = style.Width(width)
+	}
+	return style.Render(body) + "\n"
+}
+
+func (r renderer) blockquote(node jira.ContentNode, width int) string {
+	return indentLines(ui.BlockquoteBarStyle.Render("│")+" ", r.blocks(node.Content, width-2)) + "\n"
+}
+
+// expand renders a collapsible section open: its title, then its content
+// indented under it.
+func (r renderer) expand(node jira.ContentNode, width int) string {
+	title := ""
+	if node.Attrs != nil {
+		title = node.Attrs.Title
+	}
+	if title == "" {
+		title = "Details"
+	}
+	heading := ui.ExpandTitleStyle.Render(ui.IconExpanded + " " + title)
+	return heading + "\n" + indentLines("  ", r.blocks(node.Content, width-2)) + "\n"
+}
+
+// media draws the embedded files it has pictures for over their names.
+func (r renderer) media(node jira.ContentNode, width int) string {
+	if r.image == nil {
+		return formatMedia(node)
+	}
+	var content strings.Builder
+	for _, item := range node.Content {
+		if item.Type != "media" {
+			continue
+		}
+		if item.Attrs != nil {
+			if img := r.image(*item.Attrs, width); img != "" {
+				content.WriteString(img + "\n")
+			}
+		}
+		content.WriteString(formatMedia(jira.ContentNode{Content: []jira.ContentNode{item}}))
+	}
+	return content.String()
+}
+
+// formatMedia names the embedded files; their content is in the attachments
+// section. Media without alt text (pasted images) gets a generic name.
+func formatMedia(node jira.ContentNode) string {
+	var content strings.Builder
+	for _, item := range node.Content {
+		if item.Type != "media" {
+			continue
+		}
+		name := "attachment"
+		if item.Attrs != nil && item.Attrs.Alt != "" {
+			name = item.Attrs.Alt
+		}
+		content.WriteString("[📎 " + name + "]\n")
+	}
+
+	return content.String()
+}
+
+// indentLines puts prefix in front of every line of s.
+func indentLines(prefix, s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hangingIndent puts marker in front of the first line of s and aligns the
+// rest under it.
+func hangingIndent(marker, s string) string {
+	pad := strings.Repeat(" ", lipgloss.Width(marker))
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if i == 0 {
+			lines[i] = marker + line
+		} else {
+			lines[i] = pad + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
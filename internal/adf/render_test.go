@@ -0,0 +1,275 @@
+package adf
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+	"github.com/oliverjhernandez/jira-tui/internal/ui"
+)
+
+func TestRenderNil(t *testing.T) {
+	if got := Render(nil, Options{Width: 80}); got != "" {
+		t.Errorf("Render(nil) = %q, want empty", got)
+	}
+}
+
+func TestRenderParagraph(t *testing.T) {
+	doc := &jira.ContentDoc{
+		Type: "doc",
+		Content: []jira.ContentNode{
+			{
+				Type: "paragraph",
+				Content: []jira.ContentNode{
+					{Type: "text", Text: "Hello "},
+					{Type: "text", Text: "world"},
+				},
+			},
+		},
+	}
+	got := Render(doc, Options{Width: 80})
+	if !strings.Contains(got, "Hello") || !strings.Contains(got, "world") {
+		t.Errorf("Render paragraph = %q, want it to contain Hello world", got)
+	}
+}
+
+func TestRenderBlockTypes(t *testing.T) {
+	types := []string{"heading", "codeBlock", "bulletList", "orderedList", "rule"}
+	for _, typ := range types {
+		t.Run(typ, func(t *testing.T) {
+			doc := &jira.ContentDoc{
+				Content: []jira.ContentNode{
+					{
+						Type: typ,
+						Content: []jira.ContentNode{
+							{Type: "text", Text: "content"},
+						},
+					},
+				},
+			}
+			// Should not panic for any supported block type.
+			_ = Render(doc, Options{Width: 80})
+		})
+	}
+}
+
+func TestRenderMentionNilAttrs(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Render panicked on mention with nil Attrs: %v", r)
+		}
+	}()
+	doc := &jira.ContentDoc{
+		Content: []jira.ContentNode{
+			{
+				Type: "paragraph",
+				Content: []jira.ContentNode{
+					{Type: "mention"}, // Attrs is nil
+				},
+			},
+		},
+	}
+	_ = Render(doc, Options{Width: 80})
+}
+
+func TestRenderMediaNilAttrs(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Render panicked on media with nil Attrs: %v", r)
+		}
+	}()
+	doc := &jira.ContentDoc{
+		Content: []jira.ContentNode{
+			{
+				Type: "mediaSingle",
+				Content: []jira.ContentNode{
+					{Type: "media"}, // Attrs is nil
+				},
+			},
+		},
+	}
+	_ = Render(doc, Options{Width: 80})
+}
+
+func TestRenderMediaImage(t *testing.T) {
+	doc := &jira.ContentDoc{
+		Content: []jira.ContentNode{
+			{
+				Type: "mediaGroup",
+				Content: []jira.ContentNode{
+					{Type: "media", Attrs: &jira.NodeAttrs{Alt: "shot.png"}},
+					{Type: "media", Attrs: &jira.NodeAttrs{Alt: "log.txt"}},
+				},
+			},
+		},
+	}
+	var widths []int
+	image := func(media jira.NodeAttrs, width int) string {
+		widths = append(widths, width)
+		if media.Alt == "shot.png" {
+			return "<picture>"
+		}
+		return ""
+	}
+
+	got := ansi.Strip(Render(doc, Options{Width: 60, Image: image}))
+	if want := "<picture>\n[📎 shot.png]\n[📎 log.txt]\n\n"; got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+	if len(widths) != 2 || widths[0] != 60 {
+		t.Errorf("Image called with widths %v, want the render width", widths)
+	}
+}
+
+func TestRenderIrregularTable(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Render panicked on table with irregular rows: %v", r)
+		}
+	}()
+
+	cell := func(text string) jira.ContentNode {
+		return jira.ContentNode{
+			Type: "tableCell",
+			Content: []jira.ContentNode{
+				{Type: "paragraph", Content: []jira.ContentNode{{Type: "text", Text: text}}},
+			},
+		}
+	}
+	row := func(cells ...jira.ContentNode) jira.ContentNode {
+		return jira.ContentNode{Type: "tableRow", Content: cells}
+	}
+
+	doc := &jira.ContentDoc{
+		Content: []jira.ContentNode{
+			{
+				Type: "table",
+				Content: []jira.ContentNode{
+					row(cell("a"), cell("b")),            // 2 cells
+					row(cell("c"), cell("d"), cell("e")), // 3 cells (more than first row)
+				},
+			},
+		},
+	}
+	_ = Render(doc, Options{Width: 120})
+}
+
+func TestHardWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		want  string
+	}{
+		{"no wrap needed", "abc", 10, "abc"},
+		{"wrap long line", "abcdef", 3, "abc\ndef"},
+		{"zero width returns input", "abcdef", 0, "abcdef"},
+		{"negative width returns input", "abcdef", -1, "abcdef"},
+		{"preserves existing newlines", "ab\ncd", 10, "ab\ncd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hardWrap(tt.in, tt.width); got != tt.want {
+				t.Errorf("hardWrap(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func parse(t *testing.T, src string) *jira.ContentDoc {
+	t.Helper()
+	var doc jira.ContentDoc
+	if err := json.Unmarshal([]byte(src), &doc); err != nil {
+		t.Fatalf("bad fixture: %v", err)
+	}
+	return &doc
+}
+
+func plain(doc *jira.ContentDoc, width int) string {
+	return ansi.Strip(Render(doc, Options{Width: width, Users: []jira.User{{ID: "acc-1", Name: "Jane Doe"}}}))
+}
+
+func TestRenderPanel(t *testing.T) {
+	doc := parse(t, `{"content":[{"type":"panel","attrs":{"panelType":"warning"},"content":[
+		{"type":"paragraph","content":[{"type":"text","text":"Careful with prod"}]}]}]}`)
+	got := plain(doc, 40)
+	lines := strings.Split(strings.TrimRight(got, "\n"), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "╭") || !strings.Contains(lines[1], "⚠ Careful with prod") {
+		t.Fatalf("panel = %q", got)
+	}
+	for _, l := range lines {
+		if w := ansi.StringWidth(l); w != 40 {
+			t.Errorf("line %q is %d wide, want the full 40", l, w)
+		}
+	}
+}
+
+func TestRenderInlineNodes(t *testing.T) {
+	doc := parse(t, `{"content":[{"type":"paragraph","content":[
+		{"type":"status","attrs":{"text":"In review","color":"blue"}},
+		{"type":"text","text":" by "},
+		{"type":"mention","attrs":{"id":"acc-1","text":"@jane"}},
+		{"type":"text","text":" on "},
+		{"type":"date","attrs":{"timestamp":"1792195200000"}},
+		{"type":"text","text":" "},
+		{"type":"emoji","attrs":{"shortName":":thumbsup:","id":"1f44d"}},
+		{"type":"emoji","attrs":{"shortName":":tada:","id":"atlassian-tada"}},
+		{"type":"emoji","attrs":{"shortName":":smile:","text":"😄"}},
+		{"type":"emoji","attrs":{"shortName":":unknown:"}},
+		{"type":"text","text":" "},
+		{"type":"inlineCard","attrs":{"url":"https://example.com/x"}}]}]}`)
+	got := plain(doc, 200)
+	for _, want := range []string{" IN REVIEW ", "@Jane Doe", "Oct 17, 2026", "👍🎉😄:unknown:", "https://example.com/x"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in %q", want, got)
+		}
+	}
+}
+
+func TestRenderMentionFallsBackToText(t *testing.T) {
+	doc := parse(t, `{"content":[{"type":"paragraph","content":[
+		{"type":"mention","attrs":{"id":"acc-9","text":"@Someone"}},
+		{"type":"mention","attrs":{"id":"acc-8"}}]}]}`)
+	got := plain(doc, 80)
+	if !strings.Contains(got, "@Someone") || !strings.Contains(got, "acc-8") {
+		t.Errorf("mentions = %q", got)
+	}
+}
+
+func TestRenderBlockquoteAndExpand(t *testing.T) {
+	doc := parse(t, `{"content":[
+		{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted"}]}]},
+		{"type":"expand","attrs":{"title":"More"},"content":[
+			{"type":"paragraph","content":[{"type":"text","text":"outer"}]},
+			{"type":"nestedExpand","attrs":{"title":"Inner"},"content":[
+				{"type":"paragraph","content":[{"type":"text","text":"inner"}]}]}]}]}`)
+	got := plain(doc, 80)
+	for _, want := range []string{"│ quoted", "More\n  outer", "  " + ui.IconExpanded + " Inner\n    inner"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in %q", want, got)
+		}
+	}
+}
+
+func TestRenderTaskList(t *testing.T) {
+	doc := parse(t, `{"content":[{"type":"taskList","content":[
+		{"type":"taskItem","attrs":{"state":"DONE"},"content":[{"type":"text","text":"write it"}]},
+		{"type":"taskItem","attrs":{"state":"TODO"},"content":[{"type":"text","text":"ship it"}]},
+		{"type":"taskList","content":[
+			{"type":"taskItem","attrs":{"state":"TODO"},"content":[{"type":"text","text":"tell people"}]}]}]}]}`)
+	got := plain(doc, 80)
+	if !strings.Contains(got, "[x] write it\n[ ] ship it\n    [ ] tell people") {
+		t.Errorf("task list = %q", got)
+	}
+}
+
+func TestRenderUnknownNodeKeepsText(t *testing.T) {
+	doc := parse(t, `{"content":[{"type":"somethingNew","content":[
+		{"type":"paragraph","content":[{"type":"text","text":"still here"}]}]}]}`)
+	if got := plain(doc, 80); strings.TrimSpace(got) != "still here" {
+		t.Errorf("unknown node = %q", got)
+	}
+}
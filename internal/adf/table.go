@@ -0,0 +1,141 @@
+package adf
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/oliverjhernandez/jira-tui/internal/jira"
+)
+
+func hardWrap(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	var result []string
+	for _, line := range lines {
+		for len(line) > width {
+			result = append(result, line[:width])
+			line = line[width:]
+		}
+		result = append(result, line)
+	}
+	return strings.Join(result, "\n")
+}
+
+func (r renderer) table(node jira.ContentNode, panelWidth int) string {
+	var allRows [][]string
+
+	for _, row := range node.Content {
+		if row.Type == "tableRow" {
+			cells := r.rowCells(row)
+			if !isEmptyRow(cells) {
+				allRows = append(allRows, cells)
+			}
+		}
+	}
+
+	if len(allRows) == 0 {
+		return ""
+	}
+
+	colWidths := calculateColumnWidths(allRows, panelWidth)
+
+	var output strings.Builder
+	for _, row := range allRows {
+		var cells []string
+		for i, cell := range row {
+			if i >= len(colWidths) {
+				break
+			}
+			cellStyle := lipgloss.NewStyle().Width(colWidths[i])
+			cells = append(cells, cellStyle.Render(hardWrap(cell, colWidths[i])))
+		}
+		output.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cells...) + "\n")
+	}
+
+	return output.String()
+}
+
+func calculateColumnWidths(rows [][]string, maxWidth int) []int {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	numCols := len(rows[0])
+	widths := make([]int, numCols)
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < numCols {
+				cellWidth := ansi.StringWidth(cell)
+				if cellWidth > widths[i] {
+					widths[i] = cellWidth
+				}
+			}
+		}
+	}
+
+	for i := range widths {
+		widths[i] += 4
+		if widths[i] < 25 {
+			widths[i] = 25
+		}
+	}
+
+	totalWidth := 0
+	for _, w := range widths {
+		totalWidth += w
+	}
+
+	if totalWidth > maxWidth {
+		scale := float64(maxWidth) / float64(totalWidth)
+		for i := range widths {
+			widths[i] = max(int(float64(widths[i])*scale),
+				10)
+		}
+	} else {
+		scale := float64(maxWidth) / float64(totalWidth)
+		for i := range widths {
+			widths[i] = int(float64(widths[i]) * scale)
+		}
+	}
+
+	return widths
+}
+
+func isEmptyRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (r renderer) rowCells(row jira.ContentNode) []string {
+	var cells []string
+
+	for _, cell := range row.Content {
+		if cell.Type == "tableHeader" || cell.Type == "tableCell" {
+			cellText := r.cellText(cell)
+			cells = append(cells, cellText)
+		}
+	}
+
+	return cells
+}
+
+func (r renderer) cellText(cell jira.ContentNode) string {
+	var text strings.Builder
+	for _, child := range cell.Content {
+		if child.Type == "paragraph" {
+			for _, node := range child.Content {
+				text.WriteString(r.inline(node))
+			}
+		}
+	}
+	return text.String()
+}
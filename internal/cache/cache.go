@@ -0,0 +1,160 @@
+// Package cache persists the data jira-tui fetches at startup (boards, users,
+// statuses, ...) so the next launch can render the last-known state before the
+// network answers.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Kind is a category of cached data. Each kind has its own TTL.
+type Kind string
+
+const (
+	Myself     Kind = "myself"
+	Projects   Kind = "projects"
+	Issues     Kind = "issues"
+	IssueTypes Kind = "issue-types"
+	Priorities Kind = "priorities"
+	Statuses   Kind = "statuses"
+	Users      Kind = "users"
+	Fields     Kind = "fields"
+)
+
+// DefaultTTLs is how long each kind is considered fresh. Fresh entries are
+// used as-is; stale ones are still shown but refetched. Boards change often and
+// the rest rarely, hence the spread.
+var DefaultTTLs = map[Kind]time.Duration{
+	Myself:     24 * time.Hour,
+	Projects:   24 * time.Hour,
+	Issues:     time.Minute,
+	IssueTypes: 24 * time.Hour,
+	Priorities: 24 * time.Hour,
+	Statuses:   6 * time.Hour,
+	Users:      12 * time.Hour,
+	Fields:     24 * time.Hour,
+}
+
+// ErrMiss is returned by Load when there is no usable entry.
+var ErrMiss = errors.New("cache: miss")
+
+// entry is the on-disk envelope around a cached value.
+type entry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Store is a directory of JSON files, one per kind and key. A nil *Store is a
+// valid, disabled cache: Load always misses and Save does nothing, which is how
+// --no-cache is implemented.
+type Store struct {
+	dir  string
+	ttls map[Kind]time.Duration
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// New returns a store rooted at dir using DefaultTTLs. The directory is created
+// on the first Save.
+func New(dir string) *Store {
+	return &Store{dir: dir, ttls: DefaultTTLs, now: time.Now}
+}
+
+// DefaultDir returns $XDG_CACHE_HOME/jira-tui/<profile>, falling back to
+// ~/.cache/jira-tui/<profile>. Profiles never share a cache, since they
+// usually point at different Jira sites.
+func DefaultDir(profile string) string {
+	if profile == "" {
+		profile = "default"
+	}
+	dir := os.Getenv("XDG_CACHE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".cache", "jira-tui", profile)
+		}
+		dir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(dir, "jira-tui", profile)
+}
+
+// Load decodes the entry for kind/key into v. fresh reports whether the entry
+// is younger than the kind's TTL. It returns ErrMiss when nothing is cached or
+// the file can't be decoded (a corrupt entry is treated as absent).
+func (s *Store) Load(kind Kind, key string, v any) (fresh bool, err error) {
+	if s == nil {
+		return false, ErrMiss
+	}
+
+	raw, err := os.ReadFile(s.path(kind, key))
+	if err != nil {
+		return false, ErrMiss
+	}
+
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil || len(e.Data) == 0 {
+		return false, ErrMiss
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return false, ErrMiss
+	}
+
+	return s.now().Sub(e.SavedAt) < s.ttls[kind], nil
+}
+
+// Save stores v as the entry for kind/key. The file is written atomically so a
+// crash mid-write never leaves a truncated entry behind.
+func (s *Store) Save(kind Kind, key string, v any) error {
+	if s == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("cache: encoding %s: %w", kind, err)
+	}
+	raw, err := json.Marshal(entry{SavedAt: s.now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("cache: encoding %s: %w", kind, err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, "."+string(kind)+"-*")
+	if err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("cache: writing %s: %w", kind, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cache: writing %s: %w", kind, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(kind, key)); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+	return nil
+}
+
+// path maps kind/key to a file name. Keys can be arbitrary strings (a board's
+// JQL), so they are hashed.
+func (s *Store) path(kind Kind, key string) string {
+	name := string(kind)
+	if key != "" {
+		sum := sha256.Sum256([]byte(strings.TrimSpace(key)))
+		name += "-" + hex.EncodeToString(sum[:8])
+	}
+	return filepath.Join(s.dir, name+".json")
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type item struct {
+	Key  string
+	Name string
+}
+
+func newTestStore(t *testing.T) (*Store, *time.Time) {
+	t.Helper()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := New(filepath.Join(t.TempDir(), "profile"))
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	s, _ := newTestStore(t)
+
+	want := []item{{Key: "A-1", Name: "first"}, {Key: "A-2", Name: "second"}}
+	if err := s.Save(Issues, "project = A", want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	var got []item
+	fresh, err := s.Load(Issues, "project = A", &got)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !fresh {
+		t.Error("entry just saved should be fresh")
+	}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestStoreKeysAreIndependent(t *testing.T) {
+	s, _ := newTestStore(t)
+
+	if err := s.Save(Issues, "project = A", []item{{Key: "A-1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []item
+	if _, err := s.Load(Issues, "project = B", &got); !errors.Is(err, ErrMiss) {
+		t.Errorf("other key: err = %v, want ErrMiss", err)
+	}
+	if _, err := s.Load(Users, "project = A", &got); !errors.Is(err, ErrMiss) {
+		t.Errorf("other kind: err = %v, want ErrMiss", err)
+	}
+}
+
+func TestStoreTTL(t *testing.T) {
+	s, now := newTestStore(t)
+
+	if err := s.Save(Issues, "", []item{{Key: "A-1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(Users, "", []item{{Name: "ana"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	*now = now.Add(DefaultTTLs[Issues] + time.Second)
+
+	var got []item
+	fresh, err := s.Load(Issues, "", &got)
+	if err != nil {
+		t.Fatalf("stale entries are still returned: %v", err)
+	}
+	if fresh {
+		t.Error("issues past their TTL should be stale")
+	}
+	if len(got) != 1 || got[0].Key != "A-1" {
+		t.Errorf("got %+v", got)
+	}
+
+	fresh, err = s.Load(Users, "", &got)
+	if err != nil || !fresh {
+		t.Errorf("users within their TTL: fresh = %v, err = %v", fresh, err)
+	}
+}
+
+func TestStoreCorruptEntryIsMiss(t *testing.T) {
+	s, _ := newTestStore(t)
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(s.path(Priorities, ""), []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []item
+	if _, err := s.Load(Priorities, "", &got); !errors.Is(err, ErrMiss) {
+		t.Errorf("err = %v, want ErrMiss", err)
+	}
+}
+
+func TestNilStoreIsDisabled(t *testing.T) {
+	var s *Store
+
+	if err := s.Save(Users, "", []item{{Name: "ana"}}); err != nil {
+		t.Errorf("Save on nil store: %v", err)
+	}
+	var got []item
+	if _, err := s.Load(Users, "", &got); !errors.Is(err, ErrMiss) {
+		t.Errorf("Load on nil store: err = %v, want ErrMiss", err)
+	}
+}
+
+func TestDefaultDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+
+	if got, want := DefaultDir("work"), filepath.Join("/tmp/xdg-cache", "jira-tui", "work"); got != want {
+		t.Errorf("DefaultDir(work) = %q, want %q", got, want)
+	}
+	if got, want := DefaultDir(""), filepath.Join("/tmp/xdg-cache", "jira-tui", "default"); got != want {
+		t.Errorf("DefaultDir(\"\") = %q, want %q", got, want)
+	}
+}
@@ -2,46 +2,399 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
 )
 
+// DefaultProfile is the profile used when neither --profile, JIRA_TUI_PROFILE
+// nor the file's default_profile names one.
+const DefaultProfile = "default"
+
 type Config struct {
+	// Profile is the name of the profile the settings were loaded from.
+	Profile    string
 	JiraURL    string
 	JiraToken  string
+	JiraEmail  string
 	TempoURL   string
 	TempoToken string
-	JIraEmail  string
+	// WorklogBackend is "tempo" or "jira". When unset it defaults to "tempo"
+	// if any Tempo setting is present, otherwise to Jira's native worklogs.
+	WorklogBackend string
+	// JiraFlavor is "cloud" (the default) or "server" for Jira Server/Data
+	// Center, where JiraToken is a personal access token and JiraEmail is
+	// unused.
+	JiraFlavor string
+	// Workflow describes the profile's status and transition names.
+	Workflow Workflow
+	// CustomFields are the custom fields shown in lists and issue detail.
+	CustomFields []CustomField
+	// DownloadDir is where attachments are saved. Defaults to
+	// DefaultDownloadDir; a leading ~/ is expanded.
+	DownloadDir string
+	// InlineImages picks how screenshots in descriptions and comments are
+	// drawn: "auto" (the default) detects kitty graphics or sixel support,
+	// "kitty" and "sixel" force a protocol, "off" always shows file names.
+	InlineImages string
+}
+
+// Jira deployment flavors accepted by jira_flavor. "datacenter" is accepted
+// as an alias for FlavorServer.
+const (
+	FlavorCloud  = "cloud"
+	FlavorServer = "server"
+)
+
+// Worklog backends accepted by worklog_backend.
+const (
+	WorklogBackendTempo = "tempo"
+	WorklogBackendJira  = "jira"
+)
+
+// Inline image modes accepted by inline_images.
+const (
+	InlineImagesAuto  = "auto"
+	InlineImagesKitty = "kitty"
+	InlineImagesSixel = "sixel"
+	InlineImagesOff   = "off"
+)
+
+// Profile is one named set of settings in the config file.
+type Profile struct {
+	JiraURL        string        `toml:"jira_url"`
+	JiraEmail      string        `toml:"jira_email"`
+	JiraToken      string        `toml:"jira_token"`
+	TempoURL       string        `toml:"tempo_url"`
+	TempoToken     string        `toml:"tempo_token"`
+	JiraFlavor     string        `toml:"jira_flavor"`
+	WorklogBackend string        `toml:"worklog_backend"`
+	Workflow       Workflow      `toml:"workflow"`
+	CustomFields   []CustomField `toml:"custom_fields"`
+	DownloadDir    string        `toml:"download_dir"`
+	InlineImages   string        `toml:"inline_images"`
+}
+
+// CustomField declares a custom field to show. Name is the field's name as
+// Jira displays it or its ID; either is resolved against Jira's field list.
+//
+//	[[profiles.work.custom_fields]]
+//	name   = "Story Points"
+//	column = true
+//	width  = 6
+//
+//	[[profiles.work.custom_fields]]
+//	name   = "customfield_10042" # Cliente
+//	detail = true
+type CustomField struct {
+	Name string `toml:"name"`
+	// Column adds the field as a list column, Width cells wide (default 12),
+	// labelled Header (default: the upper-cased field name).
+	Column bool   `toml:"column"`
+	Width  int    `toml:"width"`
+	Header string `toml:"header"`
+	// Detail adds the field to the issue detail's metadata panel.
+	Detail bool `toml:"detail"`
+}
+
+// Workflow names the statuses, priorities and transitions the TUI treats
+// specially. Every list is optional and matched case-insensitively; when one is
+// empty the TUI falls back to what Jira reports (status categories, the
+// priority order from /priority).
+//
+//	[profiles.work.workflow]
+//	status_order        = ["In Progress", "In Review", "To Do", "Backlog"]
+//	in_transit_statuses = ["In Review", "Ready to Deploy"]
+//	closed_statuses     = ["Done", "Won't Do"]
+//	cancel_transitions  = ["cancel", "won't do"]
+//
+//	[profiles.work.workflow.projects.OPS]
+//	in_transit_statuses = ["Waiting for Customer"]
+type Workflow struct {
+	// StatusOrder sorts issues within a section; unlisted statuses go last.
+	StatusOrder []string `toml:"status_order"`
+	// PriorityOrder sorts issues of the same status, highest first.
+	PriorityOrder []string `toml:"priority_order"`
+	// InTransitStatuses are pulled out of their category into "In Transit".
+	InTransitStatuses []string `toml:"in_transit_statuses"`
+	// ClosedStatuses are dimmed in lists. Defaults to the "done" category.
+	ClosedStatuses []string `toml:"closed_statuses"`
+	// CancelTransitions and BlockedTransitions are substrings of transition
+	// names that prompt for a cancel or block reason.
+	CancelTransitions  []string `toml:"cancel_transitions"`
+	BlockedTransitions []string `toml:"blocked_transitions"`
+	// Projects overrides any of the lists above per project key.
+	Projects map[string]Workflow `toml:"projects"`
 }
 
-func LoadConfig() (*Config, error) {
-	cfg := &Config{
-		JiraURL:    os.Getenv("JIRA_URL"),
-		JiraToken:  os.Getenv("JIRA_TOKEN"),
-		JIraEmail:  os.Getenv("JIRA_EMAIL"),
-		TempoURL:   os.Getenv("TEMPO_URL"),
-		TempoToken: os.Getenv("TEMPO_TOKEN"),
+// ForProject returns w with the lists set for projectKey layered on top.
+func (w Workflow) ForProject(projectKey string) Workflow {
+	p, ok := w.Projects[projectKey]
+	if !ok {
+		return w
+	}
+
+	merged := w
+	override := func(dst *[]string, src []string) {
+		if len(src) > 0 {
+			*dst = src
+		}
+	}
+	override(&merged.StatusOrder, p.StatusOrder)
+	override(&merged.PriorityOrder, p.PriorityOrder)
+	override(&merged.InTransitStatuses, p.InTransitStatuses)
+	override(&merged.ClosedStatuses, p.ClosedStatuses)
+	override(&merged.CancelTransitions, p.CancelTransitions)
+	override(&merged.BlockedTransitions, p.BlockedTransitions)
+	return merged
+}
+
+// File is the on-disk config file:
+//
+//	default_profile = "work"
+//
+//	[profiles.work]
+//	jira_url   = "https://work.atlassian.net"
+//	jira_email = "me@work.com"
+//	jira_token = "..."
+type File struct {
+	DefaultProfile string             `toml:"default_profile"`
+	Profiles       map[string]Profile `toml:"profiles"`
+}
+
+// envOverrides maps each env var to the Config field it overrides. Env vars
+// always win over the file so a single value can be swapped without editing it.
+var envOverrides = []struct {
+	name string
+	set  func(c *Config, v string)
+}{
+	{"JIRA_URL", func(c *Config, v string) { c.JiraURL = v }},
+	{"JIRA_EMAIL", func(c *Config, v string) { c.JiraEmail = v }},
+	{"JIRA_TOKEN", func(c *Config, v string) { c.JiraToken = v }},
+	{"TEMPO_URL", func(c *Config, v string) { c.TempoURL = v }},
+	{"TEMPO_TOKEN", func(c *Config, v string) { c.TempoToken = v }},
+	{"JIRA_FLAVOR", func(c *Config, v string) { c.JiraFlavor = v }},
+	{"JIRA_WORKLOG_BACKEND", func(c *Config, v string) { c.WorklogBackend = v }},
+	{"JIRA_TUI_DOWNLOAD_DIR", func(c *Config, v string) { c.DownloadDir = v }},
+	{"JIRA_TUI_INLINE_IMAGES", func(c *Config, v string) { c.InlineImages = v }},
+}
+
+// DefaultPath returns $XDG_CONFIG_HOME/jira-tui/config.toml, falling back to
+// ~/.config/jira-tui/config.toml.
+func DefaultPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "jira-tui", "config.toml")
+}
+
+// DefaultDownloadDir returns $XDG_DOWNLOAD_DIR, falling back to ~/Downloads.
+func DefaultDownloadDir() string {
+	if dir := os.Getenv("XDG_DOWNLOAD_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "Downloads")
+}
+
+// expandHome replaces a leading ~/ in path with the user's home directory.
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
+// LoadConfig reads the config file at path (a missing file is not an error),
+// selects a profile, applies env var overrides and validates the result.
+//
+// The profile is chosen from, in order: the profile argument (--profile), the
+// JIRA_TUI_PROFILE env var, the file's default_profile, then DefaultProfile.
+// Every validation problem is reported at once in the returned error.
+func LoadConfig(path, profile string) (*Config, error) {
+	f, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := firstNonEmpty(profile, os.Getenv("JIRA_TUI_PROFILE"), f.DefaultProfile, DefaultProfile)
+
+	cfg := &Config{Profile: name}
+	p, ok := f.Profiles[name]
+	if ok {
+		cfg.JiraURL = p.JiraURL
+		cfg.JiraEmail = p.JiraEmail
+		cfg.JiraToken = p.JiraToken
+		cfg.TempoURL = p.TempoURL
+		cfg.TempoToken = p.TempoToken
+		cfg.JiraFlavor = p.JiraFlavor
+		cfg.WorklogBackend = p.WorklogBackend
+		cfg.Workflow = p.Workflow
+		cfg.CustomFields = p.CustomFields
+		cfg.DownloadDir = p.DownloadDir
+		cfg.InlineImages = p.InlineImages
+	} else if name != DefaultProfile || profile != "" {
+		// Asking for a profile by name that doesn't exist is always a mistake;
+		// only the implicit default may be absent (env-only setups).
+		return nil, fmt.Errorf("profile %q not found in %s (available: %s)", name, path, strings.Join(f.profileNames(), ", "))
 	}
 
-	if cfg.JiraURL == "" {
-		return nil, fmt.Errorf("missing env var JIRA_URL")
+	for _, o := range envOverrides {
+		if v := os.Getenv(o.name); v != "" {
+			o.set(cfg, v)
+		}
 	}
 
-	if cfg.JiraToken == "" {
-		return nil, fmt.Errorf("missing env var JIRA_TOKEN")
+	switch strings.ToLower(cfg.JiraFlavor) {
+	case "", FlavorCloud:
+		cfg.JiraFlavor = FlavorCloud
+	case FlavorServer, "datacenter", "data-center", "dc":
+		cfg.JiraFlavor = FlavorServer
 	}
 
-	if cfg.JIraEmail == "" {
-		return nil, fmt.Errorf("missing env var JIRA_EMAIL")
+	if cfg.DownloadDir == "" {
+		cfg.DownloadDir = DefaultDownloadDir()
 	}
+	cfg.DownloadDir = expandHome(cfg.DownloadDir)
 
-	if cfg.TempoURL == "" {
-		return nil, fmt.Errorf("missing env var TEMPO_URL")
+	cfg.InlineImages = strings.ToLower(cfg.InlineImages)
+	if cfg.InlineImages == "" {
+		cfg.InlineImages = InlineImagesAuto
 	}
 
-	if cfg.TempoToken == "" {
-		return nil, fmt.Errorf("missing env var TEMPO_TOKEN")
+	if cfg.WorklogBackend == "" {
+		cfg.WorklogBackend = WorklogBackendJira
+		if cfg.TempoURL != "" || cfg.TempoToken != "" {
+			cfg.WorklogBackend = WorklogBackendTempo
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config for profile %q:\n%w", name, err)
 	}
 
 	return cfg, nil
 }
+
+// Validate reports every missing or malformed setting, joined into one error.
+func (c *Config) Validate() error {
+	var errs []error
+
+	require := func(value, key, env string) {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, fmt.Errorf("missing %s (or env var %s)", key, env))
+		}
+	}
+	requireURL := func(value, key string) {
+		if value != "" && !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			errs = append(errs, fmt.Errorf("%s must start with http:// or https://, got %q", key, value))
+		}
+	}
+
+	require(c.JiraURL, "jira_url", "JIRA_URL")
+	requireURL(c.JiraURL, "jira_url")
+	switch c.JiraFlavor {
+	case FlavorCloud:
+		require(c.JiraEmail, "jira_email", "JIRA_EMAIL")
+	case FlavorServer:
+	default:
+		errs = append(errs, fmt.Errorf("jira_flavor must be %q or %q, got %q", FlavorCloud, FlavorServer, c.JiraFlavor))
+	}
+	require(c.JiraToken, "jira_token", "JIRA_TOKEN")
+	requireURL(c.TempoURL, "tempo_url")
+
+	switch c.WorklogBackend {
+	case WorklogBackendTempo:
+		require(c.TempoURL, "tempo_url", "TEMPO_URL")
+		require(c.TempoToken, "tempo_token", "TEMPO_TOKEN")
+	case WorklogBackendJira:
+	default:
+		errs = append(errs, fmt.Errorf("worklog_backend must be %q or %q, got %q", WorklogBackendTempo, WorklogBackendJira, c.WorklogBackend))
+	}
+
+	switch c.InlineImages {
+	case InlineImagesAuto, InlineImagesKitty, InlineImagesSixel, InlineImagesOff:
+	default:
+		errs = append(errs, fmt.Errorf("inline_images must be %q, %q, %q or %q, got %q",
+			InlineImagesAuto, InlineImagesKitty, InlineImagesSixel, InlineImagesOff, c.InlineImages))
+	}
+
+	for i, f := range c.CustomFields {
+		if strings.TrimSpace(f.Name) == "" {
+			errs = append(errs, fmt.Errorf("custom_fields[%d] is missing a name", i))
+		}
+		if f.Width < 0 {
+			errs = append(errs, fmt.Errorf("custom_fields[%d] (%s) has a negative width", i, f.Name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func readFile(path string) (File, error) {
+	var f File
+	if path == "" {
+		return f, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return f, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	md, err := toml.Decode(string(data), &f)
+	if err != nil {
+		return f, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		return f, fmt.Errorf("parsing config %s: unknown keys: %s", path, strings.Join(keys, ", "))
+	}
+
+	return f, nil
+}
+
+func (f File) profileNames() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for n := range f.Profiles {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -1,6 +1,32 @@
 package config
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var allEnvVars = []string{"JIRA_URL", "JIRA_TOKEN", "JIRA_EMAIL", "TEMPO_URL", "TEMPO_TOKEN", "JIRA_WORKLOG_BACKEND", "JIRA_FLAVOR", "JIRA_TUI_PROFILE", "JIRA_TUI_DOWNLOAD_DIR", "XDG_DOWNLOAD_DIR", "JIRA_TUI_INLINE_IMAGES"}
+
+// clearEnv blanks every env var LoadConfig reads so tests don't pick up the
+// developer's real settings.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, k := range allEnvVars {
+		t.Setenv(k, "")
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	return path
+}
 
 func TestLoadConfig(t *testing.T) {
 	full := map[string]string{
@@ -26,6 +52,7 @@ func TestLoadConfig(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
 			for k, v := range full {
 				if k == tt.unset {
 					t.Setenv(k, "")
@@ -34,7 +61,8 @@ func TestLoadConfig(t *testing.T) {
 				}
 			}
 
-			cfg, err := LoadConfig()
+			// No config file: env vars alone must still work.
+			cfg, err := LoadConfig(filepath.Join(t.TempDir(), "missing.toml"), "")
 
 			if tt.wantErr {
 				if err == nil {
@@ -52,8 +80,8 @@ func TestLoadConfig(t *testing.T) {
 			if cfg.JiraToken != full["JIRA_TOKEN"] {
 				t.Errorf("JiraToken = %q, want %q", cfg.JiraToken, full["JIRA_TOKEN"])
 			}
-			if cfg.JIraEmail != full["JIRA_EMAIL"] {
-				t.Errorf("JIraEmail = %q, want %q", cfg.JIraEmail, full["JIRA_EMAIL"])
+			if cfg.JiraEmail != full["JIRA_EMAIL"] {
+				t.Errorf("JiraEmail = %q, want %q", cfg.JiraEmail, full["JIRA_EMAIL"])
 			}
 			if cfg.TempoURL != full["TEMPO_URL"] {
 				t.Errorf("TempoURL = %q, want %q", cfg.TempoURL, full["TEMPO_URL"])
@@ -64,3 +92,363 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+const twoProfiles = `
+default_profile = "work"
+
+[profiles.work]
+jira_url    = "https://work.atlassian.net"
+jira_email  = "me@work.com"
+jira_token  = "work-token"
+tempo_url   = "https://api.tempo.io"
+tempo_token = "work-tempo"
+
+[profiles.oss]
+jira_url    = "https://oss.atlassian.net"
+jira_email  = "me@oss.org"
+jira_token  = "oss-token"
+tempo_url   = "https://api.tempo.io"
+tempo_token = "oss-tempo"
+`
+
+func TestLoadConfigProfiles(t *testing.T) {
+	path := writeConfig(t, twoProfiles)
+
+	tests := []struct {
+		name        string
+		flag        string
+		env         string
+		wantURL     string
+		wantProfile string
+	}{
+		{name: "default_profile from file", wantURL: "https://work.atlassian.net", wantProfile: "work"},
+		{name: "flag selects profile", flag: "oss", wantURL: "https://oss.atlassian.net", wantProfile: "oss"},
+		{name: "env selects profile", env: "oss", wantURL: "https://oss.atlassian.net", wantProfile: "oss"},
+		{name: "flag beats env", flag: "work", env: "oss", wantURL: "https://work.atlassian.net", wantProfile: "work"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("JIRA_TUI_PROFILE", tt.env)
+
+			cfg, err := LoadConfig(path, tt.flag)
+			if err != nil {
+				t.Fatalf("LoadConfig() unexpected error: %v", err)
+			}
+			if cfg.JiraURL != tt.wantURL {
+				t.Errorf("JiraURL = %q, want %q", cfg.JiraURL, tt.wantURL)
+			}
+			if cfg.Profile != tt.wantProfile {
+				t.Errorf("Profile = %q, want %q", cfg.Profile, tt.wantProfile)
+			}
+		})
+	}
+}
+
+func TestLoadConfigEnvOverridesFile(t *testing.T) {
+	path := writeConfig(t, twoProfiles)
+	clearEnv(t)
+	t.Setenv("JIRA_TOKEN", "from-env")
+
+	cfg, err := LoadConfig(path, "oss")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if cfg.JiraToken != "from-env" {
+		t.Errorf("JiraToken = %q, want env override", cfg.JiraToken)
+	}
+	if cfg.JiraEmail != "me@oss.org" {
+		t.Errorf("JiraEmail = %q, want file value", cfg.JiraEmail)
+	}
+}
+
+func TestLoadConfigUnknownProfile(t *testing.T) {
+	path := writeConfig(t, twoProfiles)
+	clearEnv(t)
+
+	_, err := LoadConfig(path, "nope")
+	if err == nil {
+		t.Fatalf("LoadConfig() expected error for unknown profile")
+	}
+	if !strings.Contains(err.Error(), "oss, work") {
+		t.Errorf("error should list available profiles, got: %v", err)
+	}
+}
+
+func TestLoadConfigReportsAllProblems(t *testing.T) {
+	path := writeConfig(t, `
+[profiles.default]
+jira_url = "work.atlassian.net"
+worklog_backend = "tempo"
+`)
+	clearEnv(t)
+
+	_, err := LoadConfig(path, "")
+	if err == nil {
+		t.Fatalf("LoadConfig() expected error")
+	}
+	for _, want := range []string{"jira_url must start with", "jira_email", "jira_token", "tempo_url", "tempo_token"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadConfigWorklogBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no tempo settings defaults to jira",
+			env:  map[string]string{},
+			want: WorklogBackendJira,
+		},
+		{
+			name: "tempo settings default to tempo",
+			env:  map[string]string{"TEMPO_URL": "https://api.tempo.io", "TEMPO_TOKEN": "tempo-token"},
+			want: WorklogBackendTempo,
+		},
+		{
+			name: "explicit jira ignores tempo settings",
+			env:  map[string]string{"TEMPO_TOKEN": "tempo-token", "JIRA_WORKLOG_BACKEND": "jira"},
+			want: WorklogBackendJira,
+		},
+		{
+			name:    "explicit tempo requires tempo settings",
+			env:     map[string]string{"JIRA_WORKLOG_BACKEND": "tempo"},
+			wantErr: "tempo_token",
+		},
+		{
+			name:    "unknown backend",
+			env:     map[string]string{"JIRA_WORKLOG_BACKEND": "harvest"},
+			wantErr: "worklog_backend must be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("JIRA_URL", "https://jira.example.com")
+			t.Setenv("JIRA_EMAIL", "user@example.com")
+			t.Setenv("JIRA_TOKEN", "jira-token")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := LoadConfig("", "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() unexpected error: %v", err)
+			}
+			if cfg.WorklogBackend != tt.want {
+				t.Errorf("WorklogBackend = %q, want %q", cfg.WorklogBackend, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigJiraFlavor(t *testing.T) {
+	tests := []struct {
+		name    string
+		flavor  string
+		email   string
+		want    string
+		wantErr string
+	}{
+		{name: "defaults to cloud", email: "user@example.com", want: FlavorCloud},
+		{name: "cloud requires email", flavor: "cloud", wantErr: "jira_email"},
+		{name: "server needs no email", flavor: "server", want: FlavorServer},
+		{name: "datacenter alias", flavor: "DataCenter", want: FlavorServer},
+		{name: "unknown flavor", flavor: "onprem", wantErr: "jira_flavor must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("JIRA_URL", "https://jira.example.com")
+			t.Setenv("JIRA_TOKEN", "jira-token")
+			t.Setenv("JIRA_EMAIL", tt.email)
+			t.Setenv("JIRA_FLAVOR", tt.flavor)
+
+			cfg, err := LoadConfig("", "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() unexpected error: %v", err)
+			}
+			if cfg.JiraFlavor != tt.want {
+				t.Errorf("JiraFlavor = %q, want %q", cfg.JiraFlavor, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigWorkflow(t *testing.T) {
+	path := writeConfig(t, `
+[profiles.default]
+jira_url   = "https://jira.example.com"
+jira_email = "user@example.com"
+jira_token = "jira-token"
+
+[profiles.default.workflow]
+status_order        = ["In Progress", "To Do"]
+in_transit_statuses = ["In Review"]
+
+[profiles.default.workflow.projects.OPS]
+in_transit_statuses = ["Waiting for Customer"]
+`)
+	clearEnv(t)
+
+	cfg, err := LoadConfig(path, "")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+
+	dev := cfg.Workflow.ForProject("DEV")
+	if !slices.Equal(dev.InTransitStatuses, []string{"In Review"}) {
+		t.Errorf("DEV InTransitStatuses = %v, want profile default", dev.InTransitStatuses)
+	}
+
+	ops := cfg.Workflow.ForProject("OPS")
+	if !slices.Equal(ops.InTransitStatuses, []string{"Waiting for Customer"}) {
+		t.Errorf("OPS InTransitStatuses = %v, want project override", ops.InTransitStatuses)
+	}
+	if !slices.Equal(ops.StatusOrder, []string{"In Progress", "To Do"}) {
+		t.Errorf("OPS StatusOrder = %v, want inherited profile value", ops.StatusOrder)
+	}
+}
+
+func TestLoadConfigCustomFields(t *testing.T) {
+	path := writeConfig(t, `
+[profiles.default]
+jira_url   = "https://jira.example.com"
+jira_email = "user@example.com"
+jira_token = "jira-token"
+
+[[profiles.default.custom_fields]]
+name   = "Story Points"
+column = true
+width  = 6
+
+[[profiles.default.custom_fields]]
+name   = "customfield_10042"
+detail = true
+`)
+	clearEnv(t)
+
+	cfg, err := LoadConfig(path, "")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	want := []CustomField{
+		{Name: "Story Points", Column: true, Width: 6},
+		{Name: "customfield_10042", Detail: true},
+	}
+	if !slices.Equal(cfg.CustomFields, want) {
+		t.Errorf("CustomFields = %+v, want %+v", cfg.CustomFields, want)
+	}
+
+	path = writeConfig(t, `
+[profiles.default]
+jira_url   = "https://jira.example.com"
+jira_email = "user@example.com"
+jira_token = "jira-token"
+
+[[profiles.default.custom_fields]]
+column = true
+`)
+	if _, err := LoadConfig(path, ""); err == nil || !strings.Contains(err.Error(), "custom_fields[0] is missing a name") {
+		t.Errorf("expected a missing-name error, got: %v", err)
+	}
+}
+
+func TestLoadConfigDownloadDir(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("HOME", "/home/me")
+	t.Setenv("JIRA_URL", "https://jira.example.com")
+	t.Setenv("JIRA_EMAIL", "user@example.com")
+	t.Setenv("JIRA_TOKEN", "jira-token")
+
+	missing := filepath.Join(t.TempDir(), "missing.toml")
+	cfg, err := LoadConfig(missing, "")
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+	if cfg.DownloadDir != "/home/me/Downloads" {
+		t.Errorf("default DownloadDir = %q", cfg.DownloadDir)
+	}
+
+	path := writeConfig(t, `
+[profiles.default]
+download_dir = "~/jira"
+`)
+	if cfg, err = LoadConfig(path, ""); err != nil || cfg.DownloadDir != "/home/me/jira" {
+		t.Errorf("DownloadDir = %q (err %v), want ~ expanded", cfg.DownloadDir, err)
+	}
+
+	t.Setenv("JIRA_TUI_DOWNLOAD_DIR", "/tmp/attachments")
+	if cfg, err = LoadConfig(path, ""); err != nil || cfg.DownloadDir != "/tmp/attachments" {
+		t.Errorf("env DownloadDir = %q (err %v)", cfg.DownloadDir, err)
+	}
+}
+
+func TestLoadConfigInlineImages(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("JIRA_URL", "https://jira.example.com")
+	t.Setenv("JIRA_EMAIL", "user@example.com")
+	t.Setenv("JIRA_TOKEN", "jira-token")
+
+	path := writeConfig(t, `
+[profiles.default]
+`)
+	cfg, err := LoadConfig(path, "")
+	if err != nil || cfg.InlineImages != InlineImagesAuto {
+		t.Errorf("default InlineImages = %q (err %v), want auto", cfg.InlineImages, err)
+	}
+
+	path = writeConfig(t, `
+[profiles.default]
+inline_images = "Sixel"
+`)
+	if cfg, err = LoadConfig(path, ""); err != nil || cfg.InlineImages != InlineImagesSixel {
+		t.Errorf("InlineImages = %q (err %v), want sixel", cfg.InlineImages, err)
+	}
+
+	t.Setenv("JIRA_TUI_INLINE_IMAGES", "iterm")
+	if _, err = LoadConfig(path, ""); err == nil || !strings.Contains(err.Error(), "inline_images") {
+		t.Errorf("expected an inline_images error, got: %v", err)
+	}
+}
+
+func TestLoadConfigRejectsUnknownKeys(t *testing.T) {
+	path := writeConfig(t, `
+[profiles.default]
+jira_ulr = "https://typo.example.com"
+`)
+	clearEnv(t)
+
+	_, err := LoadConfig(path, "")
+	if err == nil || !strings.Contains(err.Error(), "jira_ulr") {
+		t.Errorf("expected unknown-key error naming jira_ulr, got: %v", err)
+	}
+}
+
+func TestDefaultPathHonorsXDG(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	if got, want := DefaultPath(), "/tmp/xdg/jira-tui/config.toml"; got != want {
+		t.Errorf("DefaultPath() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Jira Software (agile) API: boards, sprints and backlog ranking. It lives
+// under /rest/agile/1.0 on both Cloud and Server/DC.
+
+// Board is a Jira Software board.
+type Board struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Type     string        `json:"type"` // "scrum", "kanban" or "simple"
+	Location BoardLocation `json:"location"`
+}
+
+type BoardLocation struct {
+	ProjectKey  string `json:"projectKey"`
+	ProjectName string `json:"projectName"`
+}
+
+// Sprint is a sprint on a scrum board. Future sprints usually have no dates.
+type Sprint struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	State         string    `json:"state"` // "active", "future" or "closed"
+	Goal          string    `json:"goal"`
+	StartDate     time.Time `json:"startDate"`
+	EndDate       time.Time `json:"endDate"`
+	OriginBoardID int       `json:"originBoardId"`
+}
+
+const (
+	SprintActive = "active"
+	SprintFuture = "future"
+	SprintClosed = "closed"
+)
+
+// Remaining is the time left until the sprint ends, or 0 once it's over or
+// when it has no end date.
+func (s Sprint) Remaining(now time.Time) time.Duration {
+	if s.EndDate.IsZero() || !now.Before(s.EndDate) {
+		return 0
+	}
+	return s.EndDate.Sub(now)
+}
+
+// agilePage is the offset-paged envelope of agile list endpoints.
+type agilePage[T any] struct {
+	StartAt    int  `json:"startAt"`
+	MaxResults int  `json:"maxResults"`
+	IsLast     bool `json:"isLast"`
+	Values     []T  `json:"values"`
+}
+
+// agilePath formats a path under the agile API root, e.g.
+// agilePath("/board/%d", id) is "/rest/agile/1.0/board/ID".
+func agilePath(format string, args ...any) string {
+	return "/rest/agile/1.0" + fmt.Sprintf(format, args...)
+}
+
+// getAgilePages follows an agile list endpoint to its last page. params must
+// not be nil; its paging parameters are overwritten.
+func getAgilePages[T any](ctx context.Context, c *Client, endpoint string, params url.Values) ([]T, error) {
+	var result []T
+	startAt := 0
+	for {
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", "50")
+
+		var page agilePage[T]
+		if err := c.doJiraRequest(ctx, "GET", endpoint, params, nil, &page); err != nil {
+			return nil, err
+		}
+		result = append(result, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			return result, nil
+		}
+	}
+}
+
+// GetBoards lists the boards of a project (by key or id), or every board the
+// user can see when projectKeyOrID is empty.
+func (c *Client) GetBoards(ctx context.Context, projectKeyOrID string) ([]Board, error) {
+	params := url.Values{}
+	if projectKeyOrID != "" {
+		params.Set("projectKeyOrId", projectKeyOrID)
+	}
+	return getAgilePages[Board](ctx, c, agilePath("/board"), params)
+}
+
+// GetSprints lists a board's sprints in the given states (all when none are
+// given). Kanban boards have no sprints and answer 400.
+func (c *Client) GetSprints(ctx context.Context, boardID int, states ...string) ([]Sprint, error) {
+	params := url.Values{}
+	if len(states) > 0 {
+		params.Set("state", strings.Join(states, ","))
+	}
+	return getAgilePages[Sprint](ctx, c, agilePath("/board/%d/sprint", boardID), params)
+}
+
+// MoveIssuesToSprint moves issues into an active or future sprint.
+func (c *Client) MoveIssuesToSprint(ctx context.Context, sprintID int, issueKeys ...string) error {
+	body := map[string]any{
+		"issues": issueKeys,
+	}
+	return c.doJiraRequest(ctx, "POST", agilePath("/sprint/%d/issue", sprintID), nil, body, nil, 204)
+}
+
+// RankIssues moves issues right before beforeKey or, when beforeKey is empty,
+// right after afterKey in the global rank.
+func (c *Client) RankIssues(ctx context.Context, issueKeys []string, beforeKey, afterKey string) error {
+	body := map[string]any{
+		"issues": issueKeys,
+	}
+	switch {
+	case beforeKey != "":
+		body["rankBeforeIssue"] = beforeKey
+	case afterKey != "":
+		body["rankAfterIssue"] = afterKey
+	default:
+		return fmt.Errorf("rank: no issue to rank against")
+	}
+	// A 207 means some issues failed to rank; treat it as an error.
+	return c.doJiraRequest(ctx, "PUT", agilePath("/issue/rank"), nil, body, nil, 204)
+}
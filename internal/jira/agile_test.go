@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetSprintsPagesAndFilters(t *testing.T) {
+	var calls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/agile/1.0/board/7/sprint", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != "active,future" {
+			t.Errorf("state = %q, want active,future", got)
+		}
+		if calls == 0 {
+			_, _ = w.Write([]byte(`{"startAt":0,"isLast":false,"values":[
+				{"id":1,"name":"Sprint 1","state":"active","startDate":"2026-10-05T09:00:00.000Z","endDate":"2026-10-19T09:00:00.000Z","originBoardId":7}]}`))
+		} else {
+			if got := r.URL.Query().Get("startAt"); got != "1" {
+				t.Errorf("second page startAt = %q, want 1", got)
+			}
+			_, _ = w.Write([]byte(`{"startAt":1,"isLast":true,"values":[{"id":2,"name":"Sprint 2","state":"future"}]}`))
+		}
+		calls++
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	sprints, err := c.GetSprints(context.Background(), 7, SprintActive, SprintFuture)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sprints) != 2 || calls != 2 {
+		t.Fatalf("got %d sprints in %d calls, want 2 in 2", len(sprints), calls)
+	}
+	active := sprints[0]
+	if active.Name != "Sprint 1" || active.State != SprintActive || active.OriginBoardID != 7 {
+		t.Errorf("unexpected sprint: %+v", active)
+	}
+	if want := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC); !active.EndDate.Equal(want) {
+		t.Errorf("EndDate = %v, want %v", active.EndDate, want)
+	}
+	if !sprints[1].StartDate.IsZero() {
+		t.Errorf("future sprint without dates should have a zero StartDate")
+	}
+}
+
+func TestSprintRemaining(t *testing.T) {
+	end := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	s := Sprint{EndDate: end}
+	if got := s.Remaining(end.Add(-26 * time.Hour)); got != 26*time.Hour {
+		t.Errorf("Remaining = %v, want 26h", got)
+	}
+	if got := s.Remaining(end.Add(time.Hour)); got != 0 {
+		t.Errorf("overdue sprint: Remaining = %v, want 0", got)
+	}
+	if got := (Sprint{}).Remaining(end); got != 0 {
+		t.Errorf("no end date: Remaining = %v, want 0", got)
+	}
+}
+
+func TestMoveIssuesToSprintAndRank(t *testing.T) {
+	var moved, ranked map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/agile/1.0/sprint/42/issue", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&moved)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/rest/agile/1.0/issue/rank", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&ranked)
+		if ranked["rankBeforeIssue"] == "DEV-9" {
+			w.WriteHeader(http.StatusMultiStatus)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+	ctx := context.Background()
+
+	if err := c.MoveIssuesToSprint(ctx, 42, "DEV-1", "DEV-2"); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if issues, _ := moved["issues"].([]any); len(issues) != 2 {
+		t.Errorf("moved body = %v", moved)
+	}
+
+	if err := c.RankIssues(ctx, []string{"DEV-1"}, "", "DEV-3"); err != nil {
+		t.Fatalf("rank: %v", err)
+	}
+	if ranked["rankAfterIssue"] != "DEV-3" || ranked["rankBeforeIssue"] != nil {
+		t.Errorf("rank body = %v", ranked)
+	}
+
+	var apiErr *APIError
+	if err := c.RankIssues(ctx, []string{"DEV-1"}, "DEV-9", ""); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusMultiStatus {
+		t.Errorf("partial rank failure should be an APIError, got %v", err)
+	}
+	if err := c.RankIssues(ctx, []string{"DEV-1"}, "", ""); err == nil {
+		t.Error("ranking against nothing should fail")
+	}
+}
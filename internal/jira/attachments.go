@@ -0,0 +1,162 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// Attachment is a file attached to an issue.
+type Attachment struct {
+	ID       string
+	Filename string
+	Size     int64
+	MimeType string
+	Author   string
+	Created  string
+	// Content is the download URL Jira reports for the file.
+	Content string
+	// Thumbnail is the URL of Jira's preview of an image, empty for other
+	// files.
+	Thumbnail string
+}
+
+// IsImage reports whether a is a picture Jira can preview.
+func (a Attachment) IsImage() bool {
+	return strings.HasPrefix(a.MimeType, "image/")
+}
+
+type jiraAttachment struct {
+	ID        string    `json:"id"`
+	Filename  string    `json:"filename"`
+	Size      int64     `json:"size"`
+	MimeType  string    `json:"mimeType"`
+	Author    UserField `json:"author"`
+	Created   string    `json:"created"`
+	Content   string    `json:"content"`
+	Thumbnail string    `json:"thumbnail"`
+}
+
+func toAttachments(in []jiraAttachment) []Attachment {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Attachment, len(in))
+	for i, a := range in {
+		out[i] = Attachment{
+			ID:        a.ID,
+			Filename:  a.Filename,
+			Size:      a.Size,
+			MimeType:  a.MimeType,
+			Author:    a.Author.DisplayName,
+			Created:   a.Created,
+			Content:   a.Content,
+			Thumbnail: a.Thumbnail,
+		}
+	}
+	return out
+}
+
+// attachmentEndpoint is where a's bytes are downloaded from: the content URL
+// Jira reported when it's on the configured site (Server/DC serves files
+// outside the REST API), the REST content endpoint otherwise.
+func (c *Client) attachmentEndpoint(a Attachment) string {
+	return c.siteEndpoint(a.Content, c.apiPath("/attachment/content/%s", a.ID))
+}
+
+// thumbnailEndpoint is attachmentEndpoint for a's preview image.
+func (c *Client) thumbnailEndpoint(a Attachment) string {
+	return c.siteEndpoint(a.Thumbnail, c.apiPath("/attachment/thumbnail/%s", a.ID))
+}
+
+// siteEndpoint is the path of rawURL when it's on the configured site, or
+// fallback.
+func (c *Client) siteEndpoint(rawURL, fallback string) string {
+	if rest, ok := strings.CutPrefix(rawURL, strings.TrimRight(c.jira.baseURL, "/")); ok && strings.HasPrefix(rest, "/") {
+		return rest
+	}
+	return fallback
+}
+
+// DownloadAttachment streams a's content into w and returns the bytes written.
+func (c *Client) DownloadAttachment(ctx context.Context, a Attachment, w io.Writer) (int64, error) {
+	return c.download(ctx, c.attachmentEndpoint(a), a.Filename, w)
+}
+
+// DownloadThumbnail streams Jira's preview of the image a into w and returns
+// the bytes written.
+func (c *Client) DownloadThumbnail(ctx context.Context, a Attachment, w io.Writer) (int64, error) {
+	return c.download(ctx, c.thumbnailEndpoint(a), a.Filename, w)
+}
+
+func (c *Client) download(ctx context.Context, endpoint, filename string, w io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.jira.baseURL+endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "*/*")
+
+	resp, err := c.send(c.jira, req, endpoint, http.StatusOK)
+	if err != nil {
+		return 0, err
+	}
+	defer closeBody(resp)
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download %s: %w", filename, err)
+	}
+	return n, nil
+}
+
+// UploadAttachment attaches the contents of r to issueKey as filename and
+// returns the attachment Jira created.
+func (c *Client) UploadAttachment(ctx context.Context, issueKey, filename string, r io.Reader) ([]Attachment, error) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipartFile(mw, filename, r))
+	}()
+
+	endpoint := c.apiPath("/issue/%s/attachments", issueKey)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.jira.baseURL+endpoint, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	// Jira rejects multipart requests without this as a CSRF guard.
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	resp, err := c.send(c.jira, req, endpoint, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	var created []jiraAttachment
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return toAttachments(created), nil
+}
+
+// writeMultipartFile encodes r as the form's "file" field and closes the form.
+func writeMultipartFile(mw *multipart.Writer, filename string, r io.Reader) error {
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	if err := mw.Close(); err != nil {
+		return fmt.Errorf("failed to encode upload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestGetIssueDetailAttachments(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Query().Get("fields"), "attachment") {
+			t.Errorf("fields = %q, want attachment requested", r.URL.Query().Get("fields"))
+		}
+		_, _ = w.Write([]byte(`{"key":"DEV-1","id":"1","fields":{"summary":"One","status":{"name":"To Do"},
+			"attachment":[{"id":"10","filename":"log.txt","size":2048,"mimeType":"text/plain",
+				"author":{"accountId":"a1","displayName":"Jane Doe"},"created":"2026-10-01T10:00:00.000+0000",
+				"content":"https://example.atlassian.net/rest/api/3/attachment/content/10"}]}}`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	issue, err := c.GetIssueDetail(context.Background(), "DEV-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issue.Attachments) != 1 {
+		t.Fatalf("attachments = %+v", issue.Attachments)
+	}
+	a := issue.Attachments[0]
+	if a.ID != "10" || a.Filename != "log.txt" || a.Size != 2048 || a.Author != "Jane Doe" {
+		t.Errorf("attachment = %+v", a)
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/secure/attachment/10/log.txt", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Error("download should be authenticated")
+		}
+		_, _ = w.Write([]byte("hello"))
+	})
+	mux.HandleFunc("/rest/api/3/attachment/content/11", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("from the api"))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	// A content URL on the site is used as is.
+	var buf bytes.Buffer
+	n, err := c.DownloadAttachment(context.Background(), Attachment{ID: "10", Filename: "log.txt", Content: srv.URL + "/secure/attachment/10/log.txt"}, &buf)
+	if err != nil || n != 5 || buf.String() != "hello" {
+		t.Errorf("download = %q (%d bytes), err %v", buf.String(), n, err)
+	}
+
+	// Elsewhere (or missing), the REST content endpoint stands in.
+	buf.Reset()
+	if _, err := c.DownloadAttachment(context.Background(), Attachment{ID: "11", Content: "https://elsewhere.example.com/x"}, &buf); err != nil || buf.String() != "from the api" {
+		t.Errorf("download = %q, err %v", buf.String(), err)
+	}
+
+	_, err = c.DownloadAttachment(context.Background(), Attachment{ID: "12"}, io.Discard)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a 404 APIError, got %v", err)
+	}
+}
+
+func TestDownloadThumbnail(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/secure/thumbnail/10/_thumb_10.png", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("site"))
+	})
+	mux.HandleFunc("/rest/api/3/attachment/thumbnail/11", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("api"))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	a := Attachment{ID: "10", Filename: "shot.png", MimeType: "image/png", Thumbnail: srv.URL + "/secure/thumbnail/10/_thumb_10.png"}
+	if _, err := c.DownloadThumbnail(context.Background(), a, &buf); err != nil || buf.String() != "site" {
+		t.Errorf("thumbnail = %q, err %v", buf.String(), err)
+	}
+	if !a.IsImage() || (Attachment{MimeType: "text/plain"}).IsImage() {
+		t.Error("IsImage should follow the MIME type")
+	}
+
+	buf.Reset()
+	if _, err := c.DownloadThumbnail(context.Background(), Attachment{ID: "11"}, &buf); err != nil || buf.String() != "api" {
+		t.Errorf("thumbnail = %q, err %v", buf.String(), err)
+	}
+}
+
+func TestUploadAttachment(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1/attachments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.Header.Get("X-Atlassian-Token") != "no-check" {
+			t.Error("missing X-Atlassian-Token header")
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("reading multipart file: %v", err)
+		}
+		content, _ := io.ReadAll(file)
+		if header.Filename != "notes.md" || string(content) != "# notes" {
+			t.Errorf("uploaded %q with %q", header.Filename, content)
+		}
+		_, _ = w.Write([]byte(`[{"id":"20","filename":"notes.md","size":7,"author":{"displayName":"Me"},"created":"2026-10-17T09:00:00.000+0000"}]`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	got, err := c.UploadAttachment(context.Background(), "DEV-1", "notes.md", strings.NewReader("# notes"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "20" || got[0].Author != "Me" {
+		t.Errorf("attachments = %+v", got)
+	}
+}
+
+func TestUploadAttachmentReadError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1/attachments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	r := io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(errors.New("disk gone")))
+	if _, err := c.UploadAttachment(context.Background(), "DEV-1", "notes.md", r); err == nil || !strings.Contains(err.Error(), "disk gone") {
+		t.Errorf("err = %v, want the read failure", err)
+	}
+}
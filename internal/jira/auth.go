@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Authenticator adds credentials to an outgoing request. The Jira and Tempo
+// APIs each get their own, chosen with WithJiraAuth / WithTempoAuth.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// BasicAuth authenticates with an Atlassian account email and API token, the
+// scheme Jira Cloud uses for personal scripts and tools.
+type BasicAuth struct {
+	Email string
+	Token string
+}
+
+func (a BasicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Email, a.Token)
+	return nil
+}
+
+// BearerToken sends a static token as "Authorization: Bearer <token>". Tempo
+// API tokens and Jira Server/Data Center personal access tokens both use it.
+type BearerToken struct {
+	Token string
+}
+
+func (a BearerToken) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// PersonalAccessToken authenticates against Jira Server/Data Center with a
+// personal access token.
+func PersonalAccessToken(token string) Authenticator {
+	return BearerToken{Token: token}
+}
+
+// TokenSource returns a currently valid OAuth 2.0 access token, refreshing it
+// as needed. It is called once per request, before the retry transport, so
+// retries of a GET reuse the token: it should stay valid for a few minutes
+// after it's handed out.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// OAuth2 authenticates with access tokens from Source (e.g. an Atlassian
+// OAuth 2.0 (3LO) app).
+type OAuth2 struct {
+	Source TokenSource
+}
+
+func (a OAuth2) Authenticate(req *http.Request) error {
+	if a.Source == nil {
+		return errors.New("oauth2: no token source")
+	}
+	token, err := a.Source.Token(req.Context())
+	if err != nil {
+		return fmt.Errorf("oauth2: fetching token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// WithJiraAuth replaces the email/API-token basic auth NewClient sets up for
+// Jira requests.
+func WithJiraAuth(a Authenticator) ClientOption {
+	return func(c *Client) {
+		c.jira.auth = a
+	}
+}
+
+// WithTempoAuth replaces the bearer-token auth NewClient sets up for Tempo
+// requests.
+func WithTempoAuth(a Authenticator) ClientOption {
+	return func(c *Client) {
+		c.tempo.auth = a
+	}
+}
@@ -0,0 +1,116 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// HistoryEntry is one change to an issue: the fields one author changed at
+// one time.
+type HistoryEntry struct {
+	ID      string
+	Author  string
+	Created string
+	Items   []HistoryItem
+}
+
+// HistoryItem is a single field's change, as display strings.
+type HistoryItem struct {
+	Field string
+	From  string
+	To    string
+}
+
+type jiraChangelog struct {
+	StartAt   int           `json:"startAt"`
+	Total     int           `json:"total"`
+	Histories []jiraHistory `json:"histories"`
+}
+
+type jiraHistory struct {
+	ID      string    `json:"id"`
+	Author  UserField `json:"author"`
+	Created string    `json:"created"`
+	Items   []struct {
+		Field      string `json:"field"`
+		FromString string `json:"fromString"`
+		ToString   string `json:"toString"`
+	} `json:"items"`
+}
+
+func toHistory(in []jiraHistory) []HistoryEntry {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]HistoryEntry, len(in))
+	for i, h := range in {
+		e := HistoryEntry{ID: h.ID, Author: h.Author.DisplayName, Created: h.Created}
+		for _, it := range h.Items {
+			e.Items = append(e.Items, HistoryItem{Field: it.Field, From: it.FromString, To: it.ToString})
+		}
+		out[i] = e
+	}
+	return out
+}
+
+// MergeHistory combines changelog pages into one history, oldest first. The
+// pages overlap and come in different orders (Cloud embeds the most recent
+// changes newest first, /changelog pages oldest first), so entries are kept
+// once by ID and sorted by when they were made.
+func MergeHistory(pages ...[]HistoryEntry) []HistoryEntry {
+	var out []HistoryEntry
+	seen := make(map[string]bool)
+	for _, page := range pages {
+		for _, e := range page {
+			if seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			out = append(out, e)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b HistoryEntry) int {
+		return historyTime(a).Compare(historyTime(b))
+	})
+	return out
+}
+
+// historyTime is when e was made, or the zero time when Jira's timestamp
+// doesn't parse.
+func historyTime(e HistoryEntry) time.Time {
+	t, _ := time.Parse(jiraTimeLayout, e.Created)
+	return t
+}
+
+// GetChangelog pages through /changelog, oldest first, from startAt on. The
+// changelog GetIssueDetail embeds (expand=changelog) holds only the most
+// recent changes on Cloud; see Issue.HistoryTotal. Merge the pages with
+// MergeHistory. Server/DC embeds the whole changelog and has no such
+// endpoint.
+func (c *Client) GetChangelog(ctx context.Context, issueKey string, startAt int) ([]HistoryEntry, error) {
+	if c.flavor == FlavorServer {
+		return nil, nil
+	}
+
+	var entries []HistoryEntry
+	for page := 0; page < 50; page++ { // safety cap
+		var resp struct {
+			Values []jiraHistory `json:"values"`
+			IsLast bool          `json:"isLast"`
+		}
+		query := url.Values{"startAt": {strconv.Itoa(startAt)}, "maxResults": {"100"}}
+		if err := c.doJiraRequest(ctx, "GET", c.apiPath("/issue/%s/changelog", issueKey), query, nil, &resp, http.StatusOK); err != nil {
+			return entries, err
+		}
+		entries = append(entries, toHistory(resp.Values)...)
+		if resp.IsLast || len(resp.Values) == 0 {
+			break
+		}
+		startAt += len(resp.Values)
+	}
+	return entries, nil
+}
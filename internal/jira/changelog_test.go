@@ -0,0 +1,98 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestChangelogPagedOnDemand(t *testing.T) {
+	var pages int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("expand") != "changelog" {
+			t.Errorf("expand = %q, want changelog", r.URL.Query().Get("expand"))
+		}
+		// The embedded page is the most recent changes, newest first.
+		_, _ = w.Write([]byte(`{"key":"DEV-1","id":"1","fields":{"summary":"One","status":{"name":"To Do"}},
+			"changelog":{"startAt":0,"maxResults":2,"total":3,"histories":[
+				{"id":"102","author":{"displayName":"Jane"},"created":"2026-10-03T10:00:00.000+0000",
+				 "items":[{"field":"status","fromString":"In Progress","toString":"To Do"},
+				          {"field":"resolution","fromString":"Done","toString":null}]},
+				{"id":"101","author":{"displayName":"Ana"},"created":"2026-10-02T12:00:00.000+0200",
+				 "items":[{"field":"assignee","fromString":null,"toString":"Ana"}]}]}}`))
+	})
+	mux.HandleFunc("/rest/api/3/issue/DEV-1/changelog", func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		// /changelog pages oldest first, overlapping the embedded page.
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			_, _ = w.Write([]byte(`{"startAt":0,"isLast":false,"values":[
+				{"id":"100","author":{"displayName":"Jane"},"created":"2026-10-01T10:00:00.000+0000",
+				 "items":[{"field":"status","fromString":"To Do","toString":"In Progress"}]},
+				{"id":"101","author":{"displayName":"Ana"},"created":"2026-10-02T12:00:00.000+0200",
+				 "items":[{"field":"assignee","fromString":null,"toString":"Ana"}]}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"startAt":2,"isLast":true,"values":[
+				{"id":"102","author":{"displayName":"Jane"},"created":"2026-10-03T10:00:00.000+0000",
+				 "items":[{"field":"status","fromString":"In Progress","toString":"To Do"},
+				          {"field":"resolution","fromString":"Done","toString":null}]}]}`))
+		default:
+			t.Errorf("unexpected startAt %q", r.URL.Query().Get("startAt"))
+		}
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	issue, err := c.GetIssueDetail(context.Background(), "DEV-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := historyIDs(issue.History); got != "101,102" || issue.HistoryTotal != 3 || pages != 0 {
+		t.Fatalf("history = %s of %d after %d page(s), want the embedded page oldest first", got, issue.HistoryTotal, pages)
+	}
+
+	rest, err := c.GetChangelog(context.Background(), "DEV-1", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	history := MergeHistory(issue.History, rest)
+	if got := historyIDs(history); got != "100,101,102" {
+		t.Fatalf("merged history = %s, want each entry once, oldest first", got)
+	}
+	last := history[2]
+	if last.Author != "Jane" || len(last.Items) != 2 || last.Items[0].To != "To Do" || last.Items[1].To != "" {
+		t.Errorf("last entry = %+v", last)
+	}
+	if history[1].Items[0].From != "" {
+		t.Errorf("a null fromString should be empty, got %q", history[1].Items[0].From)
+	}
+}
+
+func historyIDs(entries []HistoryEntry) string {
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestServerFlavorChangelogIsEmbedded(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/issue/OPS-1", func(w http.ResponseWriter, r *http.Request) {
+		// Totals beyond the embedded entries must not trigger /changelog.
+		_, _ = w.Write([]byte(`{"key":"OPS-1","id":"1","fields":{"summary":"One","status":{"name":"Open"}},
+			"changelog":{"startAt":0,"total":2,"histories":[
+				{"id":"1","author":{"displayName":"Jane"},"created":"2026-10-01T10:00:00.000+0000","items":[]}]}}`))
+	})
+
+	c, srv := newServerTestClient(mux)
+	defer srv.Close()
+
+	issue, err := c.GetIssueDetail(context.Background(), "OPS-1")
+	if err != nil || len(issue.History) != 1 || issue.HistoryTotal != 1 {
+		t.Errorf("history = %+v of %d, err %v", issue.History, issue.HistoryTotal, err)
+	}
+}
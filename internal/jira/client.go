@@ -12,17 +12,41 @@ import (
 	"net/url"
 	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 type Client struct {
-	Client     *http.Client
-	jiraURL    string
-	jiraToken  string
-	tempoURL   string
-	tempoToken string
-	jiraEmail  string
-}
+	Client *http.Client
+	flavor Flavor
+	jira   service
+	tempo  service
+	// worklogs is where worklogs are read and written: Tempo when it's
+	// configured, Jira's native worklog API otherwise.
+	worklogs WorklogBackend
+	// retry is the outermost layer of Client's transport; it retries
+	// transient failures for both Jira and Tempo requests.
+	retry *retryTransport
+	// middleware and transport are assembled under retry by NewClient.
+	middleware []Middleware
+	transport  http.RoundTripper
+	// extraFields are requested on top of the built-in field lists (see
+	// SetExtraFields); mu guards them since requests run concurrently.
+	mu          sync.Mutex
+	extraFields []string
+}
+
+// service is one API the client talks to: where it lives and how to
+// authenticate against it.
+type service struct {
+	name    string
+	baseURL string
+	auth    Authenticator
+}
+
+// ClientOption customizes a Client built by NewClient.
+type ClientOption func(*Client)
 
 // APIError is returned when a Jira or Tempo request completes with an
 // unexpected HTTP status. It carries the full detail (including the raw
@@ -59,6 +83,25 @@ type Issue struct {
 	DueDate          string
 	SubTasks         []Issue
 	Worklogs         []Worklog
+	Labels           []string
+	Components       []string
+	FixVersions      []string
+	Attachments      []Attachment
+	// Watchers are who watch the issue and Watching whether the current user
+	// is one of them; Votes and Voted likewise. Only GetIssueDetail fills
+	// them.
+	Watchers []User
+	Watching bool
+	Votes    int
+	Voted    bool
+	// History is the issue's changelog, oldest first, and HistoryTotal how
+	// many entries it has. Only GetIssueDetail fills them, with the embedded
+	// page of History; GetChangelog fetches the rest.
+	History      []HistoryEntry
+	HistoryTotal int
+	// CustomFields holds the raw values of the custom fields requested with
+	// SetExtraFields, by field ID. Render them with FormatFieldValue.
+	CustomFields map[string]json.RawMessage `json:",omitempty"`
 }
 
 type IssueType struct {
@@ -125,22 +168,52 @@ type Parent struct {
 	Type string `json:"type"`
 }
 
-func NewClient(jiraBaseURL, email, jiraToken, tempoBaseURL, tempoToken string) (*Client, error) {
-	return &Client{
-		Client:     &http.Client{},
-		jiraURL:    jiraBaseURL,
-		jiraToken:  jiraToken,
-		tempoURL:   tempoBaseURL,
-		tempoToken: tempoToken,
-		jiraEmail:  email,
-	}, nil
+func NewClient(jiraBaseURL, email, jiraToken, tempoBaseURL, tempoToken string, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		flavor: FlavorCloud,
+		jira:   service{name: "jira", baseURL: jiraBaseURL},
+		tempo:  service{name: "tempo", baseURL: tempoBaseURL, auth: BearerToken{Token: tempoToken}},
+		retry:  newRetryTransport(nil),
+	}
+
+	if tempoBaseURL != "" && tempoToken != "" {
+		c.worklogs = tempoWorklogs{c}
+	} else {
+		c.worklogs = jiraWorklogs{c}
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// Default Jira auth depends on the flavor, so it's only picked once the
+	// options have run (WithJiraAuth still wins).
+	if c.jira.auth == nil {
+		if c.flavor == FlavorServer {
+			c.jira.auth = PersonalAccessToken(jiraToken)
+		} else {
+			c.jira.auth = BasicAuth{Email: email, Token: jiraToken}
+		}
+	}
+
+	c.Client = &http.Client{Transport: c.buildTransport()}
+
+	return c, nil
+}
+
+// BrowseURL returns the web link for issueKey on the configured Jira site.
+func (c *Client) BrowseURL(issueKey string) string {
+	return strings.TrimRight(c.jira.baseURL, "/") + "/browse/" + url.PathEscape(issueKey)
 }
 
 // Response structs for the v3 API
 type issuesSearchResponse struct {
 	Issues        []jiraIssue `json:"issues"`
 	NextPageToken string      `json:"nextPageToken"`
-	IsLast        bool        `json:"isLast"`
+	// StartAt and Total page the Server/DC /search endpoint.
+	StartAt int  `json:"startAt"`
+	Total   int  `json:"total"`
+	IsLast  bool `json:"isLast"`
 }
 
 type projectsSearchResponse struct {
@@ -148,14 +221,11 @@ type projectsSearchResponse struct {
 	IsLast   bool          `json:"isLast"`
 }
 
-type worklogsResponse struct {
-	Results []Worklog `json:"results"`
-}
-
 type jiraIssue struct {
-	Key    string      `json:"key"`
-	ID     string      `json:"id"`
-	Fields issueFields `json:"fields"`
+	Key       string         `json:"key"`
+	ID        string         `json:"id"`
+	Fields    issueFields    `json:"fields"`
+	Changelog *jiraChangelog `json:"changelog"`
 }
 
 type jiraProject struct {
@@ -165,21 +235,29 @@ type jiraProject struct {
 }
 
 type issueFields struct {
-	Summary          string         `json:"summary"`
-	Project          Project        `json:"project"`
-	Description      *ContentDoc    `json:"description"`
-	Status           statusField    `json:"status"`
-	Type             typeField      `json:"issuetype"`
-	Assignee         *UserField     `json:"assignee"`
-	Reporter         *UserField     `json:"reporter"`
-	Comment          *commentList   `json:"comment"`
-	Priority         *priorityField `json:"priority"`
-	Parent           *parentField   `json:"parent"`
-	IssueLinks       []IssueLink    `json:"issueLinks"`
-	OriginalEstimate *int           `json:"timeoriginalestimate"`
-	DueDate          string         `json:"duedate"`
-	Created          string         `json:"created"`
-	Updated          string         `json:"updated"`
+	Summary          string           `json:"summary"`
+	Project          Project          `json:"project"`
+	Description      *ContentDoc      `json:"description"`
+	Status           statusField      `json:"status"`
+	Type             typeField        `json:"issuetype"`
+	Assignee         *UserField       `json:"assignee"`
+	Reporter         *UserField       `json:"reporter"`
+	Comment          *commentList     `json:"comment"`
+	Priority         *priorityField   `json:"priority"`
+	Parent           *parentField     `json:"parent"`
+	IssueLinks       []IssueLink      `json:"issueLinks"`
+	OriginalEstimate *int             `json:"timeoriginalestimate"`
+	DueDate          string           `json:"duedate"`
+	Created          string           `json:"created"`
+	Updated          string           `json:"updated"`
+	Labels           []string         `json:"labels"`
+	Components       []namedValue     `json:"components"`
+	FixVersions      []namedValue     `json:"fixVersions"`
+	Attachment       []jiraAttachment `json:"attachment"`
+	Watches          *watchesField    `json:"watches"`
+	Votes            *votesField      `json:"votes"`
+	// Custom is filled by UnmarshalJSON with the non-null customfield_* values.
+	Custom map[string]json.RawMessage `json:"-"`
 }
 
 type IssueLink struct {
@@ -234,25 +312,58 @@ type ContentNode struct {
 	Type    string        `json:"type"`
 	Text    string        `json:"text,omitempty"`
 	Content []ContentNode `json:"content,omitempty"`
-	Attrs   *contentAttrs `json:"attrs,omitempty"`
-	Marks   []mark        `json:"marks,omitempty"`
+	Attrs   *NodeAttrs    `json:"attrs,omitempty"`
+	Marks   []Mark        `json:"marks,omitempty"`
 }
 
-type mark struct {
+// Mark is inline formatting on a text node: strong, em, code, link, strike,
+// underline, textColor...
+type Mark struct {
 	Type  string     `json:"type"`
-	Attrs *markAttrs `json:"attrs,omitempty"`
-}
-
-type markAttrs struct {
-	Href string `json:"href,omitempty"`
-}
-
-type contentAttrs struct {
-	Text  string `json:"text,omitempty"`
-	ID    string `json:"id,omitempty"`
-	Alt   string `json:"alt,omitempty"`
-	URL   string `json:"url,omitempty"`
-	Level int    `json:"level,omitempty"`
+	Attrs *MarkAttrs `json:"attrs,omitempty"`
+}
+
+type MarkAttrs struct {
+	Href  string `json:"href,omitempty"`
+	Title string `json:"title,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// NodeAttrs holds the attributes of every node type this client reads; each
+// type uses a few of them.
+type NodeAttrs struct {
+	Text     string `json:"text,omitempty"`
+	Language string `json:"language,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Alt      string `json:"alt,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Level    int    `json:"level,omitempty"`
+	// PanelType is info, note, tip, warning, error or success.
+	PanelType string `json:"panelType,omitempty"`
+	// Color is a status lozenge's color: neutral, purple, blue, red, yellow
+	// or green.
+	Color string `json:"color,omitempty"`
+	// Timestamp is a date node's value, milliseconds since the epoch.
+	Timestamp string `json:"timestamp,omitempty"`
+	// ShortName is an emoji's shortcode, ":smile:".
+	ShortName string `json:"shortName,omitempty"`
+	// State is TODO or DONE on task items, DECIDED on decision items.
+	State string `json:"state,omitempty"`
+	// Title is an expand block's heading.
+	Title string `json:"title,omitempty"`
+	// LocalID identifies task lists and items within the document.
+	LocalID string `json:"localId,omitempty"`
+	// Order is the number an ordered list starts at.
+	Order int `json:"order,omitempty"`
+	// MediaType is a media node's kind: file, link or external (by URL).
+	MediaType  string `json:"type,omitempty"`
+	Collection string `json:"collection,omitempty"`
+	// Width and Height are a media node's pixel size; on mediaSingle Width
+	// is the percentage of the page it takes.
+	Width  float64 `json:"width,omitempty"`
+	Height float64 `json:"height,omitempty"`
+	// Layout is how mediaSingle sits on the page: center, wide, full-width...
+	Layout string `json:"layout,omitempty"`
 }
 
 type statusField struct {
@@ -317,7 +428,19 @@ type StatusCategory struct {
 }
 
 func (c *Client) doJiraRequest(ctx context.Context, method, endpoint string, queryParams url.Values, body any, result any, expectedStatus ...int) error {
-	apiURL := fmt.Sprintf("%s%s", c.jiraURL, endpoint)
+	return c.do(ctx, c.jira, method, endpoint, queryParams, body, result, expectedStatus...)
+}
+
+func (c *Client) doTempoRequest(ctx context.Context, method, endpoint string, queryParams url.Values, body any, result any, expectedStatus ...int) error {
+	return c.do(ctx, c.tempo, method, endpoint, queryParams, body, result, expectedStatus...)
+}
+
+// do is the single request pipeline behind every Jira and Tempo call: build
+// the URL, encode body as JSON, authenticate, send through the client's
+// transport (retries and middlewares), then check the status and decode.
+// With no expectedStatus, any of 200, 201 and 204 is accepted.
+func (c *Client) do(ctx context.Context, svc service, method, endpoint string, queryParams url.Values, body any, result any, expectedStatus ...int) error {
+	apiURL := svc.baseURL + endpoint
 	if len(queryParams) > 0 {
 		apiURL = fmt.Sprintf("%s?%s", apiURL, queryParams.Encode())
 	}
@@ -336,44 +459,24 @@ func (c *Client) doJiraRequest(ctx context.Context, method, endpoint string, que
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.SetBasicAuth(c.jiraEmail, c.jiraToken)
 	req.Header.Set("Accept", "application/json")
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.send(svc, req, endpoint, expectedStatus...)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			slog.Warn("failed to close response body", "err", closeErr)
-		}
-	}()
+	defer closeBody(resp)
 
-	if len(expectedStatus) == 0 {
-		expectedStatus = []int{http.StatusOK, http.StatusCreated, http.StatusNoContent}
-	}
-
-	statusOK := slices.Contains(expectedStatus, resp.StatusCode)
-
-	if !statusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return &APIError{
-			Method:     method,
-			Endpoint:   endpoint,
-			StatusCode: resp.StatusCode,
-			Body:       string(bodyBytes),
-		}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if result != nil {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-
-		resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
 			return fmt.Errorf("failed to decode response: %w", err)
 		}
 	}
@@ -381,67 +484,47 @@ func (c *Client) doJiraRequest(ctx context.Context, method, endpoint string, que
 	return nil
 }
 
-func (c *Client) doTempoRequest(ctx context.Context, method, endpoint string, queryParams url.Values, body any, result any, expectedStatus ...int) error {
-	apiURL := fmt.Sprintf("%s%s", c.tempoURL, endpoint)
-
-	if len(queryParams) > 0 {
-		apiURL = fmt.Sprintf("%s?%s", apiURL, queryParams.Encode())
-	}
-
-	var bodyReader io.Reader
-	if body != nil {
-		bodyBytes, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request: %w", err)
+// send authenticates req for svc and sends it through the client's transport.
+// A response whose status isn't in expectedStatus (200, 201 or 204 when
+// empty) is read and closed and returned as an *APIError; otherwise the
+// caller owns the body. do uses it for JSON; attachments stream through it.
+func (c *Client) send(svc service, req *http.Request, endpoint string, expectedStatus ...int) (*http.Response, error) {
+	if svc.auth != nil {
+		if err := svc.auth.Authenticate(req); err != nil {
+			return nil, fmt.Errorf("%s auth: %w", svc.name, err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, apiURL, bodyReader)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Tempo uses Bearer token, not BasicAuth
-	req.Header.Set("Authorization", "Bearer "+c.tempoToken)
-	req.Header.Set("Accept", "application/json")
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
 	}
 
 	resp, err := c.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			slog.Warn("failed to close response body", "err", closeErr)
-		}
-	}()
 
 	if len(expectedStatus) == 0 {
 		expectedStatus = []int{http.StatusOK, http.StatusCreated, http.StatusNoContent}
 	}
 
-	statusOK := slices.Contains(expectedStatus, resp.StatusCode)
-
-	if !statusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return &APIError{
-			Method:     method,
+	if !slices.Contains(expectedStatus, resp.StatusCode) {
+		defer closeBody(resp)
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		return nil, &APIError{
+			Method:     req.Method,
 			Endpoint:   endpoint,
 			StatusCode: resp.StatusCode,
-			Body:       string(bodyBytes),
+			Body:       string(respBody),
 		}
 	}
 
-	if result != nil {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
-		}
-	}
+	return resp, nil
+}
 
-	return nil
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		slog.Warn("failed to close response body", "err", err)
+	}
 }
 
 func (c *Client) GetMySelf(ctx context.Context) (*User, error) {
@@ -449,7 +532,7 @@ func (c *Client) GetMySelf(ctx context.Context) (*User, error) {
 	err := c.doJiraRequest(
 		ctx,
 		"GET",
-		"/rest/api/3/myself",
+		c.apiPath("/myself"),
 		nil,
 		nil,
 		&result,
@@ -458,17 +541,28 @@ func (c *Client) GetMySelf(ctx context.Context) (*User, error) {
 	return &result, err
 }
 
+// issueListFields are the fields fetched for list rows (see jiraIssue.toIssue).
+const issueListFields = "id,summary,description,status,issuetype,assignee,parent,priority,project,reporter,timeoriginalestimate,duedate,created,updated,labels,components,fixVersions"
+
 func (c *Client) SearchIssuesJql(ctx context.Context, jql string) ([]Issue, error) {
 	result := make([]Issue, 0)
 	nextPageToken := ""
+	startAt := 0
 	page := 0
 
 	for {
 		params := url.Values{}
 		params.Add("jql", jql)
 		params.Add("maxResults", "900")
-		params.Add("fields", "id,summary,description,status,issuetype,assignee,parent,priority,project,reporter,timeoriginalestimate,duedate,created,updated")
-		if nextPageToken != "" {
+		params.Add("fields", c.withExtraFields(issueListFields))
+
+		// Cloud pages /search/jql with tokens; Server/DC only has the classic
+		// /search endpoint, paged by offset.
+		endpoint := c.apiPath("/search/jql")
+		if c.flavor == FlavorServer {
+			endpoint = c.apiPath("/search")
+			params.Add("startAt", strconv.Itoa(startAt))
+		} else if nextPageToken != "" {
 			params.Add("nextPageToken", nextPageToken)
 		}
 
@@ -476,7 +570,7 @@ func (c *Client) SearchIssuesJql(ctx context.Context, jql string) ([]Issue, erro
 		err := c.doJiraRequest(
 			ctx,
 			"GET",
-			"/rest/api/3/search/jql",
+			endpoint,
 			params,
 			nil,
 			&searchResp,
@@ -487,55 +581,15 @@ func (c *Client) SearchIssuesJql(ctx context.Context, jql string) ([]Issue, erro
 
 		page++
 		for _, issue := range searchResp.Issues {
-			var assignee string
-			if issue.Fields.Assignee == nil {
-				assignee = "Unassigned"
-			} else {
-				assignee = issue.Fields.Assignee.DisplayName
-			}
-			i := Issue{
-				ID:       issue.ID,
-				Key:      issue.Key,
-				Summary:  issue.Fields.Summary,
-				Status:   issue.Fields.Status.Name,
-				Type:     issue.Fields.Type.Name,
-				Assignee: assignee,
-				Project:  issue.Fields.Project,
-			}
-			if issue.Fields.Priority != nil {
-				i.Priority = Priority{
-					ID:   issue.Fields.Priority.ID,
-					Name: issue.Fields.Priority.Name,
-				}
-			}
-			d, err := time.Parse("2006-01-02", issue.Fields.DueDate)
-			if err == nil {
-				i.DueDate = d.Format("Jan 02")
-			}
-			cr, err := time.Parse("2006-01-02T15:04:05.000-0700", issue.Fields.Created)
-			if err == nil {
-				i.Created = cr.Format("Jan 02")
-			}
-			if issue.Fields.Reporter != nil {
-				i.Reporter = Reporter{
-					ID:          issue.Fields.Reporter.ID,
-					DisplayName: issue.Fields.Reporter.DisplayName,
-				}
-			}
-			if issue.Fields.Parent != nil {
-				i.Parent = &Parent{
-					issue.Fields.Parent.ID,
-					issue.Fields.Parent.Key,
-					issue.Fields.Parent.ParentType,
-				}
-			}
-			if issue.Fields.Description != nil {
-				i.Description = issue.Fields.Description
-			}
-			if issue.Fields.OriginalEstimate != nil {
-				i.OriginalEstimate = strconv.Itoa(*issue.Fields.OriginalEstimate)
+			result = append(result, issue.toIssue())
+		}
+
+		if c.flavor == FlavorServer {
+			startAt += len(searchResp.Issues)
+			if len(searchResp.Issues) == 0 || startAt >= searchResp.Total {
+				break
 			}
-			result = append(result, i)
+			continue
 		}
 
 		if searchResp.NextPageToken == "" {
@@ -547,6 +601,65 @@ func (c *Client) SearchIssuesJql(ctx context.Context, jql string) ([]Issue, erro
 	return result, nil
 }
 
+// toIssue maps a search result onto Issue. Dates are formatted for the list
+// ("Jan 02"); Updated keeps Jira's timestamp for polling.
+func (ji jiraIssue) toIssue() Issue {
+	var assignee string
+	if ji.Fields.Assignee == nil {
+		assignee = "Unassigned"
+	} else {
+		assignee = ji.Fields.Assignee.DisplayName
+	}
+	i := Issue{
+		ID:       ji.ID,
+		Key:      ji.Key,
+		Summary:  ji.Fields.Summary,
+		Status:   ji.Fields.Status.Name,
+		Type:     ji.Fields.Type.Name,
+		Assignee: assignee,
+		Project:  ji.Fields.Project,
+	}
+	if ji.Fields.Priority != nil {
+		i.Priority = Priority{
+			ID:   ji.Fields.Priority.ID,
+			Name: ji.Fields.Priority.Name,
+		}
+	}
+	d, err := time.Parse("2006-01-02", ji.Fields.DueDate)
+	if err == nil {
+		i.DueDate = d.Format("Jan 02")
+	}
+	cr, err := time.Parse("2006-01-02T15:04:05.000-0700", ji.Fields.Created)
+	if err == nil {
+		i.Created = cr.Format("Jan 02")
+	}
+	if ji.Fields.Reporter != nil {
+		i.Reporter = Reporter{
+			ID:          ji.Fields.Reporter.ID,
+			DisplayName: ji.Fields.Reporter.DisplayName,
+		}
+	}
+	if ji.Fields.Parent != nil {
+		i.Parent = &Parent{
+			ji.Fields.Parent.ID,
+			ji.Fields.Parent.Key,
+			ji.Fields.Parent.ParentType,
+		}
+	}
+	if ji.Fields.Description != nil {
+		i.Description = ji.Fields.Description
+	}
+	if ji.Fields.OriginalEstimate != nil {
+		i.OriginalEstimate = strconv.Itoa(*ji.Fields.OriginalEstimate)
+	}
+	i.Updated = ji.Fields.Updated
+	i.Labels = ji.Fields.Labels
+	i.Components = names(ji.Fields.Components)
+	i.FixVersions = names(ji.Fields.FixVersions)
+	i.CustomFields = ji.Fields.Custom
+	return i
+}
+
 func (c *Client) GetMyIssues(ctx context.Context) ([]Issue, error) {
 	jql := "assignee = currentUser() AND resolution = Unresolved ORDER BY status DESC"
 	issues, err := c.SearchIssuesJql(ctx, jql)
@@ -559,7 +672,14 @@ func (c *Client) GetSubTasks(ctx context.Context, parentKey string) ([]Issue, er
 }
 
 func (c *Client) GetProjects(ctx context.Context) ([]jiraProject, error) {
-	apiURL := "/rest/api/3/project/search"
+	if c.flavor == FlavorServer {
+		// Server/DC has no /project/search; /project returns every project.
+		var all []jiraProject
+		err := c.doJiraRequest(ctx, "GET", c.apiPath("/project"), nil, nil, &all, http.StatusOK)
+		return all, err
+	}
+
+	apiURL := c.apiPath("/project/search")
 
 	// /project/search is paginated (default 50 per page); page through it so
 	// projects beyond the first page are included.
@@ -588,7 +708,7 @@ func (c *Client) GetProjects(ctx context.Context) ([]jiraProject, error) {
 }
 
 func (c *Client) GetIssueTypes(ctx context.Context) ([]IssueType, error) {
-	apiURL := "/rest/api/3/issuetype"
+	apiURL := c.apiPath("/issuetype")
 
 	var issueTypes []IssueType
 	err := c.doJiraRequest(
@@ -605,9 +725,10 @@ func (c *Client) GetIssueTypes(ctx context.Context) ([]IssueType, error) {
 }
 
 func (c *Client) GetIssueDetail(ctx context.Context, issueKey string) (*Issue, error) {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s", issueKey)
+	apiURL := c.apiPath("/issue/%s", issueKey)
 	params := url.Values{}
-	params.Add("fields", "id,summary,description,project,status,issuetype,assignee,reporter,comment,priority,parent,issuelinks,timeoriginalestimate,created,updated")
+	params.Add("fields", c.withExtraFields("id,summary,description,project,status,issuetype,assignee,reporter,comment,priority,parent,issuelinks,timeoriginalestimate,created,updated,labels,components,fixVersions,attachment,watches,votes"))
+	params.Add("expand", "changelog")
 
 	var issue jiraIssue
 	err := c.doJiraRequest(
@@ -679,12 +800,65 @@ func (c *Client) GetIssueDetail(ctx context.Context, issueKey string) (*Issue, e
 
 	detail.Created = issue.Fields.Created
 	detail.Updated = issue.Fields.Updated
+	detail.Labels = issue.Fields.Labels
+	detail.Components = names(issue.Fields.Components)
+	detail.FixVersions = names(issue.Fields.FixVersions)
+	detail.Attachments = toAttachments(issue.Fields.Attachment)
+
+	if w := issue.Fields.Watches; w != nil {
+		detail.Watching = w.IsWatching
+		if err == nil && w.WatchCount > 0 {
+			watchers, watchErr := c.GetWatchers(ctx, issueKey)
+			if watchErr != nil {
+				// Listing watchers can need a permission viewing the issue
+				// doesn't; the issue is still worth showing without them.
+				slog.Warn("fetching issue watchers", "issue", issueKey, "err", watchErr)
+			}
+			detail.Watchers = watchers
+		}
+	}
+	if v := issue.Fields.Votes; v != nil {
+		detail.Votes = v.Votes
+		detail.Voted = v.HasVoted
+	}
+
+	if cl := issue.Changelog; cl != nil {
+		detail.History = MergeHistory(toHistory(cl.Histories))
+		detail.HistoryTotal = len(detail.History)
+		if c.flavor != FlavorServer {
+			detail.HistoryTotal = max(cl.Total, detail.HistoryTotal)
+		}
+	}
+	detail.CustomFields = issue.Fields.Custom
 
 	return detail, err
 }
 
+// GetIssueUpdated returns only the issue's "updated" timestamp, a cheap way
+// to tell whether a cached copy is still current.
+func (c *Client) GetIssueUpdated(ctx context.Context, issueKey string) (string, error) {
+	var issue struct {
+		Fields struct {
+			Updated string `json:"updated"`
+		} `json:"fields"`
+	}
+	err := c.doJiraRequest(
+		ctx,
+		"GET",
+		c.apiPath("/issue/%s", issueKey),
+		url.Values{"fields": {"updated"}},
+		nil,
+		&issue,
+		http.StatusOK,
+	)
+	if err != nil {
+		return "", err
+	}
+	return issue.Fields.Updated, nil
+}
+
 func (c *Client) GetTransitions(ctx context.Context, issueKey string) ([]Transition, error) {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s/transitions", issueKey)
+	apiURL := c.apiPath("/issue/%s/transitions", issueKey)
 
 	// expand=transitions.fields returns the fields on each transition's screen so
 	// we can tell whether a worklog (Time Spent) field must be filled.
@@ -724,10 +898,16 @@ func (c *Client) GetTransitions(ctx context.Context, issueKey string) ([]Transit
 	return transitions, err
 }
 
-func (c Client) GetAllUsers(ctx context.Context) ([]User, error) {
-	apiURL := "/rest/api/3/users/search"
+func (c *Client) GetAllUsers(ctx context.Context) ([]User, error) {
+	apiURL := c.apiPath("/users/search")
 	params := url.Values{}
 	params.Add("maxResults", "500")
+	if c.flavor == FlavorServer {
+		// Server/DC has no account types; "." matches every active user.
+		apiURL = c.apiPath("/user/search")
+		params.Set("username", ".")
+		params.Set("maxResults", "1000")
+	}
 
 	var result []User
 
@@ -741,7 +921,7 @@ func (c Client) GetAllUsers(ctx context.Context) ([]User, error) {
 
 	users := make([]User, 0, len(result))
 	for _, u := range result {
-		if u.Type == "atlassian" {
+		if u.Type == "atlassian" || c.flavor == FlavorServer {
 			users = append(users, User{
 				ID:    u.ID,
 				Name:  u.Name,
@@ -755,11 +935,9 @@ func (c Client) GetAllUsers(ctx context.Context) ([]User, error) {
 }
 
 func (c *Client) PostAssignee(ctx context.Context, issueKey, assigneeID string) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s/assignee", issueKey)
+	apiURL := c.apiPath("/issue/%s/assignee", issueKey)
 
-	body := map[string]any{
-		"accountId": assigneeID,
-	}
+	body := c.userRef(assigneeID)
 
 	err := c.doJiraRequest(
 		ctx,
@@ -775,7 +953,7 @@ func (c *Client) PostAssignee(ctx context.Context, issueKey, assigneeID string)
 }
 
 func (c *Client) PostTransition(ctx context.Context, issueKey, transitionID string, fields map[string]any, comment, worklogTime string) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s/transitions", issueKey)
+	apiURL := c.apiPath("/issue/%s/transitions", issueKey)
 
 	body := map[string]any{
 		"transition": map[string]string{
@@ -790,26 +968,12 @@ func (c *Client) PostTransition(ctx context.Context, issueKey, transitionID stri
 	update := make(map[string]any)
 
 	if comment != "" {
+		doc := &ContentDoc{Type: "doc", Version: 1, Content: []ContentNode{{
+			Type:    "paragraph",
+			Content: []ContentNode{{Type: "text", Text: comment}},
+		}}}
 		update["comment"] = []map[string]any{
-			{
-				"add": map[string]any{
-					"body": map[string]any{
-						"type":    "doc",
-						"version": 1,
-						"content": []map[string]any{
-							{
-								"type": "paragraph",
-								"content": []map[string]any{
-									{
-										"type": "text",
-										"text": comment,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+			{"add": map[string]any{"body": c.richText(doc)}},
 		}
 	}
 
@@ -836,12 +1000,14 @@ func (c *Client) PostTransition(ctx context.Context, issueKey, transitionID stri
 	return err
 }
 
-func (c *Client) UpdateDescription(ctx context.Context, issueKey string, description string) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s", issueKey)
+// UpdateDescription replaces the issue's description with Markdown, turning
+// "@[Name]" mentions of users in usersCache into mention nodes as comments do.
+func (c *Client) UpdateDescription(ctx context.Context, issueKey string, description string, usersCache []User) error {
+	apiURL := c.apiPath("/issue/%s", issueKey)
 
 	body := map[string]any{
 		"fields": map[string]any{
-			"description": MarkdownToADF(description),
+			"description": c.richText(CommentToADF(description, usersCache)),
 		},
 	}
 
@@ -859,7 +1025,7 @@ func (c *Client) UpdateDescription(ctx context.Context, issueKey string, descrip
 }
 
 func (c *Client) UpdateSummary(ctx context.Context, issueKey string, summary string) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s", issueKey)
+	apiURL := c.apiPath("/issue/%s", issueKey)
 
 	body := map[string]any{
 		"fields": map[string]any{
@@ -881,7 +1047,7 @@ func (c *Client) UpdateSummary(ctx context.Context, issueKey string, summary str
 }
 
 func (c *Client) UpdatePriority(ctx context.Context, issueKey string, priority string) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s", issueKey)
+	apiURL := c.apiPath("/issue/%s", issueKey)
 
 	body := map[string]any{
 		"fields": map[string]any{
@@ -905,7 +1071,7 @@ func (c *Client) UpdatePriority(ctx context.Context, issueKey string, priority s
 }
 
 func (c *Client) UpdateOriginalEstimate(ctx context.Context, issueKey string, estimate string) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s", issueKey)
+	apiURL := c.apiPath("/issue/%s", issueKey)
 
 	// TODO: validate estimate
 	body := map[string]any{
@@ -938,7 +1104,7 @@ func (c *Client) GetPriorities(ctx context.Context) ([]Priority, error) {
 	err := c.doJiraRequest(
 		ctx,
 		"GET",
-		"/rest/api/3/priority",
+		c.apiPath("/priority"),
 		nil,
 		nil,
 		&result,
@@ -958,7 +1124,7 @@ func (c *Client) GetPriorities(ctx context.Context) ([]Priority, error) {
 func (c *Client) GetStatuses(ctx context.Context, p Project) ([]Status, error) {
 	statuses := []Status{}
 
-	apiURL := fmt.Sprintf("/rest/api/3/project/%s/statuses", p.ID)
+	apiURL := c.apiPath("/project/%s/statuses", p.ID)
 
 	var result []struct {
 		Statuses []Status `json:"statuses"`
@@ -983,10 +1149,10 @@ func (c *Client) GetStatuses(ctx context.Context, p Project) ([]Status, error) {
 }
 
 func (c *Client) PostComment(ctx context.Context, issueKey string, comment string, usersCache []User) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s/comment", issueKey)
+	apiURL := c.apiPath("/issue/%s/comment", issueKey)
 
 	body := map[string]any{
-		"body": CommentToADF(comment, usersCache),
+		"body": c.richText(CommentToADF(comment, usersCache)),
 	}
 
 	err := c.doJiraRequest(
@@ -1002,10 +1168,10 @@ func (c *Client) PostComment(ctx context.Context, issueKey string, comment strin
 }
 
 func (c *Client) PutComment(ctx context.Context, issueKey, commentID, comment string, usersCache []User) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s/comment/%s", issueKey, commentID)
+	apiURL := c.apiPath("/issue/%s/comment/%s", issueKey, commentID)
 
 	body := map[string]any{
-		"body": CommentToADF(comment, usersCache),
+		"body": c.richText(CommentToADF(comment, usersCache)),
 	}
 
 	err := c.doJiraRequest(
@@ -1021,7 +1187,7 @@ func (c *Client) PutComment(ctx context.Context, issueKey, commentID, comment st
 }
 
 func (c *Client) DeleteComment(ctx context.Context, issueKey, commentID string) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issue/%s/comment/%s", issueKey, commentID)
+	apiURL := c.apiPath("/issue/%s/comment/%s", issueKey, commentID)
 
 	err := c.doJiraRequest(
 		ctx,
@@ -1036,96 +1202,29 @@ func (c *Client) DeleteComment(ctx context.Context, issueKey, commentID string)
 	return err
 }
 
-func (c *Client) GetWorkLogs(ctx context.Context, issueID string) ([]Worklog, error) {
-	apiURL := fmt.Sprintf("/4/worklogs/issue/%s", issueID)
-	var result worklogsResponse
-	err := c.doTempoRequest(
-		ctx,
-		"GET",
-		apiURL,
-		nil,
-		nil,
-		&result,
-	)
-
-	return result.Results, err
-}
-
-func (c *Client) PostWorkLog(ctx context.Context, issueID, startDate, accountID, description string, time int) error {
-	body := map[string]any{
-		"authorAccountId":  accountID,
-		"description":      description,
-		"issueId":          issueID,
-		"startDate":        startDate,
-		"timeSpentSeconds": time,
-	}
-
-	err := c.doTempoRequest(
-		ctx,
-		"POST",
-		"/4/worklogs",
-		nil,
-		body,
-		nil,
-	)
-
-	return err
-}
-
-func (c *Client) PutWorkLog(ctx context.Context, worklogID, issueID, startDate, accountID, description string, time int) error {
-	apiURL := fmt.Sprintf("/4/worklogs/%s", worklogID)
-	body := map[string]any{
-		"authorAccountId":  accountID,
-		"description":      description,
-		"issueId":          issueID,
-		"startDate":        startDate,
-		"timeSpentSeconds": time,
-	}
-
-	err := c.doTempoRequest(
-		ctx,
-		"PUT",
-		apiURL,
-		nil,
-		body,
-		nil,
-	)
-
-	return err
-}
-
-func (c *Client) DeleteWorkLog(ctx context.Context, worklogID string) error {
-	apiURL := fmt.Sprintf("/4/worklogs/%s", worklogID)
-
-	err := c.doTempoRequest(
-		ctx,
-		"DELETE",
-		apiURL,
-		nil,
-		nil,
-		nil,
-	)
-
-	return err
+func (c *Client) PostIssueLink(ctx context.Context, fromKey, toKey string, linkType LinkType) error {
+	return c.PostIssueLinkByName(ctx, fromKey, toKey, linkType.String())
 }
 
-func (c *Client) PostIssueLink(ctx context.Context, fromKey, toKey string, linkType LinkType) error {
+// PostIssueLinkByName links two issues with a link type given by name, which
+// also covers types outside LinkType (e.g. "Cloners") when restoring a link.
+func (c *Client) PostIssueLinkByName(ctx context.Context, inwardKey, outwardKey, typeName string) error {
 	body := map[string]any{
 		"type": map[string]string{
-			"name": linkType.String(),
+			"name": typeName,
 		},
 		"inwardIssue": map[string]string{
-			"key": fromKey,
+			"key": inwardKey,
 		},
 		"outwardIssue": map[string]string{
-			"key": toKey,
+			"key": outwardKey,
 		},
 	}
 
 	err := c.doJiraRequest(
 		ctx,
 		"POST",
-		"/rest/api/3/issueLink",
+		c.apiPath("/issueLink"),
 		nil,
 		body,
 		nil,
@@ -1135,7 +1234,7 @@ func (c *Client) PostIssueLink(ctx context.Context, fromKey, toKey string, linkT
 }
 
 func (c *Client) DeleteIssueLink(ctx context.Context, linkID string) error {
-	apiURL := fmt.Sprintf("/rest/api/3/issueLink/%s", linkID)
+	apiURL := c.apiPath("/issueLink/%s", linkID)
 
 	err := c.doJiraRequest(
 		ctx,
@@ -1163,13 +1262,11 @@ func (c *Client) PostNewIssue(
 	dueDate string,
 
 ) error {
-	apiURL := "/rest/api/3/issue"
+	apiURL := c.apiPath("/issue")
 
 	fields := map[string]any{
-		"assignee": map[string]any{
-			"id": assigneeID,
-		},
-		"description": description,
+		"assignee":    c.userRef(assigneeID),
+		"description": c.richText(description),
 		"duedate":     dueDate,
 		"issuetype": map[string]any{
 			"id": issueTypeID,
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -241,6 +242,71 @@ func TestGetWorkLogsTempoAuth(t *testing.T) {
 	}
 }
 
+func TestGetWorkLogsNativeJira(t *testing.T) {
+	body := `{"startAt": 0, "maxResults": 1000, "total": 1, "worklogs": [
+		{"id": "42", "timeSpentSeconds": 1800, "started": "2024-03-05T09:00:00.000+0000", "author": {"accountId": "a1"},
+		 "comment": {"type": "doc", "version": 1, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "pairing"}]}]}}
+	]}`
+	var deleted bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/5/worklog", func(w http.ResponseWriter, r *http.Request) {
+		if u, _, ok := r.BasicAuth(); !ok || u != "user@example.com" {
+			t.Errorf("expected jira basic auth, got user=%q ok=%v", u, ok)
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	mux.HandleFunc("/rest/api/3/issue/5/worklog/42", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, _ := NewClient(srv.URL, "user@example.com", "jira-token", "", "")
+
+	wls, err := c.GetWorkLogs(context.Background(), "5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(wls) != 1 {
+		t.Fatalf("expected 1 worklog, got %d", len(wls))
+	}
+	wl := wls[0]
+	if wl.ID != 42 || wl.Time != 1800 || wl.StartDate != "2024-03-05" || wl.Author.AccountID != "a1" {
+		t.Errorf("worklog not mapped: %+v", wl)
+	}
+	if wl.Description != "pairing" {
+		t.Errorf("Description = %q, want %q", wl.Description, "pairing")
+	}
+
+	if err := c.DeleteWorkLog(context.Background(), "5", "42"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if !deleted {
+		t.Errorf("expected DELETE on the issue's worklog endpoint")
+	}
+}
+
+func TestBrowseURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"https://team.atlassian.net", "https://team.atlassian.net/browse/DEV-1"},
+		{"https://team.atlassian.net/", "https://team.atlassian.net/browse/DEV-1"},
+		{"https://jira.example.com/jira", "https://jira.example.com/jira/browse/DEV-1"},
+	}
+
+	for _, tt := range tests {
+		c, _ := NewClient(tt.baseURL, "user@example.com", "jira-token", "", "")
+		if got := c.BrowseURL("DEV-1"); got != tt.want {
+			t.Errorf("BrowseURL() with base %q = %q, want %q", tt.baseURL, got, tt.want)
+		}
+	}
+}
+
 func TestDoJiraRequestErrorStatus(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/rest/api/3/project/search", func(w http.ResponseWriter, r *http.Request) {
@@ -256,3 +322,48 @@ func TestDoJiraRequestErrorStatus(t *testing.T) {
 		t.Fatalf("expected error for 500 response, got nil")
 	}
 }
+
+func TestGetIssueUpdated(t *testing.T) {
+	c, srv := newTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/DEV-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("fields"); got != "updated" {
+			t.Errorf("fields = %q, want only updated", got)
+		}
+		_, _ = w.Write([]byte(`{"key": "DEV-1", "fields": {"updated": "2024-03-05T10:00:00.000+0000"}}`))
+	}))
+	defer srv.Close()
+
+	got, err := c.GetIssueUpdated(context.Background(), "DEV-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "2024-03-05T10:00:00.000+0000" {
+		t.Errorf("updated = %q", got)
+	}
+}
+
+func TestUpdateDescriptionResolvesMentions(t *testing.T) {
+	var body struct {
+		Fields struct {
+			Description ContentDoc `json:"description"`
+		} `json:"fields"`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	users := []User{{ID: "acc-9", Name: "Jane Doe"}}
+	if err := c.UpdateDescription(context.Background(), "DEV-1", "ask @[Jane Doe]", users); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m := findNode(body.Fields.Description.Content, "mention"); m == nil || m.Attrs.ID != "acc-9" {
+		t.Errorf("want a mention of acc-9, got %+v", body.Fields.Description.Content)
+	}
+}
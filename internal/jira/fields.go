@@ -0,0 +1,349 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is one entry of Jira's field metadata (/field): a system field or a
+// custom field such as "Story Points" (customfield_10016).
+type Field struct {
+	ID     string      `json:"id"`
+	Name   string      `json:"name"`
+	Custom bool        `json:"custom"`
+	Schema FieldSchema `json:"schema"`
+}
+
+// FieldSchema describes a field's value: Type is "number", "string", "date",
+// "datetime", "option", "user", "array", ...; Items is the element type of
+// arrays.
+type FieldSchema struct {
+	Type   string `json:"type"`
+	Items  string `json:"items,omitempty"`
+	Custom string `json:"custom,omitempty"`
+}
+
+// FieldKind is how a field is edited, derived from its schema.
+type FieldKind int
+
+const (
+	FieldUnsupported FieldKind = iota
+	FieldText
+	FieldNumber
+	FieldDate
+	FieldDateTime
+	FieldSelect
+	FieldMultiSelect
+	FieldUser
+	FieldMultiUser
+	FieldLabels
+)
+
+// Kind classifies the field for editing. Fields Jira manages itself (sprint,
+// epic link, rank) and unknown types are FieldUnsupported.
+func (f Field) Kind() FieldKind {
+	if strings.HasPrefix(f.Schema.Custom, "com.pyxis.greenhopper") {
+		return FieldUnsupported
+	}
+	switch f.Schema.Type {
+	case "string":
+		return FieldText
+	case "number":
+		return FieldNumber
+	case "date":
+		return FieldDate
+	case "datetime":
+		return FieldDateTime
+	case "option":
+		return FieldSelect
+	case "user":
+		return FieldUser
+	case "array":
+		switch f.Schema.Items {
+		case "option":
+			return FieldMultiSelect
+		case "user":
+			return FieldMultiUser
+		case "string":
+			return FieldLabels
+		}
+	}
+	return FieldUnsupported
+}
+
+// isTextArea reports whether f is a multi-line "paragraph" custom field.
+func (f Field) isTextArea() bool {
+	return f.Schema.Custom == "com.atlassian.jira.plugin.system.customfieldtypes:textarea"
+}
+
+// FieldOption is an allowed value of a select field.
+type FieldOption struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+// GetFields returns every system and custom field visible to the user.
+func (c *Client) GetFields(ctx context.Context) ([]Field, error) {
+	var fields []Field
+	err := c.doJiraRequest(ctx, "GET", c.apiPath("/field"), nil, nil, &fields, http.StatusOK)
+	return fields, err
+}
+
+// FindField looks a field up by ID or, case-insensitively, by name.
+func FindField(fields []Field, nameOrID string) (Field, bool) {
+	for _, f := range fields {
+		if f.ID == nameOrID {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, nameOrID) {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// GetFieldOptions returns the values a select field accepts on issueKey's edit
+// screen, from /issue/{key}/editmeta.
+func (c *Client) GetFieldOptions(ctx context.Context, issueKey, fieldID string) ([]FieldOption, error) {
+	var meta struct {
+		Fields map[string]struct {
+			AllowedValues []FieldOption `json:"allowedValues"`
+		} `json:"fields"`
+	}
+	err := c.doJiraRequest(ctx, "GET", c.apiPath("/issue/%s/editmeta", issueKey), nil, nil, &meta, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := meta.Fields[fieldID]
+	if !ok {
+		return nil, fmt.Errorf("field %s is not editable on %s", fieldID, issueKey)
+	}
+	return f.AllowedValues, nil
+}
+
+// UpdateField sets one field of issueKey. value is sent as-is; build it with
+// EncodeFieldValue.
+func (c *Client) UpdateField(ctx context.Context, issueKey, fieldID string, value any) error {
+	body := map[string]any{
+		"fields": map[string]any{fieldID: value},
+	}
+	return c.doJiraRequest(ctx, "PUT", c.apiPath("/issue/%s", issueKey), nil, body, nil, http.StatusNoContent)
+}
+
+// EncodeFieldValue turns what the user entered into the JSON value Jira
+// expects for f. values holds one input for single-value kinds (text, number,
+// date, or an option/user ID) and the selected IDs or labels otherwise. An
+// empty input clears the field.
+func (c *Client) EncodeFieldValue(f Field, values []string) (any, error) {
+	var v string
+	if len(values) > 0 {
+		v = strings.TrimSpace(values[0])
+	}
+
+	switch f.Kind() {
+	case FieldText:
+		// Cloud's paragraph fields are rich text and reject plain strings.
+		if c.flavor != FlavorServer && f.isTextArea() {
+			return nilIfEmpty(v, MarkdownToADF(v)), nil
+		}
+		return nilIfEmpty(v, v), nil
+	case FieldNumber:
+		if v == "" {
+			return nil, nil
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", f.Name)
+		}
+		return n, nil
+	case FieldDate:
+		if v == "" {
+			return nil, nil
+		}
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			return nil, fmt.Errorf("%s must be a date (YYYY-MM-DD)", f.Name)
+		}
+		return v, nil
+	case FieldDateTime:
+		if v == "" {
+			return nil, nil
+		}
+		t, err := time.ParseInLocation("2006-01-02 15:04", v, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date and time (YYYY-MM-DD HH:MM)", f.Name)
+		}
+		return t.Format(jiraTimeLayout), nil
+	case FieldSelect:
+		return nilIfEmpty(v, map[string]any{"id": v}), nil
+	case FieldUser:
+		return nilIfEmpty(v, c.userRef(v)), nil
+	case FieldMultiSelect:
+		opts := make([]map[string]any, 0, len(values))
+		for _, id := range values {
+			opts = append(opts, map[string]any{"id": id})
+		}
+		return opts, nil
+	case FieldMultiUser:
+		users := make([]map[string]any, 0, len(values))
+		for _, id := range values {
+			users = append(users, c.userRef(id))
+		}
+		return users, nil
+	case FieldLabels:
+		labels := make([]string, 0, len(values))
+		for _, l := range values {
+			if l = strings.TrimSpace(l); l != "" {
+				labels = append(labels, l)
+			}
+		}
+		return labels, nil
+	default:
+		return nil, fmt.Errorf("editing %s fields is not supported", f.Name)
+	}
+}
+
+// nilIfEmpty returns nil, which clears the field, for an empty input and v
+// otherwise.
+func nilIfEmpty(input string, v any) any {
+	if input == "" {
+		return nil
+	}
+	return v
+}
+
+// jiraTimeLayout is how Jira formats and accepts datetime values.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// FormatFieldValue renders a raw custom field value for display: numbers
+// without trailing zeros, options by value, users by display name, dates as
+// "Jan 02 2006", rich text as Markdown and arrays comma-separated. null is "".
+func FormatFieldValue(raw json.RawMessage) string {
+	var v any
+	if len(raw) == 0 || json.Unmarshal(raw, &v) != nil {
+		return ""
+	}
+	if m, ok := v.(map[string]any); ok && m["type"] == "doc" {
+		var doc ContentDoc
+		if json.Unmarshal(raw, &doc) == nil {
+			return ADFToMarkdown(&doc)
+		}
+	}
+	return formatValue(v)
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if t, err := time.Parse(jiraTimeLayout, v); err == nil {
+			return t.Local().Format("Jan 02 2006 15:04")
+		}
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return t.Format("Jan 02 2006")
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, e := range v {
+			if s := formatValue(e); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]any:
+		// Options carry "value", users "displayName", sprints and versions
+		// "name". Cascading selects nest their second level under "child".
+		for _, k := range []string{"displayName", "value", "name", "key"} {
+			if s, ok := v[k].(string); ok {
+				if child, ok := v["child"].(map[string]any); ok {
+					return s + " › " + formatValue(child)
+				}
+				return s
+			}
+		}
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FieldValueIDs returns the option or user IDs selected in a raw select, user
+// or array value, so an edit form can start from the current selection.
+func FieldValueIDs(raw json.RawMessage) []string {
+	var v any
+	if len(raw) == 0 || json.Unmarshal(raw, &v) != nil {
+		return nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		items = []any{v}
+	}
+	var ids []string
+	for _, item := range items {
+		switch item := item.(type) {
+		case string:
+			ids = append(ids, item)
+		case map[string]any:
+			for _, k := range []string{"accountId", "id", "name"} {
+				if s, ok := item[k].(string); ok {
+					ids = append(ids, s)
+					break
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// SetExtraFields adds field IDs (typically custom fields) to those fetched for
+// list rows and issue detail; their raw values land in Issue.CustomFields.
+func (c *Client) SetExtraFields(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.extraFields = append([]string(nil), ids...)
+}
+
+// withExtraFields appends the extra field IDs to a comma-separated field list.
+func (c *Client) withExtraFields(fields string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.extraFields) == 0 {
+		return fields
+	}
+	return fields + "," + strings.Join(c.extraFields, ",")
+}
+
+// UnmarshalJSON decodes the known fields and keeps every non-null custom
+// field's raw value in Custom.
+func (f *issueFields) UnmarshalJSON(data []byte) error {
+	type plain issueFields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for id, raw := range all {
+		if !strings.HasPrefix(id, "customfield_") || string(raw) == "null" {
+			continue
+		}
+		if f.Custom == nil {
+			f.Custom = make(map[string]json.RawMessage)
+		}
+		f.Custom[id] = raw
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetFieldsAndFindField(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/field", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id":"summary","name":"Summary","custom":false,"schema":{"type":"string","system":"summary"}},
+			{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number","custom":"com.atlassian.jira.plugin.system.customfieldtypes:float"}},
+			{"id":"customfield_10020","name":"Sprint","custom":true,"schema":{"type":"array","items":"json","custom":"com.pyxis.greenhopper.jira:gh-sprint"}}]`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	fields, err := c.GetFields(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f, ok := FindField(fields, "story points")
+	if !ok || f.ID != "customfield_10016" || f.Kind() != FieldNumber {
+		t.Errorf("FindField by name = %+v, %v", f, ok)
+	}
+	if f, ok := FindField(fields, "customfield_10020"); !ok || f.Kind() != FieldUnsupported {
+		t.Errorf("sprint field should be found by ID and not be editable: %+v, %v", f, ok)
+	}
+	if _, ok := FindField(fields, "Cliente"); ok {
+		t.Error("unknown name should not match")
+	}
+}
+
+func TestSearchIssuesJqlExtraFields(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fields"); !strings.HasSuffix(got, ",customfield_10016") {
+			t.Errorf("fields = %q, want the extra field appended", got)
+		}
+		_, _ = w.Write([]byte(`{"issues":[{"key":"DEV-1","id":"1","fields":{
+			"summary":"One","status":{"name":"To Do"},
+			"customfield_10016":5,"customfield_10099":null}}]}`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+	c.SetExtraFields("customfield_10016")
+
+	issues, err := c.SearchIssuesJql(context.Background(), "project = DEV")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := FormatFieldValue(issues[0].CustomFields["customfield_10016"]); got != "5" {
+		t.Errorf("story points = %q, want 5", got)
+	}
+	if _, ok := issues[0].CustomFields["customfield_10099"]; ok {
+		t.Error("null custom fields should be dropped")
+	}
+}
+
+func TestFormatFieldValue(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`null`, ""},
+		{`3.5`, "3.5"},
+		{`8`, "8"},
+		{`"2026-10-17"`, "Oct 17 2026"},
+		{`{"id":"1","value":"Acme"}`, "Acme"},
+		{`{"accountId":"a1","displayName":"Jane Doe"}`, "Jane Doe"},
+		{`[{"value":"A"},{"value":"B"}]`, "A, B"},
+		{`{"value":"EU","child":{"value":"Spain"}}`, "EU › Spain"},
+		{`["backend","urgent"]`, "backend, urgent"},
+		{`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Steps"}]}]}`, "Steps"},
+	}
+	for _, tt := range tests {
+		if got := FormatFieldValue(json.RawMessage(tt.raw)); got != tt.want {
+			t.Errorf("FormatFieldValue(%s) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestFieldValueIDs(t *testing.T) {
+	got := FieldValueIDs(json.RawMessage(`[{"id":"10","value":"A"},{"accountId":"u1","displayName":"Jane"}]`))
+	if strings.Join(got, ",") != "10,u1" {
+		t.Errorf("FieldValueIDs = %v", got)
+	}
+	if got := FieldValueIDs(json.RawMessage(`null`)); len(got) != 0 {
+		t.Errorf("null should have no IDs, got %v", got)
+	}
+}
+
+func TestEncodeFieldValue(t *testing.T) {
+	c, _ := NewClient("https://jira.example.com", "user@example.com", "token", "", "")
+	field := func(typ, items string) Field {
+		return Field{Name: "F", Schema: FieldSchema{Type: typ, Items: items}}
+	}
+
+	tests := []struct {
+		field   Field
+		values  []string
+		want    string
+		wantErr bool
+	}{
+		{field("number", ""), []string{"3"}, `3`, false},
+		{field("number", ""), []string{"three"}, ``, true},
+		{field("number", ""), []string{""}, `null`, false},
+		{field("date", ""), []string{"2026-10-17"}, `"2026-10-17"`, false},
+		{field("date", ""), []string{"17/10/2026"}, ``, true},
+		{field("option", ""), []string{"10"}, `{"id":"10"}`, false},
+		{field("option", ""), []string{""}, `null`, false},
+		{field("array", "option"), []string{"10", "11"}, `[{"id":"10"},{"id":"11"}]`, false},
+		{field("user", ""), []string{"u1"}, `{"accountId":"u1"}`, false},
+		{field("array", "string"), []string{" a", "", "b "}, `["a","b"]`, false},
+		{field("any", ""), []string{"x"}, ``, true},
+	}
+	for _, tt := range tests {
+		got, err := c.EncodeFieldValue(tt.field, tt.values)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("EncodeFieldValue(%s, %v) error = %v, wantErr %v", tt.field.Schema.Type, tt.values, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		raw, _ := json.Marshal(got)
+		if string(raw) != tt.want {
+			t.Errorf("EncodeFieldValue(%s, %v) = %s, want %s", tt.field.Schema.Type, tt.values, raw, tt.want)
+		}
+	}
+}
+
+func TestEncodeTextAreaField(t *testing.T) {
+	textarea := Field{Name: "Notes", Schema: FieldSchema{Type: "string", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:textarea"}}
+
+	c, _ := NewClient("https://jira.example.com", "user@example.com", "token", "", "")
+	got, err := c.EncodeFieldValue(textarea, []string{"**Steps**"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc, ok := got.(*ContentDoc); !ok || doc.Type != "doc" {
+		t.Fatalf("Cloud textarea should be sent as ADF, got %#v", got)
+	}
+	if got, _ := c.EncodeFieldValue(textarea, []string{""}); got != nil {
+		t.Errorf("an empty textarea should clear the field, got %#v", got)
+	}
+
+	server, _ := NewClient("https://jira.example.com", "user@example.com", "token", "", "", WithFlavor(FlavorServer))
+	if got, _ := server.EncodeFieldValue(textarea, []string{"*Steps*"}); got != "*Steps*" {
+		t.Errorf("Server textarea should stay a string, got %#v", got)
+	}
+}
+
+func TestUpdateField(t *testing.T) {
+	var body map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	if err := c.UpdateField(context.Background(), "DEV-1", "customfield_10016", 5.0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["fields"]["customfield_10016"] != 5.0 {
+		t.Errorf("body = %v", body)
+	}
+}
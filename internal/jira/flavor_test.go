@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newServerTestClient(handler http.Handler) (*Client, *httptest.Server) {
+	srv := httptest.NewServer(handler)
+	c, _ := NewClient(srv.URL, "", "server-pat", "", "", WithFlavor(FlavorServer))
+	return c, srv
+}
+
+func TestServerFlavorSearch(t *testing.T) {
+	var calls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer server-pat" {
+			t.Errorf("Authorization = %q, want PAT bearer", got)
+		}
+		calls++
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			_, _ = w.Write([]byte(`{"startAt": 0, "total": 2, "issues": [
+				{"key": "OPS-1", "id": "1", "fields": {"summary": "One", "description": "h1. Hello\n*bold*",
+				 "status": {"name": "Open"}, "assignee": {"name": "jdoe", "displayName": "Jane Doe"}}}
+			]}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"startAt": 1, "total": 2, "issues": [
+				{"key": "OPS-2", "id": "2", "fields": {"summary": "Two", "status": {"name": "Open"}}}
+			]}`))
+		default:
+			t.Errorf("unexpected startAt %q", r.URL.Query().Get("startAt"))
+		}
+	})
+
+	c, srv := newServerTestClient(mux)
+	defer srv.Close()
+
+	issues, err := c.SearchIssuesJql(context.Background(), "project = OPS")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 || len(issues) != 2 {
+		t.Fatalf("expected 2 pages and 2 issues, got %d calls and %d issues", calls, len(issues))
+	}
+	if issues[0].Assignee != "Jane Doe" {
+		t.Errorf("Assignee = %q, want Jane Doe", issues[0].Assignee)
+	}
+	if got := ADFToMarkdown(issues[0].Description); got != "# Hello\n\n**bold**" {
+		t.Errorf("wiki description not converted, got %q", got)
+	}
+}
+
+func TestServerFlavorWritesWikiMarkup(t *testing.T) {
+	var body map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/issue/OPS-1/comment", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/rest/api/2/issue/OPS-1/assignee", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, srv := newServerTestClient(mux)
+	defer srv.Close()
+
+	users := []User{{ID: "jdoe", Name: "Jane Doe"}}
+	if err := c.PostComment(context.Background(), "OPS-1", "**done**, thanks @[Jane Doe]", users); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := body["body"]; got != "*done*, thanks [~jdoe]" {
+		t.Errorf("comment body = %#v, want wiki markup string", got)
+	}
+
+	if err := c.PostAssignee(context.Background(), "OPS-1", "jdoe"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["name"] != "jdoe" || body["accountId"] != nil {
+		t.Errorf("assignee body = %#v, want name only", body)
+	}
+}
+
+func TestServerFlavorUsers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/user/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("username") != "." {
+			t.Errorf("expected username=. query, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"name": "jdoe", "displayName": "Jane Doe", "emailAddress": "jane@example.com"}]`))
+	})
+
+	c, srv := newServerTestClient(mux)
+	defer srv.Close()
+
+	users, err := c.GetAllUsers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != "jdoe" || users[0].Name != "Jane Doe" {
+		t.Errorf("users not mapped: %+v", users)
+	}
+}
@@ -0,0 +1,169 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListField is a multi-value system field edited with add/remove operations
+// (the issue "update" API) rather than by overwriting the whole list.
+type ListField string
+
+const (
+	LabelsField      ListField = "labels"
+	ComponentsField  ListField = "components"
+	FixVersionsField ListField = "fixVersions"
+)
+
+// Title is the field's display name.
+func (f ListField) Title() string {
+	switch f {
+	case LabelsField:
+		return "Labels"
+	case ComponentsField:
+		return "Components"
+	case FixVersionsField:
+		return "Fix versions"
+	default:
+		return string(f)
+	}
+}
+
+// ref is how a value is referenced in an update operation: labels are plain
+// strings, components and versions objects by name.
+func (f ListField) ref(value string) any {
+	if f == LabelsField {
+		return value
+	}
+	return map[string]any{"name": value}
+}
+
+// ListValues returns the issue's values of f.
+func (i Issue) ListValues(f ListField) []string {
+	switch f {
+	case LabelsField:
+		return i.Labels
+	case ComponentsField:
+		return i.Components
+	case FixVersionsField:
+		return i.FixVersions
+	default:
+		return nil
+	}
+}
+
+// SetListValues replaces the issue's values of f.
+func (i *Issue) SetListValues(f ListField, values []string) {
+	switch f {
+	case LabelsField:
+		i.Labels = values
+	case ComponentsField:
+		i.Components = values
+	case FixVersionsField:
+		i.FixVersions = values
+	}
+}
+
+// namedValue is a component or version as embedded in issue fields.
+type namedValue struct {
+	Name string `json:"name"`
+}
+
+func names(values []namedValue) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = v.Name
+	}
+	return out
+}
+
+// UpdateIssueList adds and removes values of field on issueKey in one request,
+// leaving values it doesn't mention alone.
+func (c *Client) UpdateIssueList(ctx context.Context, issueKey string, field ListField, add, remove []string) error {
+	ops := make([]map[string]any, 0, len(add)+len(remove))
+	for _, v := range add {
+		ops = append(ops, map[string]any{"add": field.ref(v)})
+	}
+	for _, v := range remove {
+		ops = append(ops, map[string]any{"remove": field.ref(v)})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	body := map[string]any{
+		"update": map[string]any{string(field): ops},
+	}
+	return c.doJiraRequest(ctx, "PUT", c.apiPath("/issue/%s", issueKey), nil, body, nil, http.StatusNoContent)
+}
+
+// GetListOptions returns the values field can take in projectKey: the site's
+// labels, or the project's components or unarchived versions.
+func (c *Client) GetListOptions(ctx context.Context, field ListField, projectKey string) ([]string, error) {
+	switch field {
+	case LabelsField:
+		return c.getLabels(ctx)
+	case ComponentsField:
+		var components []namedValue
+		err := c.doJiraRequest(ctx, "GET", c.apiPath("/project/%s/components", projectKey), nil, nil, &components, http.StatusOK)
+		return names(components), err
+	case FixVersionsField:
+		var versions []struct {
+			Name     string `json:"name"`
+			Archived bool   `json:"archived"`
+		}
+		err := c.doJiraRequest(ctx, "GET", c.apiPath("/project/%s/versions", projectKey), nil, nil, &versions, http.StatusOK)
+		var out []string
+		for _, v := range versions {
+			if !v.Archived {
+				out = append(out, v.Name)
+			}
+		}
+		return out, err
+	default:
+		return nil, nil
+	}
+}
+
+// getLabels pages through /label on Cloud. Server/DC has no label list, so
+// its JQL autocomplete suggestions stand in.
+func (c *Client) getLabels(ctx context.Context) ([]string, error) {
+	if c.flavor == FlavorServer {
+		var resp struct {
+			Results []struct {
+				Value string `json:"value"`
+			} `json:"results"`
+		}
+		err := c.doJiraRequest(ctx, "GET", c.apiPath("/jql/autocompletedata/suggestions"),
+			url.Values{"fieldName": {"labels"}}, nil, &resp, http.StatusOK)
+		labels := make([]string, 0, len(resp.Results))
+		for _, r := range resp.Results {
+			labels = append(labels, r.Value)
+		}
+		return labels, err
+	}
+
+	var labels []string
+	startAt := 0
+	for page := 0; page < 20; page++ { // safety cap
+		var resp struct {
+			Values []string `json:"values"`
+			IsLast bool     `json:"isLast"`
+		}
+		query := url.Values{"startAt": {strconv.Itoa(startAt)}, "maxResults": {"1000"}}
+		if err := c.doJiraRequest(ctx, "GET", c.apiPath("/label"), query, nil, &resp, http.StatusOK); err != nil {
+			return labels, err
+		}
+		labels = append(labels, resp.Values...)
+		if resp.IsLast || len(resp.Values) == 0 {
+			break
+		}
+		startAt += len(resp.Values)
+	}
+	return labels, nil
+}
@@ -0,0 +1,134 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUpdateIssueList(t *testing.T) {
+	var body map[string]map[string][]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	if err := c.UpdateIssueList(context.Background(), "DEV-1", ComponentsField, []string{"API"}, []string{"Web"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ops := body["update"]["components"]
+	if len(ops) != 2 {
+		t.Fatalf("ops = %v", ops)
+	}
+	if add, _ := ops[0]["add"].(map[string]any); add["name"] != "API" {
+		t.Errorf("add = %v, want the component by name", ops[0])
+	}
+	if remove, _ := ops[1]["remove"].(map[string]any); remove["name"] != "Web" {
+		t.Errorf("remove = %v", ops[1])
+	}
+
+	body = nil
+	if err := c.UpdateIssueList(context.Background(), "DEV-1", LabelsField, []string{"urgent"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := body["update"]["labels"]; len(got) != 1 || got[0]["add"] != "urgent" {
+		t.Errorf("labels are added as plain strings, got %v", got)
+	}
+}
+
+func TestUpdateIssueListNoOps(t *testing.T) {
+	c, srv := newTestClient(http.NewServeMux())
+	defer srv.Close()
+
+	// Any request would 404 on the empty mux.
+	if err := c.UpdateIssueList(context.Background(), "DEV-1", LabelsField, nil, nil); err != nil {
+		t.Errorf("no changes should send nothing, got %v", err)
+	}
+}
+
+func TestGetListOptions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/label", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			_, _ = w.Write([]byte(`{"values":["backend","frontend"],"isLast":false}`))
+		default:
+			_, _ = w.Write([]byte(`{"values":["urgent"],"isLast":true}`))
+		}
+	})
+	mux.HandleFunc("/rest/api/3/project/DEV/versions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name":"1.0","archived":true},{"name":"1.1","archived":false}]`))
+	})
+	mux.HandleFunc("/rest/api/3/project/DEV/components", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name":"API"},{"name":"Web"}]`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		field ListField
+		want  string
+	}{
+		{LabelsField, "backend,frontend,urgent"},
+		{ComponentsField, "API,Web"},
+		{FixVersionsField, "1.1"},
+	}
+	for _, tt := range tests {
+		got, err := c.GetListOptions(context.Background(), tt.field, "DEV")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.field, err)
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("%s options = %v, want %s", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestServerFlavorLabels(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/jql/autocompletedata/suggestions", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fieldName") != "labels" {
+			t.Errorf("fieldName = %q", r.URL.Query().Get("fieldName"))
+		}
+		_, _ = w.Write([]byte(`{"results":[{"value":"ops","displayName":"<b>ops</b>"}]}`))
+	})
+
+	c, srv := newServerTestClient(mux)
+	defer srv.Close()
+
+	got, err := c.GetListOptions(context.Background(), LabelsField, "OPS")
+	if err != nil || len(got) != 1 || got[0] != "ops" {
+		t.Errorf("labels = %v, %v", got, err)
+	}
+}
+
+func TestSearchIssuesJqlListFields(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issues":[{"key":"DEV-1","id":"1","fields":{
+			"summary":"One","status":{"name":"To Do"},
+			"labels":["backend"],"components":[{"id":"1","name":"API"}],"fixVersions":[{"id":"2","name":"1.1"}]}}]}`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	issues, err := c.SearchIssuesJql(context.Background(), "project = DEV")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	is := issues[0]
+	if strings.Join(is.Labels, ",") != "backend" || strings.Join(is.Components, ",") != "API" || strings.Join(is.FixVersions, ",") != "1.1" {
+		t.Errorf("labels %v, components %v, fix versions %v", is.Labels, is.Components, is.FixVersions)
+	}
+}
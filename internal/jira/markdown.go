@@ -1,26 +1,57 @@
 package jira
 
 import (
+	"cmp"
+	"crypto/rand"
 	"fmt"
+	"html"
+	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/text"
 )
 
 // mentionRegex matches the "@[Display Name]" mention syntax used in comments.
 var mentionRegex = regexp.MustCompile(`@\[([^\]]+)\]`)
 
-// mdParser is a CommonMark parser (no extensions) shared across conversions.
-var mdParser = goldmark.New()
+// mdParser is a CommonMark parser with GitHub's tables, strikethrough and
+// task lists, shared across conversions.
+var mdParser = goldmark.New(goldmark.WithExtensions(
+	extension.Table,
+	extension.Strikethrough,
+	extension.TaskList,
+))
+
+var (
+	// admonitionLine is the "[!WARNING]" first line that makes a blockquote
+	// a panel.
+	admonitionLine = regexp.MustCompile(`^\[!([A-Za-z]+)\]\s*$`)
+	// mdEntity matches an HTML entity or numeric character reference.
+	mdEntity = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+	mdBreak  = regexp.MustCompile(`(?i)^<br\s*/?>$`)
+)
+
+// panelTypes are the panel types the admonition syntax can name.
+var panelTypes = []string{"info", "note", "tip", "warning", "error", "success"}
+
+// mediaScheme starts the image URLs that reference a Jira media file by ID
+// instead of by address: "media:ID?collection=...".
+const mediaScheme = "media:"
 
 // MarkdownToADF converts Markdown source into a Jira ADF document. It supports
-// the subset the TUI can round-trip: headings, paragraphs, bold/italic/inline
-// code, links, bullet/ordered lists (incl. nesting), fenced code blocks and
-// horizontal rules. Anything else degrades to its plain text.
+// the subset the TUI can round-trip: headings, paragraphs, bold/italic/strike/
+// inline code, links (with titles), bullet/ordered/task lists (incl. nesting),
+// fenced code blocks, quotes, panels ("> [!INFO]" admonitions), GFM tables,
+// images (media) and horizontal rules. Anything else degrades to its plain
+// text.
 func MarkdownToADF(src string) *ContentDoc {
 	source := []byte(src)
 	root := mdParser.Parser().Parse(text.NewReader(source))
@@ -48,33 +79,56 @@ func blockToNodes(n ast.Node, source []byte) []ContentNode {
 	case *ast.Heading:
 		return []ContentNode{{
 			Type:    "heading",
-			Attrs:   &contentAttrs{Level: node.Level},
+			Attrs:   &NodeAttrs{Level: node.Level},
 			Content: inlineChildren(n, source),
 		}}
 	case *ast.Paragraph, *ast.TextBlock:
+		if media, ok := mediaBlock(n, source); ok {
+			return []ContentNode{media}
+		}
 		return []ContentNode{{Type: "paragraph", Content: inlineChildren(n, source)}}
 	case *ast.List:
-		listType := "bulletList"
+		if isTaskList(node) {
+			return []ContentNode{taskListNode(node, source)}
+		}
+		list := ContentNode{Type: "bulletList"}
 		if node.IsOrdered() {
-			listType = "orderedList"
+			list.Type = "orderedList"
+			if node.Start > 1 {
+				list.Attrs = &NodeAttrs{Order: node.Start}
+			}
 		}
-		var items []ContentNode
 		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
 			if _, ok := c.(*ast.ListItem); ok {
-				items = append(items, ContentNode{Type: "listItem", Content: blocksToNodes(c, source)})
+				list.Content = append(list.Content, ContentNode{Type: "listItem", Content: blocksToNodes(c, source)})
 			}
 		}
-		return []ContentNode{{Type: listType, Content: items}}
+		return []ContentNode{list}
 	case *ast.FencedCodeBlock:
-		return []ContentNode{codeBlockNode(node.Lines(), source)}
+		code := codeBlockNode(node.Lines(), source)
+		if lang := string(node.Language(source)); lang != "" {
+			code.Attrs = &NodeAttrs{Language: lang}
+		}
+		return []ContentNode{code}
 	case *ast.CodeBlock:
 		return []ContentNode{codeBlockNode(node.Lines(), source)}
 	case *ast.ThematicBreak:
 		return []ContentNode{{Type: "rule"}}
 	case *ast.Blockquote:
-		// The renderer has no blockquote; flatten its inner blocks so they still
-		// display and round-trip rather than showing "Unknown node type".
-		return blocksToNodes(n, source)
+		if panel, ok := panelNode(node, source); ok {
+			return []ContentNode{panel}
+		}
+		return []ContentNode{{Type: "blockquote", Content: blocksToNodes(n, source)}}
+	case *east.Table:
+		return []ContentNode{tableNode(node, source)}
+	case *ast.HTMLBlock:
+		// An empty comment is how ADFToMarkdown keeps two lists apart; other
+		// HTML is kept as text.
+		raw := strings.TrimSpace(segmentsText(node.Lines(), source))
+		if raw == "" || (strings.HasPrefix(raw, "<!--") && strings.HasSuffix(raw, "-->")) {
+			return nil
+		}
+		return []ContentNode{{Type: "paragraph", Content: []ContentNode{{Type: "text", Text: raw}}}}
 	default:
 		// Unknown block: keep any inline text as a paragraph so nothing is lost.
 		if inline := inlineChildren(n, source); len(inline) > 0 {
@@ -85,13 +139,260 @@ func blockToNodes(n ast.Node, source []byte) []ContentNode {
 }
 
 func codeBlockNode(lines *text.Segments, source []byte) ContentNode {
+	code := strings.TrimRight(segmentsText(lines, source), "\n")
+	return ContentNode{Type: "codeBlock", Content: []ContentNode{{Type: "text", Text: code}}}
+}
+
+func segmentsText(lines *text.Segments, source []byte) string {
 	var b strings.Builder
 	for i := 0; i < lines.Len(); i++ {
 		seg := lines.At(i)
 		b.Write(seg.Value(source))
 	}
-	code := strings.TrimRight(b.String(), "\n")
-	return ContentNode{Type: "codeBlock", Content: []ContentNode{{Type: "text", Text: code}}}
+	return b.String()
+}
+
+// panelNode reads a blockquote whose first line is an admonition,
+// "> [!WARNING]", as a panel of that type.
+func panelNode(quote *ast.Blockquote, source []byte) (ContentNode, bool) {
+	first, ok := quote.FirstChild().(*ast.Paragraph)
+	if !ok || first.Lines().Len() == 0 {
+		return ContentNode{}, false
+	}
+	line := first.Lines().At(0)
+	m := admonitionLine.FindSubmatch(line.Value(source))
+	if m == nil {
+		return ContentNode{}, false
+	}
+	panelType := strings.ToLower(string(m[1]))
+	if !slices.Contains(panelTypes, panelType) {
+		return ContentNode{}, false
+	}
+
+	panel := ContentNode{Type: "panel", Attrs: &NodeAttrs{PanelType: panelType}}
+	// The rest of the first paragraph, below the admonition line.
+	var rest []ContentNode
+	for c := first.FirstChild(); c != nil; c = c.NextSibling() {
+		if t, ok := c.(*ast.Text); ok && t.Segment.Start < line.Stop {
+			continue
+		}
+		rest = append(rest, inlineToNodes(c, source, nil)...)
+	}
+	if len(rest) > 0 {
+		panel.Content = append(panel.Content, ContentNode{Type: "paragraph", Content: rest})
+	}
+	for c := first.NextSibling(); c != nil; c = c.NextSibling() {
+		panel.Content = append(panel.Content, blockToNodes(c, source)...)
+	}
+	if len(panel.Content) == 0 {
+		// ADF panels can't be empty.
+		panel.Content = []ContentNode{{Type: "paragraph"}}
+	}
+	return panel, true
+}
+
+// tableNode converts a GFM table. A header row of empty cells stands for a
+// table without one (Markdown tables always have a header) and is dropped.
+func tableNode(table *east.Table, source []byte) ContentNode {
+	out := ContentNode{Type: "table"}
+	for r := table.FirstChild(); r != nil; r = r.NextSibling() {
+		cellType := "tableCell"
+		if _, ok := r.(*east.TableHeader); ok {
+			cellType = "tableHeader"
+		}
+		row := ContentNode{Type: "tableRow"}
+		empty := true
+		for c := r.FirstChild(); c != nil; c = c.NextSibling() {
+			inline := inlineChildren(c, source)
+			if len(inline) > 0 {
+				empty = false
+			}
+			row.Content = append(row.Content, ContentNode{
+				Type:    cellType,
+				Content: []ContentNode{{Type: "paragraph", Content: inline}},
+			})
+		}
+		if cellType == "tableHeader" && empty {
+			continue
+		}
+		out.Content = append(out.Content, row)
+	}
+	return out
+}
+
+// isTaskList reports whether every item of a bullet list opens with a
+// checkbox, "- [ ]" or "- [x]".
+func isTaskList(list *ast.List) bool {
+	if list.IsOrdered() || list.FirstChild() == nil {
+		return false
+	}
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		if taskCheckBox(item) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func taskCheckBox(item ast.Node) *east.TaskCheckBox {
+	block := item.FirstChild()
+	if block == nil {
+		return nil
+	}
+	box, _ := block.FirstChild().(*east.TaskCheckBox)
+	return box
+}
+
+// taskListNode converts a checkbox list. ADF nests a task list as a sibling
+// of the item it hangs under.
+func taskListNode(list *ast.List, source []byte) ContentNode {
+	out := ContentNode{Type: "taskList", Attrs: &NodeAttrs{LocalID: newLocalID()}}
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		state := "TODO"
+		if taskCheckBox(item).IsChecked {
+			state = "DONE"
+		}
+		task := ContentNode{Type: "taskItem", Attrs: &NodeAttrs{LocalID: newLocalID(), State: state}}
+		var nested []ContentNode
+		for block := item.FirstChild(); block != nil; block = block.NextSibling() {
+			if sub, ok := block.(*ast.List); ok && isTaskList(sub) {
+				nested = append(nested, taskListNode(sub, source))
+				continue
+			}
+			// A task item holds only inline content: further paragraphs and
+			// plain sub-lists join it line by line.
+			for _, line := range taskLines(block, source) {
+				if len(task.Content) > 0 {
+					task.Content = append(task.Content, ContentNode{Type: "hardBreak"})
+				}
+				task.Content = append(task.Content, line...)
+			}
+		}
+		out.Content = append(out.Content, task)
+		out.Content = append(out.Content, nested...)
+	}
+	return out
+}
+
+func taskLines(block ast.Node, source []byte) [][]ContentNode {
+	if list, ok := block.(*ast.List); ok {
+		var lines [][]ContentNode
+		for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+			for c := item.FirstChild(); c != nil; c = c.NextSibling() {
+				lines = append(lines, taskLines(c, source)...)
+			}
+		}
+		return lines
+	}
+	var line []ContentNode
+	for c := block.FirstChild(); c != nil; c = c.NextSibling() {
+		if _, ok := c.(*east.TaskCheckBox); ok {
+			continue
+		}
+		line = append(line, inlineToNodes(c, source, nil)...)
+	}
+	if len(line) == 0 {
+		return nil
+	}
+	return [][]ContentNode{line}
+}
+
+// newLocalID returns a random UUID for the localId ADF requires on task
+// lists and items.
+func newLocalID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// mediaBlock turns a paragraph holding nothing but images into a
+// mediaSingle, or a mediaGroup when there are several Jira media files (or
+// one flagged "group"). Images flagged "inline" stay in the paragraph.
+func mediaBlock(p ast.Node, source []byte) (ContentNode, bool) {
+	var media []ContentNode
+	var single *NodeAttrs
+	group := false
+	for c := p.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Image:
+			m, s, flag := imageMedia(c, source)
+			if flag == "inline" {
+				return ContentNode{}, false
+			}
+			media = append(media, m)
+			single, group = s, group || flag == "group"
+		case *ast.Text:
+			if c.HardLineBreak() || strings.TrimSpace(string(c.Segment.Value(source))) != "" {
+				return ContentNode{}, false
+			}
+		default:
+			return ContentNode{}, false
+		}
+	}
+
+	switch {
+	case len(media) == 0:
+		return ContentNode{}, false
+	case len(media) == 1 && !group:
+		return ContentNode{Type: "mediaSingle", Attrs: single, Content: media}, true
+	}
+	for _, m := range media {
+		// A group holds only Jira files.
+		if m.Attrs.MediaType == "external" {
+			return ContentNode{}, false
+		}
+	}
+	return ContentNode{Type: "mediaGroup", Content: media}, true
+}
+
+// imageMedia reads an image as a media node, plus the mediaSingle attrs its
+// URL carries and its flag: "group" or "inline" when it asks to be in a
+// group or in its paragraph's text.
+func imageMedia(img *ast.Image, source []byte) (media ContentNode, single *NodeAttrs, flag string) {
+	dest := string(img.Destination)
+	alt := plainInline(img, source)
+	single = &NodeAttrs{Layout: "center"}
+
+	rest, ok := strings.CutPrefix(dest, mediaScheme)
+	if !ok {
+		return ContentNode{Type: "media", Attrs: &NodeAttrs{MediaType: "external", URL: dest, Alt: alt}}, single, ""
+	}
+	id, rawQuery, _ := strings.Cut(rest, "?")
+	q, _ := url.ParseQuery(rawQuery)
+	attrs := &NodeAttrs{ID: id, MediaType: "file", Collection: q.Get("collection"), Alt: alt}
+	if t := q.Get("type"); t != "" {
+		attrs.MediaType = t
+	}
+	attrs.Width, _ = strconv.ParseFloat(q.Get("width"), 64)
+	attrs.Height, _ = strconv.ParseFloat(q.Get("height"), 64)
+	if layout := q.Get("layout"); layout != "" {
+		single.Layout = layout
+	}
+	single.Width, _ = strconv.ParseFloat(q.Get("size"), 64)
+	for _, f := range []string{"group", "inline"} {
+		if q.Has(f) {
+			flag = f
+		}
+	}
+	return ContentNode{Type: "media", Attrs: attrs}, single, flag
+}
+
+// plainInline is the unformatted text under n, such as an image's alt text.
+func plainInline(n ast.Node, source []byte) string {
+	var b strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Text:
+			b.WriteString(unescapeMarkdown(string(c.Segment.Value(source))))
+		case *ast.String:
+			b.Write(c.Value)
+		default:
+			b.WriteString(plainInline(c, source))
+		}
+	}
+	return b.String()
 }
 
 func inlineChildren(n ast.Node, source []byte) []ContentNode {
@@ -104,11 +405,15 @@ func inlineChildren(n ast.Node, source []byte) []ContentNode {
 
 // inlineToNodes converts an inline node, carrying accumulated marks from any
 // enclosing emphasis/link/code spans.
-func inlineToNodes(n ast.Node, source []byte, marks []mark) []ContentNode {
+func inlineToNodes(n ast.Node, source []byte, marks []Mark) []ContentNode {
 	switch node := n.(type) {
 	case *ast.Text:
 		var out []ContentNode
-		if seg := string(node.Segment.Value(source)); seg != "" {
+		seg := string(node.Segment.Value(source))
+		if !node.IsRaw() {
+			seg = unescapeMarkdown(seg)
+		}
+		if seg != "" {
 			out = append(out, ContentNode{Type: "text", Text: seg, Marks: marks})
 		}
 		if node.HardLineBreak() {
@@ -126,25 +431,54 @@ func inlineToNodes(n ast.Node, source []byte, marks []mark) []ContentNode {
 				sb.Write(t.Segment.Value(source))
 			}
 		}
-		return []ContentNode{{Type: "text", Text: sb.String(), Marks: addMark(marks, mark{Type: "code"})}}
+		return []ContentNode{{Type: "text", Text: sb.String(), Marks: addMark(marks, Mark{Type: "code"})}}
 	case *ast.Emphasis:
 		markType := "em"
 		if node.Level == 2 {
 			markType = "strong"
 		}
-		return inlineChildrenWithMarks(n, source, addMark(marks, mark{Type: markType}))
+		return inlineChildrenWithMarks(n, source, addMark(marks, Mark{Type: markType}))
+	case *east.Strikethrough:
+		return inlineChildrenWithMarks(n, source, addMark(marks, Mark{Type: "strike"}))
 	case *ast.Link:
-		href := string(node.Destination)
-		return inlineChildrenWithMarks(n, source, addMark(marks, mark{Type: "link", Attrs: &markAttrs{Href: href}}))
+		attrs := &MarkAttrs{Href: string(node.Destination), Title: unescapeMarkdown(string(node.Title))}
+		return inlineChildrenWithMarks(n, source, addMark(marks, Mark{Type: "link", Attrs: attrs}))
 	case *ast.AutoLink:
 		url := string(node.URL(source))
-		return []ContentNode{{Type: "text", Text: url, Marks: addMark(marks, mark{Type: "link", Attrs: &markAttrs{Href: url}})}}
+		if node.AutoLinkType == ast.AutoLinkURL && len(marks) == 0 {
+			return []ContentNode{{Type: "inlineCard", Attrs: &NodeAttrs{URL: url}}}
+		}
+		return []ContentNode{{Type: "text", Text: url, Marks: addMark(marks, Mark{Type: "link", Attrs: &MarkAttrs{Href: url}})}}
+	case *ast.Image:
+		media, _, _ := imageMedia(node, source)
+		if media.Attrs.MediaType == "external" {
+			// Only Jira files can sit inline; an outside image becomes a link.
+			label := media.Attrs.Alt
+			if label == "" {
+				label = media.Attrs.URL
+			}
+			return []ContentNode{{Type: "text", Text: label, Marks: addMark(marks, Mark{Type: "link", Attrs: &MarkAttrs{Href: media.Attrs.URL}})}}
+		}
+		media.Type = "mediaInline"
+		return []ContentNode{media}
+	case *ast.RawHTML:
+		raw := segmentsText(node.Segments, source)
+		if mdBreak.MatchString(raw) {
+			return []ContentNode{{Type: "hardBreak"}}
+		}
+		return []ContentNode{{Type: "text", Text: raw, Marks: marks}}
+	case *east.TaskCheckBox:
+		box := "[ ] "
+		if node.IsChecked {
+			box = "[x] "
+		}
+		return []ContentNode{{Type: "text", Text: box, Marks: marks}}
 	default:
 		return inlineChildrenWithMarks(n, source, marks)
 	}
 }
 
-func inlineChildrenWithMarks(n ast.Node, source []byte, marks []mark) []ContentNode {
+func inlineChildrenWithMarks(n ast.Node, source []byte, marks []Mark) []ContentNode {
 	var out []ContentNode
 	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
 		out = append(out, inlineToNodes(c, source, marks)...)
@@ -152,60 +486,181 @@ func inlineChildrenWithMarks(n ast.Node, source []byte, marks []mark) []ContentN
 	return out
 }
 
-func addMark(marks []mark, m mark) []mark {
-	next := make([]mark, len(marks), len(marks)+1)
+func addMark(marks []Mark, m Mark) []Mark {
+	next := make([]Mark, len(marks), len(marks)+1)
 	copy(next, marks)
 	return append(next, m)
 }
 
+// unescapeMarkdown resolves the backslash escapes and entity references the
+// parser leaves in text.
+func unescapeMarkdown(s string) string {
+	if !strings.ContainsAny(s, `\&`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			i++
+			b.WriteByte(s[i])
+		case c == '&':
+			if m := mdEntity.FindString(s[i:]); m != "" {
+				b.WriteString(html.UnescapeString(m))
+				i += len(m) - 1
+				continue
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
 // ADFToMarkdown converts an ADF document back into Markdown for pre-filling the
-// edit form, over the same subset MarkdownToADF supports. Nodes outside that
-// subset degrade to their plain text (see ADFHasUnsupported to warn the user).
+// edit form, over the same subset MarkdownToADF supports, so that converting
+// the result back gives the same document. Nodes outside that subset degrade
+// to their plain text (see ADFHasUnsupported to warn the user).
 func ADFToMarkdown(doc *ContentDoc) string {
 	if doc == nil {
 		return ""
 	}
-	var b strings.Builder
-	for _, node := range doc.Content {
-		blockToMarkdown(node, 0, &b)
+	return strings.TrimSpace(blocksToMarkdown(doc.Content))
+}
+
+// blocksToMarkdown renders a block sequence separated by blank lines. Two
+// lists with the same kind of marker in a row get an empty comment between
+// them, or Markdown would read them as one list.
+func blocksToMarkdown(nodes []ContentNode) string {
+	var parts []string
+	prev := ""
+	for _, node := range nodes {
+		md := blockToMarkdown(node)
+		if md == "" {
+			continue
+		}
+		kind := listMarkerKind(node.Type)
+		if kind != "" && kind == prev {
+			parts = append(parts, "<!-- -->")
+		}
+		parts = append(parts, md)
+		prev = kind
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func listMarkerKind(nodeType string) string {
+	switch nodeType {
+	case "bulletList", "taskList":
+		return "-"
+	case "orderedList":
+		return "1."
 	}
-	return strings.TrimSpace(b.String())
+	return ""
 }
 
-func blockToMarkdown(node ContentNode, indent int, b *strings.Builder) {
+func blockToMarkdown(node ContentNode) string {
 	switch node.Type {
 	case "paragraph":
-		b.WriteString(inlineToMarkdown(node.Content) + "\n\n")
+		if onlyInlineMedia(node.Content) {
+			var images []string
+			for _, media := range node.Content {
+				if media.Type == "mediaInline" {
+					images = append(images, mediaToMarkdown(media, nil, "inline"))
+				}
+			}
+			return strings.Join(images, " ")
+		}
+		return escapeLineStarts(inlineToMarkdown(node.Content))
 	case "heading":
 		level := 1
 		if node.Attrs != nil && node.Attrs.Level > 0 {
 			level = node.Attrs.Level
 		}
-		b.WriteString(strings.Repeat("#", level) + " " + inlineToMarkdown(node.Content) + "\n\n")
-	case "bulletList":
-		listToMarkdown(node, false, indent, b)
-		if indent == 0 {
-			b.WriteString("\n")
+		return strings.Repeat("#", level) + " " + inlineToMarkdown(node.Content)
+	case "bulletList", "orderedList":
+		return listToMarkdown(node)
+	case "taskList":
+		return taskListToMarkdown(node)
+	case "codeBlock":
+		code := plainText(node.Content)
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
 		}
-	case "orderedList":
-		listToMarkdown(node, true, indent, b)
-		if indent == 0 {
-			b.WriteString("\n")
+		lang := ""
+		if node.Attrs != nil {
+			lang = node.Attrs.Language
 		}
-	case "codeBlock":
-		b.WriteString("```\n" + inlineToMarkdown(node.Content) + "\n```\n\n")
+		return fence + lang + "\n" + code + "\n" + fence
 	case "rule":
-		b.WriteString("---\n\n")
+		return "---"
+	case "blockquote":
+		return quoteMarkdown(blocksToMarkdown(node.Content))
+	case "panel":
+		panelType := "info"
+		if node.Attrs != nil && node.Attrs.PanelType != "" {
+			panelType = node.Attrs.PanelType
+		}
+		body := "[!" + strings.ToUpper(panelType) + "]"
+		if inner := blocksToMarkdown(node.Content); inner != "" {
+			// Only a paragraph continues the admonition's own; anything else
+			// needs a line of its own to start.
+			if node.Content[0].Type != "paragraph" {
+				body += "\n"
+			}
+			body += "\n" + inner
+		}
+		return quoteMarkdown(body)
+	case "table":
+		return tableToMarkdown(node)
+	case "mediaSingle":
+		var images []string
+		for _, media := range node.Content {
+			images = append(images, mediaToMarkdown(media, node.Attrs, ""))
+		}
+		return strings.Join(images, " ")
+	case "mediaGroup":
+		flag := ""
+		if len(node.Content) == 1 {
+			flag = "group"
+		}
+		var images []string
+		for _, media := range node.Content {
+			images = append(images, mediaToMarkdown(media, nil, flag))
+		}
+		return strings.Join(images, " ")
 	default:
-		if s := inlineToMarkdown(node.Content); s != "" {
-			b.WriteString(s + "\n\n")
+		return escapeLineStarts(inlineToMarkdown(node.Content))
+	}
+}
+
+// quoteMarkdown puts "> " in front of every line of md.
+func quoteMarkdown(md string) string {
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
 		}
 	}
+	return strings.Join(lines, "\n")
 }
 
-func listToMarkdown(list ContentNode, ordered bool, indent int, b *strings.Builder) {
-	pad := strings.Repeat("  ", indent)
+func listToMarkdown(list ContentNode) string {
+	ordered := list.Type == "orderedList"
 	n := 1
+	if ordered && list.Attrs != nil && list.Attrs.Order > 0 {
+		n = list.Attrs.Order
+	}
+	var items []string
 	for _, item := range list.Content {
 		if item.Type != "listItem" {
 			continue
@@ -213,34 +668,199 @@ func listToMarkdown(list ContentNode, ordered bool, indent int, b *strings.Build
 		marker := "- "
 		if ordered {
 			marker = fmt.Sprintf("%d. ", n)
+			n++
 		}
-		wroteMarker := false
-		for _, child := range item.Content {
-			switch child.Type {
-			case "bulletList":
-				listToMarkdown(child, false, indent+1, b)
-			case "orderedList":
-				listToMarkdown(child, true, indent+1, b)
-			default:
-				line := inlineToMarkdown(child.Content)
-				if !wroteMarker {
-					b.WriteString(pad + marker + line + "\n")
-					wroteMarker = true
-				} else {
-					b.WriteString(pad + "  " + line + "\n")
-				}
+		items = append(items, hangMarkdown(marker, listItemToMarkdown(item)))
+	}
+	return strings.Join(items, "\n")
+}
+
+// listItemToMarkdown renders an item's blocks, keeping a nested list tight
+// under the line before it.
+func listItemToMarkdown(item ContentNode) string {
+	var b strings.Builder
+	for i, child := range item.Content {
+		if i > 0 {
+			// Only a list starting at 1 may interrupt a paragraph.
+			tight := child.Type == "bulletList" || child.Type == "taskList" ||
+				(child.Type == "orderedList" && (child.Attrs == nil || child.Attrs.Order <= 1))
+			if tight {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(blockToMarkdown(child))
+	}
+	return b.String()
+}
+
+func taskListToMarkdown(list ContentNode) string {
+	var lines []string
+	for _, child := range list.Content {
+		switch child.Type {
+		case "taskItem":
+			box := "[ ] "
+			if child.Attrs != nil && child.Attrs.State == "DONE" {
+				box = "[x] "
 			}
+			lines = append(lines, hangMarkdown("- ", box+escapeLineStarts(inlineToMarkdown(child.Content))))
+		case "taskList":
+			lines = append(lines, hangMarkdown("  ", taskListToMarkdown(child)))
 		}
-		if !wroteMarker {
-			b.WriteString(pad + marker + "\n")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hangMarkdown puts marker before the first line of md and indents the rest
+// to line up under it, as list item continuation lines must.
+func hangMarkdown(marker, md string) string {
+	pad := strings.Repeat(" ", len(marker))
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		switch {
+		case i == 0:
+			lines[i] = marker + line
+		case line != "":
+			lines[i] = pad + line
 		}
-		n++
 	}
+	return strings.Join(lines, "\n")
 }
 
-func inlineToMarkdown(nodes []ContentNode) string {
+// tableToMarkdown writes a GFM table. Markdown tables must start with a
+// header row, so a table without one gets an empty header.
+func tableToMarkdown(table ContentNode) string {
+	var rows [][]string
+	cols := 0
+	for _, row := range table.Content {
+		var cells []string
+		for _, cell := range row.Content {
+			cells = append(cells, cellToMarkdown(cell))
+		}
+		cols = max(cols, len(cells))
+		rows = append(rows, cells)
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	header := make([]string, cols)
+	if isHeaderRow(table.Content[0]) {
+		header, rows = rows[0], rows[1:]
+	}
+	lines := []string{tableRowMarkdown(header, cols), "|" + strings.Repeat(" --- |", cols)}
+	for _, row := range rows {
+		lines = append(lines, tableRowMarkdown(row, cols))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func tableRowMarkdown(cells []string, cols int) string {
+	padded := append(slices.Clone(cells), make([]string, cols-len(cells))...)
+	return "| " + strings.Join(padded, " | ") + " |"
+}
+
+// cellToMarkdown renders a cell on one line, as a table row can't span
+// lines: breaks become "<br>". Pipes are escaped even in code spans.
+func cellToMarkdown(cell ContentNode) string {
+	var parts []string
+	for _, block := range cell.Content {
+		parts = append(parts, inlineToMarkdown(block.Content))
+	}
+	md := strings.ReplaceAll(strings.Join(parts, "<br>"), "\\\n", "<br>")
 	var b strings.Builder
+	backslashes := 0
+	for i := 0; i < len(md); i++ {
+		if md[i] == '|' && backslashes%2 == 0 {
+			b.WriteByte('\\')
+		}
+		if md[i] == '\\' {
+			backslashes++
+		} else {
+			backslashes = 0
+		}
+		b.WriteByte(md[i])
+	}
+	return b.String()
+}
+
+func isHeaderRow(row ContentNode) bool {
+	if len(row.Content) == 0 {
+		return false
+	}
+	for _, cell := range row.Content {
+		if cell.Type != "tableHeader" {
+			return false
+		}
+	}
+	return true
+}
+
+// onlyInlineMedia reports whether a paragraph holds nothing but inline
+// media, which would read back as a media block unless flagged.
+func onlyInlineMedia(nodes []ContentNode) bool {
+	found := false
 	for _, n := range nodes {
+		switch {
+		case n.Type == "mediaInline":
+			found = true
+		case n.Type != "text" || strings.TrimSpace(n.Text) != "":
+			return false
+		}
+	}
+	return found
+}
+
+// mediaToMarkdown writes a media node as an image. Jira files are referenced
+// by ID under mediaScheme, with the attributes needed to restore the node in
+// the query; single carries the enclosing mediaSingle's layout, and flag is
+// "group" or "inline" to keep a lone image from reading back as a
+// mediaSingle.
+func mediaToMarkdown(media ContentNode, single *NodeAttrs, flag string) string {
+	a := media.Attrs
+	if a == nil {
+		return ""
+	}
+	alt := escapeText(a.Alt)
+	if a.MediaType == "external" {
+		return "![" + alt + "](" + linkDestination(a.URL) + ")"
+	}
+
+	q := url.Values{}
+	if a.Collection != "" {
+		q.Set("collection", a.Collection)
+	}
+	if a.MediaType != "" && a.MediaType != "file" {
+		q.Set("type", a.MediaType)
+	}
+	if a.Width > 0 {
+		q.Set("width", strconv.FormatFloat(a.Width, 'f', -1, 64))
+	}
+	if a.Height > 0 {
+		q.Set("height", strconv.FormatFloat(a.Height, 'f', -1, 64))
+	}
+	if single != nil {
+		if single.Layout != "" && single.Layout != "center" {
+			q.Set("layout", single.Layout)
+		}
+		if single.Width > 0 {
+			q.Set("size", strconv.FormatFloat(single.Width, 'f', -1, 64))
+		}
+	}
+	if flag != "" {
+		q.Set(flag, "")
+	}
+	dest := mediaScheme + a.ID
+	if len(q) > 0 {
+		dest += "?" + q.Encode()
+	}
+	return "![" + alt + "](" + dest + ")"
+}
+
+func inlineToMarkdown(nodes []ContentNode) string {
+	var b strings.Builder
+	for _, n := range mergeText(nodes) {
 		switch n.Type {
 		case "text":
 			b.WriteString(applyInlineMarks(n.Text, n.Marks))
@@ -249,10 +869,26 @@ func inlineToMarkdown(nodes []ContentNode) string {
 				b.WriteString("@[" + strings.TrimPrefix(n.Attrs.Text, "@") + "]")
 			}
 		case "hardBreak":
-			b.WriteString("\n")
+			b.WriteString("\\\n")
 		case "inlineCard":
 			if n.Attrs != nil {
-				b.WriteString(n.Attrs.URL)
+				b.WriteString("<" + n.Attrs.URL + ">")
+			}
+		case "mediaInline":
+			b.WriteString(mediaToMarkdown(n, nil, ""))
+		case "emoji":
+			if n.Attrs != nil {
+				b.WriteString(cmp.Or(n.Attrs.Text, n.Attrs.ShortName))
+			}
+		case "status":
+			if n.Attrs != nil {
+				b.WriteString(escapeText(n.Attrs.Text))
+			}
+		case "date":
+			if n.Attrs != nil {
+				if ms, err := strconv.ParseInt(n.Attrs.Timestamp, 10, 64); err == nil {
+					b.WriteString(time.UnixMilli(ms).UTC().Format(time.DateOnly))
+				}
 			}
 		default:
 			b.WriteString(inlineToMarkdown(n.Content))
@@ -261,52 +897,242 @@ func inlineToMarkdown(nodes []ContentNode) string {
 	return b.String()
 }
 
-func applyInlineMarks(s string, marks []mark) string {
+// mergeText joins adjacent text nodes with the same marks, so how the text
+// happens to be split can't change how it's escaped.
+func mergeText(nodes []ContentNode) []ContentNode {
+	var out []ContentNode
+	for _, n := range nodes {
+		if last := len(out) - 1; n.Type == "text" && last >= 0 && out[last].Type == "text" && sameMarks(out[last].Marks, n.Marks) {
+			out[last].Text += n.Text
+			continue
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+func sameMarks(a, b []Mark) bool {
+	return slices.EqualFunc(a, b, func(x, y Mark) bool {
+		return x.Type == y.Type && (x.Attrs == y.Attrs || (x.Attrs != nil && y.Attrs != nil && *x.Attrs == *y.Attrs))
+	})
+}
+
+// applyInlineMarks writes text with its marks. The delimiters nest in a
+// fixed order, link outermost and code innermost, so the same marks always
+// give the same Markdown.
+func applyInlineMarks(s string, marks []Mark) string {
 	if s == "" {
 		return s
 	}
-	var href string
-	link := false
-	for _, m := range marks {
-		switch m.Type {
-		case "code":
-			s = "`" + s + "`"
-		case "em":
-			s = "*" + s + "*"
-		case "strong":
-			s = "**" + s + "**"
-		case "link":
-			link = true
-			if m.Attrs != nil {
-				href = m.Attrs.Href
+	var link *Mark
+	has := map[string]bool{}
+	for i, m := range marks {
+		has[m.Type] = true
+		if m.Type == "link" {
+			link = &marks[i]
+		}
+	}
+
+	if has["code"] {
+		s = codeSpan(s)
+	} else {
+		s = escapeText(s)
+	}
+	// Emphasis can't open or close next to a space, so the text's leading
+	// and trailing spaces stay outside the delimiters.
+	core := strings.Trim(s, " ")
+	if core != "" {
+		lead := s[:strings.Index(s, core)]
+		trail := s[len(lead)+len(core):]
+		if has["strike"] {
+			// The parser won't close a strike right after an escaped tilde.
+			if rest, ok := strings.CutSuffix(core, `\~`); ok {
+				core = rest + "&#126;"
 			}
+			core = "~~" + core + "~~"
 		}
+		if has["em"] {
+			core = "*" + core + "*"
+		}
+		if has["strong"] {
+			core = "**" + core + "**"
+		}
+		s = lead + core + trail
 	}
-	if link {
-		s = "[" + s + "](" + href + ")"
+
+	if link != nil {
+		href, title := "", ""
+		if link.Attrs != nil {
+			href, title = link.Attrs.Href, link.Attrs.Title
+		}
+		dest := linkDestination(href)
+		if title != "" {
+			dest += ` "` + strings.ReplaceAll(strings.ReplaceAll(title, `\`, `\\`), `"`, `\"`) + `"`
+		}
+		s = "[" + s + "](" + dest + ")"
 	}
 	return s
 }
 
-// ADFHasUnsupported reports whether the document contains block types that the
-// Markdown round-trip cannot preserve (tables, media, panels, etc.), so callers
-// can warn before an edit silently drops them.
-func ADFHasUnsupported(doc *ContentDoc) bool {
-	if doc == nil {
-		return false
+// linkDestination wraps a URL in angle brackets when spaces or parentheses
+// would otherwise end it early.
+func linkDestination(href string) string {
+	if href == "" || strings.ContainsAny(href, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(href) + ">"
+	}
+	return href
+}
+
+// codeSpan fences s with one more backtick than its longest run of them,
+// padding it with spaces when it starts or ends with one.
+func codeSpan(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") ||
+		(strings.HasPrefix(s, " ") && strings.HasSuffix(s, " ") && strings.TrimSpace(s) != "") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+// escapeText backslash-escapes the characters that would otherwise be read
+// as Markdown inside a line. Underscores inside a word can't emphasize and
+// stay as they are.
+func escapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case strings.IndexByte("\\`*[]<|~", c) >= 0:
+			b.WriteByte('\\')
+		case c == '_' && !(i > 0 && isWordByte(s[i-1]) && i+1 < len(s) && isWordByte(s[i+1])):
+			b.WriteByte('\\')
+		case c == '&' && mdEntity.MatchString(s[i:]):
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// escapeLineStarts escapes what would make a line of paragraph text start a
+// heading, quote, list, rule or setext underline.
+func escapeLineStarts(md string) string {
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		lines[i] = escapeLineStart(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeLineStart(line string) string {
+	if line == "" {
+		return line
+	}
+	spaceAfter := func(j int) bool { return j >= len(line) || line[j] == ' ' || line[j] == '\t' }
+	switch line[0] {
+	case '>':
+		return `\` + line
+	case '#':
+		j := 0
+		for j < len(line) && line[j] == '#' {
+			j++
+		}
+		if j <= 6 && spaceAfter(j) {
+			return `\` + line
+		}
+	case '-', '+', '*', '=':
+		if spaceAfter(1) || strings.Trim(line, string(line[0])+" ") == "" {
+			return `\` + line
+		}
+	}
+	// "1." or "1)" would start an ordered list.
+	j := 0
+	for j < len(line) && j < 10 && line[j] >= '0' && line[j] <= '9' {
+		j++
 	}
-	supported := map[string]bool{
+	if j > 0 && j < len(line) && (line[j] == '.' || line[j] == ')') && spaceAfter(j+1) {
+		return line[:j] + `\` + line[j:]
+	}
+	return line
+}
+
+// Nodes and marks the Markdown round trip carries through unchanged.
+var (
+	roundTripNodes = map[string]bool{
 		"paragraph": true, "heading": true, "bulletList": true, "orderedList": true,
 		"listItem": true, "codeBlock": true, "rule": true, "blockquote": true,
+		"panel": true, "table": true, "tableRow": true, "tableHeader": true,
+		"tableCell": true, "taskList": true, "taskItem": true, "mediaSingle": true,
+		"mediaGroup": true, "media": true, "mediaInline": true, "text": true,
+		"hardBreak": true, "mention": true, "inlineCard": true,
 	}
-	for _, n := range doc.Content {
-		if !supported[n.Type] {
+	roundTripMarks = map[string]bool{"strong": true, "em": true, "code": true, "link": true, "strike": true}
+)
+
+// ADFHasUnsupported reports whether the document contains anything the
+// Markdown round-trip cannot preserve (expands, status lozenges, colored
+// text, header columns, etc.), so callers can warn before an edit silently
+// drops it.
+func ADFHasUnsupported(doc *ContentDoc) bool {
+	return doc != nil && hasUnsupported(doc.Content)
+}
+
+func hasUnsupported(nodes []ContentNode) bool {
+	for _, n := range nodes {
+		if !roundTripNodes[n.Type] {
+			return true
+		}
+		for _, m := range n.Marks {
+			if !roundTripMarks[m.Type] {
+				return true
+			}
+		}
+		switch n.Type {
+		case "panel":
+			if n.Attrs != nil && n.Attrs.PanelType != "" && !slices.Contains(panelTypes, n.Attrs.PanelType) {
+				return true
+			}
+		case "table":
+			if !markdownTable(n) {
+				return true
+			}
+		}
+		if hasUnsupported(n.Content) {
 			return true
 		}
 	}
 	return false
 }
 
+// markdownTable reports whether a GFM table can hold table: header cells
+// only as the whole first row, and at most one paragraph per cell.
+func markdownTable(table ContentNode) bool {
+	for i, row := range table.Content {
+		for _, cell := range row.Content {
+			if cell.Type == "tableHeader" && (i > 0 || !isHeaderRow(row)) {
+				return false
+			}
+			if len(cell.Content) > 1 || (len(cell.Content) == 1 && cell.Content[0].Type != "paragraph") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // Private-use sentinels wrap a mention index while the text passes through the
 // Markdown parser, so goldmark can't reinterpret the "@[Name]" brackets as link
 // syntax. They are ordinary letters to the parser and survive intact in a single
@@ -382,7 +1208,7 @@ func splitPlaceholders(node ContentNode, names []string, users []User) []Content
 		if accountID == "" {
 			out = append(out, ContentNode{Type: "text", Text: "@[" + name + "]", Marks: node.Marks})
 		} else {
-			out = append(out, ContentNode{Type: "mention", Attrs: &contentAttrs{ID: accountID, Text: "@" + name}})
+			out = append(out, ContentNode{Type: "mention", Attrs: &NodeAttrs{ID: accountID, Text: "@" + name}})
 		}
 		last = end
 	}
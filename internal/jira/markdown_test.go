@@ -1,8 +1,14 @@
 package jira
 
 import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/quick"
 )
 
 // findNode returns the first node of the given type in a depth-first walk.
@@ -135,3 +141,423 @@ func TestMentionRoundTrip(t *testing.T) {
 		t.Errorf("mention did not round-trip to markdown: %q", md)
 	}
 }
+
+func TestMarkdownToADFTable(t *testing.T) {
+	doc := MarkdownToADF("| Name | Value |\n| --- | --- |\n| a \\| b | **x**<br>y |")
+	table := findNode(doc.Content, "table")
+	if table == nil || len(table.Content) != 2 {
+		t.Fatalf("want a table with two rows, got %+v", doc.Content)
+	}
+	if table.Content[0].Content[0].Type != "tableHeader" || table.Content[1].Content[0].Type != "tableCell" {
+		t.Errorf("cell types = %q, %q", table.Content[0].Content[0].Type, table.Content[1].Content[0].Type)
+	}
+	if got := plainText(table.Content[1].Content[0].Content); got != "a | b" {
+		t.Errorf("escaped pipe cell = %q", got)
+	}
+	if findNode(table.Content[1].Content[1].Content, "hardBreak") == nil {
+		t.Errorf("<br> should be a hardBreak: %+v", table.Content[1].Content[1])
+	}
+}
+
+func TestMarkdownToADFTableWithoutHeader(t *testing.T) {
+	doc := MarkdownToADF("|  |  |\n| --- | --- |\n| a | b |")
+	table := findNode(doc.Content, "table")
+	if table == nil || len(table.Content) != 1 || table.Content[0].Content[0].Type != "tableCell" {
+		t.Errorf("an empty header row should be dropped: %+v", table)
+	}
+}
+
+func TestMarkdownToADFTaskList(t *testing.T) {
+	doc := MarkdownToADF("- [ ] open\n- [x] done\n  - [ ] sub")
+	list := findNode(doc.Content, "taskList")
+	if list == nil || len(list.Content) != 3 {
+		t.Fatalf("want two items and a nested list, got %+v", doc.Content)
+	}
+	if list.Content[0].Attrs.State != "TODO" || list.Content[1].Attrs.State != "DONE" {
+		t.Errorf("states = %q, %q", list.Content[0].Attrs.State, list.Content[1].Attrs.State)
+	}
+	if list.Content[2].Type != "taskList" {
+		t.Errorf("nested list = %q, want a taskList sibling", list.Content[2].Type)
+	}
+	if list.Attrs.LocalID == "" || list.Content[0].Attrs.LocalID == list.Content[1].Attrs.LocalID {
+		t.Errorf("task list and items need distinct localIds")
+	}
+	if got := plainText(list.Content[0].Content); got != "open" {
+		t.Errorf("item text = %q", got)
+	}
+}
+
+func TestMarkdownToADFPanel(t *testing.T) {
+	doc := MarkdownToADF("> [!WARNING]\n> Mind the **gap**\n>\n> - one")
+	panel := findNode(doc.Content, "panel")
+	if panel == nil || panel.Attrs.PanelType != "warning" {
+		t.Fatalf("want a warning panel, got %+v", doc.Content)
+	}
+	if len(panel.Content) != 2 || panel.Content[0].Type != "paragraph" || panel.Content[1].Type != "bulletList" {
+		t.Errorf("panel content = %+v", panel.Content)
+	}
+	if got := plainText(panel.Content[0].Content); got != "Mind the gap" {
+		t.Errorf("panel text = %q", got)
+	}
+
+	doc = MarkdownToADF("> [!SHRUG]\n> not a panel")
+	if findNode(doc.Content, "panel") != nil || findNode(doc.Content, "blockquote") == nil {
+		t.Errorf("unknown admonitions should stay quotes: %+v", doc.Content)
+	}
+}
+
+func TestMarkdownToADFStrikeAndLinkTitle(t *testing.T) {
+	doc := MarkdownToADF(`~~gone~~ [docs](https://example.com "The docs")`)
+	strike := findNode(doc.Content, "text")
+	if strike == nil || len(strike.Marks) != 1 || strike.Marks[0].Type != "strike" {
+		t.Errorf("want a strike mark, got %+v", strike)
+	}
+	p := doc.Content[0]
+	link := p.Content[len(p.Content)-1]
+	if len(link.Marks) != 1 || link.Marks[0].Attrs.Title != "The docs" {
+		t.Errorf("link title lost: %+v", link)
+	}
+}
+
+func TestMarkdownToADFMedia(t *testing.T) {
+	doc := MarkdownToADF("![diagram.png](media:abc-123?collection=contentId-1&layout=wide)")
+	single := findNode(doc.Content, "mediaSingle")
+	if single == nil || single.Attrs.Layout != "wide" {
+		t.Fatalf("want a wide mediaSingle, got %+v", doc.Content)
+	}
+	media := single.Content[0].Attrs
+	if media.ID != "abc-123" || media.Collection != "contentId-1" || media.MediaType != "file" || media.Alt != "diagram.png" {
+		t.Errorf("media attrs = %+v", media)
+	}
+
+	doc = MarkdownToADF("![](media:a?collection=c) ![](media:b?collection=c)")
+	if group := findNode(doc.Content, "mediaGroup"); group == nil || len(group.Content) != 2 {
+		t.Errorf("want a mediaGroup of two, got %+v", doc.Content)
+	}
+}
+
+func TestADFToMarkdownEscapesText(t *testing.T) {
+	doc := &ContentDoc{Type: "doc", Version: 1, Content: []ContentNode{
+		{Type: "paragraph", Content: []ContentNode{{Type: "text", Text: "# not *a* heading [x] snake_case"}}},
+	}}
+	md := ADFToMarkdown(doc)
+	if md != `\# not \*a\* heading \[x\] snake_case` {
+		t.Errorf("markdown = %q", md)
+	}
+	if got := plainText(MarkdownToADF(md).Content[0].Content); got != doc.Content[0].Content[0].Text {
+		t.Errorf("escaped text came back as %q", got)
+	}
+}
+
+func TestADFToMarkdownSeparatesAdjacentLists(t *testing.T) {
+	list := ContentNode{Type: "bulletList", Content: []ContentNode{
+		{Type: "listItem", Content: []ContentNode{{Type: "paragraph", Content: []ContentNode{{Type: "text", Text: "a"}}}}},
+	}}
+	doc := &ContentDoc{Type: "doc", Version: 1, Content: []ContentNode{list, list}}
+	if got := MarkdownToADF(ADFToMarkdown(doc)); len(got.Content) != 2 {
+		t.Errorf("two lists merged into %d blocks", len(got.Content))
+	}
+}
+
+func TestADFHasUnsupported(t *testing.T) {
+	text := func(s string, marks ...Mark) ContentNode { return ContentNode{Type: "text", Text: s, Marks: marks} }
+	para := func(inline ...ContentNode) ContentNode { return ContentNode{Type: "paragraph", Content: inline} }
+	tests := []struct {
+		name string
+		node ContentNode
+		want bool
+	}{
+		{"table", ContentNode{Type: "table", Content: []ContentNode{
+			{Type: "tableRow", Content: []ContentNode{{Type: "tableHeader", Content: []ContentNode{para(text("h"))}}}},
+			{Type: "tableRow", Content: []ContentNode{{Type: "tableCell", Content: []ContentNode{para(text("c"))}}}},
+		}}, false},
+		{"header column", ContentNode{Type: "table", Content: []ContentNode{
+			{Type: "tableRow", Content: []ContentNode{
+				{Type: "tableHeader", Content: []ContentNode{para(text("h"))}},
+				{Type: "tableCell", Content: []ContentNode{para(text("c"))}},
+			}},
+		}}, true},
+		{"panel", ContentNode{Type: "panel", Attrs: &NodeAttrs{PanelType: "note"}, Content: []ContentNode{para(text("x"))}}, false},
+		{"custom panel", ContentNode{Type: "panel", Attrs: &NodeAttrs{PanelType: "custom"}, Content: []ContentNode{para(text("x"))}}, true},
+		{"strike", para(text("x", Mark{Type: "strike"})), false},
+		{"nested status", ContentNode{Type: "blockquote", Content: []ContentNode{para(ContentNode{Type: "status"})}}, true},
+		{"colored text", para(text("x", Mark{Type: "textColor"})), true},
+		{"expand", ContentNode{Type: "expand"}, true},
+	}
+	for _, tt := range tests {
+		doc := &ContentDoc{Type: "doc", Version: 1, Content: []ContentNode{tt.node}}
+		if got := ADFHasUnsupported(doc); got != tt.want {
+			t.Errorf("%s: ADFHasUnsupported = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestADFMarkdownRoundTripProperty checks that any document made of the
+// supported nodes survives ADF -> Markdown -> ADF, and that the Markdown is
+// a fixed point of the round trip.
+func TestADFMarkdownRoundTripProperty(t *testing.T) {
+	roundTrip := func(g genDoc) bool {
+		md := ADFToMarkdown(g.doc)
+		back := MarkdownToADF(md)
+		want, got := normalizeADF(g.doc.Content), normalizeADF(back.Content)
+		if !reflect.DeepEqual(want, got) {
+			w, _ := json.Marshal(want)
+			b, _ := json.Marshal(got)
+			t.Logf("markdown:\n%s\nwant: %s\n got: %s", md, w, b)
+			return false
+		}
+		if again := ADFToMarkdown(back); again != md {
+			t.Logf("markdown not stable:\n%s\n---\n%s", md, again)
+			return false
+		}
+		return true
+	}
+	cfg := &quick.Config{MaxCount: 500, Rand: rand.New(rand.NewSource(1))}
+	if err := quick.Check(roundTrip, cfg); err != nil {
+		t.Error(err)
+	}
+}
+
+// genDoc generates random documents of the nodes, marks and text the
+// Markdown round trip supports, in the shape Jira produces them.
+type genDoc struct{ doc *ContentDoc }
+
+func (genDoc) Generate(r *rand.Rand, _ int) reflect.Value {
+	g := adfGen{r: r}
+	doc := &ContentDoc{Type: "doc", Version: 1}
+	for range 1 + r.Intn(5) {
+		doc.Content = append(doc.Content, g.block(2))
+	}
+	return reflect.ValueOf(genDoc{doc})
+}
+
+type adfGen struct{ r *rand.Rand }
+
+// genWords include Markdown syntax that must come back as literal text.
+var genWords = []string{
+	"alpha", "beta", "snake_case", "a*b", "[x]", "#tag", "1.", "-", "+", ">",
+	"<div>", "&amp;", `C:\tmp`, "a|b", "~tilde~", "`tick`", "_under_", "!bang", "100%", "ünï",
+}
+
+func (g adfGen) pick(options ...string) string { return options[g.r.Intn(len(options))] }
+
+func (g adfGen) words(n int) string {
+	var w []string
+	for range 1 + g.r.Intn(n) {
+		w = append(w, genWords[g.r.Intn(len(genWords))])
+	}
+	return strings.Join(w, " ")
+}
+
+// inline returns runs separated by single spaces (or breaks, when allowed),
+// never starting or ending with whitespace.
+func (g adfGen) inline(breaks bool) []ContentNode {
+	var out []ContentNode
+	for i := range 1 + g.r.Intn(4) {
+		if i > 0 {
+			if breaks && g.r.Intn(5) == 0 {
+				out = append(out, ContentNode{Type: "hardBreak"})
+			} else {
+				out = append(out, ContentNode{Type: "text", Text: " "})
+			}
+		}
+		switch g.r.Intn(8) {
+		case 0:
+			out = append(out, ContentNode{Type: "inlineCard", Attrs: &NodeAttrs{URL: "https://example.com/browse/DEV-" + strconv.Itoa(g.r.Intn(99))}})
+		case 1:
+			out = append(out, ContentNode{Type: "mediaInline", Attrs: &NodeAttrs{ID: "file-" + strconv.Itoa(g.r.Intn(99)), MediaType: "file", Collection: "contentId-7", Alt: g.pick("", "shot.png")}})
+		case 2, 3, 4:
+			out = append(out, ContentNode{Type: "text", Text: g.words(3), Marks: g.marks()})
+		default:
+			out = append(out, ContentNode{Type: "text", Text: g.words(3)})
+		}
+	}
+	return out
+}
+
+func (g adfGen) marks() []Mark {
+	var marks []Mark
+	if g.r.Intn(4) == 0 {
+		return []Mark{{Type: "code"}}
+	}
+	for _, t := range []string{"strong", "em", "strike"} {
+		if g.r.Intn(3) == 0 {
+			marks = append(marks, Mark{Type: t})
+		}
+	}
+	if g.r.Intn(3) == 0 {
+		attrs := &MarkAttrs{Href: g.pick("https://example.com", "https://example.com/a_(b)?q=1", "mailto:me@example.com")}
+		if g.r.Intn(2) == 0 {
+			attrs.Title = g.pick("Docs", `say "hi"`)
+		}
+		marks = append(marks, Mark{Type: "link", Attrs: attrs})
+	}
+	return marks
+}
+
+func (g adfGen) para(breaks bool) ContentNode {
+	return ContentNode{Type: "paragraph", Content: g.inline(breaks)}
+}
+
+func (g adfGen) block(depth int) ContentNode {
+	n := 10
+	if depth == 0 {
+		n = 4
+	}
+	switch g.r.Intn(n) {
+	case 0:
+		return ContentNode{Type: "heading", Attrs: &NodeAttrs{Level: 1 + g.r.Intn(6)}, Content: g.inline(false)}
+	case 1:
+		indented := g.pick("*x*", "```", "if a < b {")
+		if depth == 2 {
+			// Nested under a quote or list, a tab's width depends on the
+			// prefix before it.
+			indented = g.pick(indented, "\tindented")
+		}
+		lines := []string{g.words(4), "  " + indented, g.words(2)}
+		code := ContentNode{Type: "codeBlock", Content: []ContentNode{{Type: "text", Text: strings.Join(lines[:1+g.r.Intn(3)], "\n")}}}
+		if lang := g.pick("", "go", "python", "c++"); lang != "" {
+			code.Attrs = &NodeAttrs{Language: lang}
+		}
+		return code
+	case 2:
+		return ContentNode{Type: "rule"}
+	case 3, 4:
+		return g.para(true)
+	case 5:
+		return g.list(depth)
+	case 6:
+		return g.taskList(depth)
+	case 7:
+		if g.r.Intn(2) == 0 {
+			return ContentNode{Type: "blockquote", Content: g.blocks(depth - 1)}
+		}
+		return ContentNode{Type: "panel", Attrs: &NodeAttrs{PanelType: panelTypes[g.r.Intn(len(panelTypes))]}, Content: g.blocks(depth - 1)}
+	case 8:
+		return g.table()
+	default:
+		return g.media()
+	}
+}
+
+func (g adfGen) blocks(depth int) []ContentNode {
+	var out []ContentNode
+	for range 1 + g.r.Intn(3) {
+		out = append(out, g.block(depth))
+	}
+	return out
+}
+
+func (g adfGen) list(depth int) ContentNode {
+	list := ContentNode{Type: g.pick("bulletList", "orderedList")}
+	if list.Type == "orderedList" && g.r.Intn(3) == 0 {
+		list.Attrs = &NodeAttrs{Order: 2 + g.r.Intn(8)}
+	}
+	for range 1 + g.r.Intn(3) {
+		item := ContentNode{Type: "listItem", Content: []ContentNode{g.para(true)}}
+		if depth > 0 && g.r.Intn(3) == 0 {
+			item.Content = append(item.Content, g.list(depth-1))
+		}
+		list.Content = append(list.Content, item)
+	}
+	return list
+}
+
+func (g adfGen) taskList(depth int) ContentNode {
+	list := ContentNode{Type: "taskList", Attrs: &NodeAttrs{LocalID: "l"}}
+	for range 1 + g.r.Intn(3) {
+		state := g.pick("TODO", "DONE")
+		list.Content = append(list.Content, ContentNode{Type: "taskItem", Attrs: &NodeAttrs{LocalID: "i", State: state}, Content: g.inline(true)})
+		if depth > 0 && g.r.Intn(3) == 0 {
+			list.Content = append(list.Content, g.taskList(depth-1))
+		}
+	}
+	return list
+}
+
+func (g adfGen) table() ContentNode {
+	cols := 1 + g.r.Intn(3)
+	table := ContentNode{Type: "table"}
+	header := g.r.Intn(2) == 0
+	for i := range 1 + g.r.Intn(3) {
+		row := ContentNode{Type: "tableRow"}
+		for range cols {
+			cell := ContentNode{Type: "tableCell", Content: []ContentNode{{Type: "paragraph"}}}
+			if i == 0 && header {
+				cell.Type = "tableHeader"
+			}
+			if cell.Type == "tableHeader" || g.r.Intn(4) > 0 {
+				cell.Content[0] = g.para(true)
+			}
+			row.Content = append(row.Content, cell)
+		}
+		table.Content = append(table.Content, row)
+	}
+	return table
+}
+
+func (g adfGen) media() ContentNode {
+	file := func() ContentNode {
+		attrs := &NodeAttrs{ID: "0f1e-" + strconv.Itoa(g.r.Intn(999)), MediaType: "file", Collection: "contentId-" + strconv.Itoa(g.r.Intn(99))}
+		if g.r.Intn(2) == 0 {
+			attrs.Alt = g.pick("screenshot.png", "a [b].png")
+		}
+		if g.r.Intn(2) == 0 {
+			attrs.Width, attrs.Height = 640, 480
+		}
+		return ContentNode{Type: "media", Attrs: attrs}
+	}
+	switch g.r.Intn(3) {
+	case 0:
+		group := ContentNode{Type: "mediaGroup"}
+		for range 1 + g.r.Intn(3) {
+			group.Content = append(group.Content, file())
+		}
+		return group
+	case 1:
+		external := ContentNode{Type: "media", Attrs: &NodeAttrs{MediaType: "external", URL: "https://example.com/cat.png"}}
+		return ContentNode{Type: "mediaSingle", Attrs: &NodeAttrs{Layout: "center"}, Content: []ContentNode{external}}
+	default:
+		attrs := &NodeAttrs{Layout: g.pick("center", "wide", "align-start")}
+		if g.r.Intn(2) == 0 {
+			attrs.Width = 50
+		}
+		return ContentNode{Type: "mediaSingle", Attrs: attrs, Content: []ContentNode{file()}}
+	}
+}
+
+// normalizeADF drops what the round trip may legitimately change: localIds
+// are regenerated, and adjacent text with the same marks may split or
+// merge differently.
+func normalizeADF(nodes []ContentNode) []ContentNode {
+	var out []ContentNode
+	for _, n := range nodes {
+		if n.Attrs != nil {
+			attrs := *n.Attrs
+			attrs.LocalID = ""
+			n.Attrs = &attrs
+			if attrs == (NodeAttrs{}) {
+				n.Attrs = nil
+			}
+		}
+		if len(n.Marks) > 0 {
+			n.Marks = slices.Clone(n.Marks)
+			slices.SortFunc(n.Marks, func(a, b Mark) int { return strings.Compare(a.Type, b.Type) })
+			for i, m := range n.Marks {
+				if m.Attrs != nil && *m.Attrs == (MarkAttrs{}) {
+					n.Marks[i].Attrs = nil
+				}
+			}
+		} else {
+			n.Marks = nil
+		}
+		n.Content = normalizeADF(n.Content)
+
+		if last := len(out) - 1; n.Type == "text" && last >= 0 && out[last].Type == "text" && reflect.DeepEqual(out[last].Marks, n.Marks) {
+			out[last].Text += n.Text
+			continue
+		}
+		out = append(out, n)
+	}
+	return out
+}
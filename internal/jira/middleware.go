@@ -0,0 +1,165 @@
+package jira
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Middleware wraps the transport every Jira and Tempo request goes through.
+// Middlewares run inside the retry layer, so they see each attempt.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middlewares to the request pipeline. The first one is
+// outermost.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithTransport replaces the transport that actually sends requests, e.g. with
+// a fake in tests. Retries and middlewares still wrap it.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = rt
+	}
+}
+
+// buildTransport assembles retry(middleware...(base)).
+func (c *Client) buildTransport() http.RoundTripper {
+	rt := c.transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	c.retry.next = rt
+	return c.retry
+}
+
+// LoggingMiddleware logs every request attempt with its status and duration.
+// Credentials and bodies are never logged. A nil logger means slog.Default()
+// at the time of the request.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			logger := logger
+			if logger == nil {
+				logger = slog.Default()
+			}
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			attrs := []any{"method", req.Method, "path", req.URL.Path, "duration", time.Since(start)}
+			if err != nil {
+				logger.Warn("http request failed", append(attrs, "err", err)...)
+				return resp, err
+			}
+			logger.Debug("http request", append(attrs, "status", resp.StatusCode)...)
+			return resp, err
+		})
+	}
+}
+
+// RequestMetric is reported by MetricsMiddleware for every request attempt.
+// StatusCode is 0 when Err is set.
+type RequestMetric struct {
+	Method     string
+	Host       string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// MetricsMiddleware calls observe after every request attempt. observe runs on
+// the requesting goroutine and must not block.
+func MetricsMiddleware(observe func(RequestMetric)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			m := RequestMetric{
+				Method:   req.Method,
+				Host:     req.URL.Host,
+				Path:     req.URL.Path,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+			if resp != nil {
+				m.StatusCode = resp.StatusCode
+			}
+			observe(m)
+			return resp, err
+		})
+	}
+}
+
+// RecordedExchange is one request/response pair captured by a Recorder.
+type RecordedExchange struct {
+	Method       string
+	URL          string
+	RequestBody  []byte
+	StatusCode   int
+	ResponseBody []byte
+	Err          error
+}
+
+// Recorder captures request/response pairs, e.g. to assert on what a command
+// sent or to build fixtures. It is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	exchanges []RecordedExchange
+}
+
+// Exchanges returns a copy of everything recorded so far.
+func (r *Recorder) Exchanges() []RecordedExchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedExchange(nil), r.exchanges...)
+}
+
+// Middleware returns a Middleware that records into r. Bodies are buffered and
+// handed on unchanged.
+func (r *Recorder) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ex := RecordedExchange{Method: req.Method, URL: req.URL.String()}
+
+			if req.Body != nil && req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					ex.RequestBody, _ = io.ReadAll(body)
+					_ = body.Close()
+				}
+			}
+
+			resp, err := next.RoundTrip(req)
+			ex.Err = err
+			if resp != nil {
+				ex.StatusCode = resp.StatusCode
+				if resp.Body != nil {
+					ex.ResponseBody, _ = io.ReadAll(resp.Body)
+					_ = resp.Body.Close()
+					resp.Body = io.NopCloser(bytes.NewReader(ex.ResponseBody))
+				}
+			}
+
+			r.mu.Lock()
+			r.exchanges = append(r.exchanges, ex)
+			r.mu.Unlock()
+
+			return resp, err
+		})
+	}
+}
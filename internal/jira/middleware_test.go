@@ -0,0 +1,115 @@
+package jira
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeTransport answers every request with status and body and remembers the
+// last request it saw.
+func fakeTransport(status int, body string, last **http.Request) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		*last = req
+		return &http.Response{
+			StatusCode: status,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestAuthenticators(t *testing.T) {
+	tests := []struct {
+		name string
+		auth Authenticator
+		want string
+	}{
+		{"bearer", BearerToken{Token: "abc"}, "Bearer abc"},
+		{"personal access token", PersonalAccessToken("pat"), "Bearer pat"},
+		{"oauth2", OAuth2{Source: TokenSourceFunc(func(context.Context) (string, error) { return "fresh", nil })}, "Bearer fresh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var last *http.Request
+			c, _ := NewClient("https://jira.example.com", "user@example.com", "jira-token", "", "",
+				WithTransport(fakeTransport(http.StatusOK, `{"accountId": "a1"}`, &last)),
+				WithJiraAuth(tt.auth),
+			)
+
+			if _, err := c.GetMySelf(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := last.Header.Get("Authorization"); got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultAuthPerService(t *testing.T) {
+	var last *http.Request
+	c, _ := NewClient("https://jira.example.com", "user@example.com", "jira-token", "https://api.tempo.io", "tempo-token",
+		WithTransport(fakeTransport(http.StatusOK, `{"results": []}`, &last)),
+	)
+
+	if _, err := c.GetWorkLogs(context.Background(), "5"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last.URL.Host != "api.tempo.io" || last.Header.Get("Authorization") != "Bearer tempo-token" {
+		t.Errorf("tempo request went to %s with auth %q", last.URL.Host, last.Header.Get("Authorization"))
+	}
+
+	if _, err := c.GetMySelf(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u, p, ok := last.BasicAuth(); !ok || u != "user@example.com" || p != "jira-token" {
+		t.Errorf("jira request basic auth = %q/%q ok=%v", u, p, ok)
+	}
+}
+
+func TestMiddlewareOrderAndRecording(t *testing.T) {
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	var metrics []RequestMetric
+	rec := &Recorder{}
+	var last *http.Request
+	c, _ := NewClient("https://jira.example.com", "user@example.com", "jira-token", "", "",
+		WithTransport(fakeTransport(http.StatusCreated, `{}`, &last)),
+		WithMiddleware(tag("outer"), tag("inner")),
+		WithMiddleware(rec.Middleware(), MetricsMiddleware(func(m RequestMetric) { metrics = append(metrics, m) })),
+	)
+
+	if err := c.PostComment(context.Background(), "DEV-1", "hello", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("middleware order = %v, want [outer inner]", order)
+	}
+
+	exchanges := rec.Exchanges()
+	if len(exchanges) != 1 {
+		t.Fatalf("expected 1 recorded exchange, got %d", len(exchanges))
+	}
+	ex := exchanges[0]
+	if ex.Method != "POST" || ex.StatusCode != http.StatusCreated || !strings.Contains(string(ex.RequestBody), "hello") {
+		t.Errorf("unexpected exchange: %+v", ex)
+	}
+
+	if len(metrics) != 1 || metrics[0].Path != "/rest/api/3/issue/DEV-1/comment" || metrics[0].StatusCode != http.StatusCreated {
+		t.Errorf("unexpected metrics: %+v", metrics)
+	}
+}
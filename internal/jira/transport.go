@@ -0,0 +1,169 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Retry defaults used by NewClient. Retry-After is honored as sent but capped
+// at maxRetryAfter so a misbehaving proxy can't park a request for hours.
+const (
+	defaultMaxRetries = 4
+	defaultBaseDelay  = 500 * time.Millisecond
+	defaultMaxDelay   = 10 * time.Second
+	maxRetryAfter     = time.Minute
+)
+
+// RetryNotice describes a request that failed transiently and is about to be
+// retried after Wait.
+type RetryNotice struct {
+	Method string
+	Path   string
+	// StatusCode is the response status, or 0 when the request failed at the
+	// network level (Err is set instead).
+	StatusCode int
+	Err        error
+	Attempt    int
+	Wait       time.Duration
+}
+
+// RateLimited reports whether the server asked us to slow down.
+func (n RetryNotice) RateLimited() bool {
+	return n.StatusCode == http.StatusTooManyRequests
+}
+
+// WithRetryNotify registers fn to be called, from the requesting goroutine,
+// before each retry. fn must not block.
+func WithRetryNotify(fn func(RetryNotice)) ClientOption {
+	return func(c *Client) {
+		c.retry.notify = fn
+	}
+}
+
+// retryTransport retries idempotent requests that fail with 429, 502, 503,
+// 504 or a network error, waiting for Retry-After when the server sends one
+// and a jittered exponential backoff otherwise. It is the outermost layer of
+// the request pipeline; next is filled in by buildTransport.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	notify     func(RetryNotice)
+	// sleep waits for d or until ctx is done; replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: defaultMaxRetries,
+		baseDelay:  defaultBaseDelay,
+		maxDelay:   defaultMaxDelay,
+		sleep:      sleepContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only bodiless, idempotent requests are safe to replay as-is.
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.next.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt > t.maxRetries || !retryable(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		notice := RetryNotice{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Err:     err,
+			Attempt: attempt,
+			Wait:    t.backoff(attempt),
+		}
+		if resp != nil {
+			notice.StatusCode = resp.StatusCode
+			if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				notice.Wait = d
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if t.notify != nil {
+			t.notify(notice)
+		}
+
+		if err := t.sleep(req.Context(), notice.Wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before retry number attempt (1-based): baseDelay
+// doubled per attempt, capped at maxDelay, with "equal jitter" so concurrent
+// requests that failed together don't retry in lockstep.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.baseDelay << (attempt - 1)
+	if d <= 0 || d > t.maxDelay {
+		d = t.maxDelay
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter reads a Retry-After header in either delta-seconds or
+// HTTP-date form.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if secs, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		d = at.Sub(now)
+	} else {
+		return 0, false
+	}
+
+	return min(max(d, 0), maxRetryAfter), true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
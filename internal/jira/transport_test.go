@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// noSleep replaces the retry transport's sleep and records each wait.
+func noSleep(c *Client) *[]time.Duration {
+	var waits []time.Duration
+	c.retry.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return &waits
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var calls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"accountId": "a1"}`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+	waits := noSleep(c)
+
+	var notices []RetryNotice
+	c.retry.notify = func(n RetryNotice) { notices = append(notices, n) }
+
+	if _, err := c.GetMySelf(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Errorf("waits = %v, want [7s]", *waits)
+	}
+	if len(notices) != 1 || !notices[0].RateLimited() || notices[0].Wait != 7*time.Second {
+		t.Errorf("unexpected notices: %+v", notices)
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+	waits := noSleep(c)
+
+	_, err := c.GetMySelf(context.Background())
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 APIError, got %v", err)
+	}
+	if calls != defaultMaxRetries+1 {
+		t.Errorf("expected %d requests, got %d", defaultMaxRetries+1, calls)
+	}
+	for i, w := range *waits {
+		if w <= 0 || w > defaultMaxDelay {
+			t.Errorf("wait %d = %v, want within (0, %v]", i, w, defaultMaxDelay)
+		}
+	}
+}
+
+func TestRetrySkipsNonIdempotentRequests(t *testing.T) {
+	var calls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1/comment", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+	noSleep(c)
+
+	if err := c.PostComment(context.Background(), "DEV-1", "hi", nil); err == nil {
+		t.Fatalf("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("POST should not be retried, got %d requests", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-5", 0, true},
+		{"86400", maxRetryAfter, true},
+		{now.Add(20 * time.Second).Format(http.TimeFormat), 20 * time.Second, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
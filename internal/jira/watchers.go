@@ -0,0 +1,41 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type watchesField struct {
+	WatchCount int  `json:"watchCount"`
+	IsWatching bool `json:"isWatching"`
+}
+
+type votesField struct {
+	Votes    int  `json:"votes"`
+	HasVoted bool `json:"hasVoted"`
+}
+
+// GetWatchers returns who watches issueKey.
+func (c *Client) GetWatchers(ctx context.Context, issueKey string) ([]User, error) {
+	var resp struct {
+		Watchers []User `json:"watchers"`
+	}
+	err := c.doJiraRequest(ctx, "GET", c.apiPath("/issue/%s/watchers", issueKey), nil, nil, &resp, http.StatusOK)
+	return resp.Watchers, err
+}
+
+// AddWatcher makes userID watch issueKey. The body is the bare account ID
+// (username on Server/DC) as a JSON string.
+func (c *Client) AddWatcher(ctx context.Context, issueKey, userID string) error {
+	return c.doJiraRequest(ctx, "POST", c.apiPath("/issue/%s/watchers", issueKey), nil, userID, nil, http.StatusNoContent)
+}
+
+// RemoveWatcher stops userID watching issueKey.
+func (c *Client) RemoveWatcher(ctx context.Context, issueKey, userID string) error {
+	param := "accountId"
+	if c.flavor == FlavorServer {
+		param = "username"
+	}
+	return c.doJiraRequest(ctx, "DELETE", c.apiPath("/issue/%s/watchers", issueKey), url.Values{param: {userID}}, nil, nil, http.StatusNoContent)
+}
@@ -0,0 +1,101 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetIssueDetailWatchersAndVotes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"key":"DEV-1","id":"1","fields":{"summary":"One","status":{"name":"To Do"},
+			"watches":{"watchCount":2,"isWatching":true},"votes":{"votes":3,"hasVoted":false}}}`))
+	})
+	mux.HandleFunc("/rest/api/3/issue/DEV-1/watchers", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"watchCount":2,"watchers":[{"accountId":"u1","displayName":"Jane"},{"accountId":"u2","displayName":"Ana"}]}`))
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	issue, err := c.GetIssueDetail(context.Background(), "DEV-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !issue.Watching || len(issue.Watchers) != 2 || issue.Watchers[1].Name != "Ana" {
+		t.Errorf("watching %v, watchers %+v", issue.Watching, issue.Watchers)
+	}
+	if issue.Votes != 3 || issue.Voted {
+		t.Errorf("votes = %d (voted %v), want 3", issue.Votes, issue.Voted)
+	}
+}
+
+func TestGetIssueDetailWithoutWatchersSkipsLookup(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"key":"DEV-1","id":"1","fields":{"summary":"One","status":{"name":"To Do"},
+			"watches":{"watchCount":0,"isWatching":false}}}`))
+	})
+	mux.HandleFunc("/rest/api/3/issue/DEV-1/watchers", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("watchers should not be fetched when nobody watches")
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	if _, err := c.GetIssueDetail(context.Background(), "DEV-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAddAndRemoveWatcher(t *testing.T) {
+	var added string
+	var removed string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/DEV-1/watchers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			// The body is a bare JSON string, not an object.
+			if err := json.NewDecoder(r.Body).Decode(&added); err != nil {
+				t.Errorf("body is not a JSON string: %v", err)
+			}
+		case http.MethodDelete:
+			removed = r.URL.Query().Get("accountId")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, srv := newTestClient(mux)
+	defer srv.Close()
+
+	if err := c.AddWatcher(context.Background(), "DEV-1", "u1"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := c.RemoveWatcher(context.Background(), "DEV-1", "u2"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if added != "u1" || removed != "u2" {
+		t.Errorf("added %q removed %q", added, removed)
+	}
+}
+
+func TestServerFlavorRemoveWatcherByUsername(t *testing.T) {
+	var removed string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/issue/OPS-1/watchers", func(w http.ResponseWriter, r *http.Request) {
+		removed = r.URL.Query().Get("username")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c, srv := newServerTestClient(mux)
+	defer srv.Close()
+
+	if err := c.RemoveWatcher(context.Background(), "OPS-1", "jdoe"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != "jdoe" {
+		t.Errorf("username = %q, want jdoe", removed)
+	}
+}
@@ -0,0 +1,418 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Jira Server/Data Center's v2 API stores rich text as wiki markup instead of
+// ADF. Reads go wiki markup -> Markdown -> ADF so the rest of the TUI only ever
+// deals with ADF; writes render ADF back to wiki markup. Both directions cover
+// headings, paragraphs, emphasis, code, links, mentions, lists, code blocks,
+// quotes, tables and rules; writes also render panels, task lists and media.
+
+var (
+	wikiHeading    = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiListItem   = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	wikiCodeOpen   = regexp.MustCompile(`^\{(code|noformat)(?::([^}]*))?\}(.*)$`)
+	wikiQuoteLine  = regexp.MustCompile(`^bq\.\s+(.*)$`)
+	wikiTableRow   = regexp.MustCompile(`^\|\|?.*\|\|?\s*$`)
+	wikiMention    = regexp.MustCompile(`\[~([^\]]+)\]`)
+	wikiLink       = regexp.MustCompile(`\[([^\]|]+)\|([^\]]+)\]`)
+	wikiBareLink   = regexp.MustCompile(`\[((?:https?|mailto):[^\]|]+)\]`)
+	wikiMonospace  = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiStrong     = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*]*[^*\s])?)\*($|[^\w*])`)
+	wikiEmphasis   = regexp.MustCompile(`(^|[^\w_])_([^_\s](?:[^_]*[^_\s])?)_($|[^\w_])`)
+	wikiLineBreak  = regexp.MustCompile(`\\\\\s*`)
+	wikiColorBlock = regexp.MustCompile(`\{color(?::[^}]*)?\}`)
+)
+
+// WikiToADF converts Jira wiki markup into an ADF document. "[~username]"
+// mentions become ADF mention nodes whose id is the username.
+func WikiToADF(src string) *ContentDoc {
+	md := wikiToMarkdown(src)
+
+	protected, names := protectMentions(md)
+	users := make([]User, len(names))
+	for i, n := range names {
+		users[i] = User{ID: n, Name: n}
+	}
+
+	doc := MarkdownToADF(protected)
+	doc.Content = restoreMentions(doc.Content, names, users)
+	return doc
+}
+
+// wikiToMarkdown rewrites wiki markup line by line into the Markdown subset
+// MarkdownToADF understands.
+func wikiToMarkdown(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	inCode := ""     // "code" or "noformat" while inside a block
+	inQuote := false // inside {quote}...{quote}
+	inTable := false // the previous line was a table row
+	for _, line := range lines {
+		if inCode != "" {
+			if before, ok := strings.CutSuffix(line, "{"+inCode+"}"); ok {
+				if before != "" {
+					b.WriteString(before + "\n")
+				}
+				b.WriteString("```\n")
+				inCode = ""
+				continue
+			}
+			b.WriteString(line + "\n")
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+
+		if m := wikiCodeOpen.FindStringSubmatch(trimmed); m != nil {
+			lang := ""
+			if m[1] == "code" {
+				// {code:java} or {code:title=x.go|language=go}
+				for _, opt := range strings.Split(m[2], "|") {
+					if k, v, ok := strings.Cut(opt, "="); ok {
+						if k == "language" {
+							lang = v
+						}
+					} else if opt != "" {
+						lang = opt
+					}
+				}
+			}
+			b.WriteString("```" + lang + "\n")
+			rest := m[3]
+			if before, ok := strings.CutSuffix(rest, "{"+m[1]+"}"); ok {
+				if before != "" {
+					b.WriteString(before + "\n")
+				}
+				b.WriteString("```\n")
+				continue
+			}
+			if rest != "" {
+				b.WriteString(rest + "\n")
+			}
+			inCode = m[1]
+			continue
+		}
+
+		if trimmed == "{quote}" {
+			inQuote = !inQuote
+			b.WriteString("\n")
+			continue
+		}
+
+		if inTable && !wikiTableRow.MatchString(trimmed) {
+			inTable = false
+			b.WriteString("\n")
+		}
+
+		var out string
+		switch {
+		case trimmed == "----":
+			out = "---"
+		case wikiHeading.MatchString(trimmed):
+			m := wikiHeading.FindStringSubmatch(trimmed)
+			out = strings.Repeat("#", int(m[1][0]-'0')) + " " + wikiInline(m[2])
+		case wikiQuoteLine.MatchString(trimmed):
+			out = "> " + wikiInline(wikiQuoteLine.FindStringSubmatch(trimmed)[1])
+		case wikiListItem.MatchString(trimmed) && !strings.HasPrefix(trimmed, "----"):
+			m := wikiListItem.FindStringSubmatch(trimmed)
+			depth := len(m[1])
+			marker := "- "
+			indent := strings.Repeat("  ", depth-1)
+			if m[1][depth-1] == '#' {
+				marker = "1. "
+				indent = strings.Repeat("   ", depth-1)
+			}
+			out = indent + marker + wikiInline(m[2])
+		case wikiTableRow.MatchString(trimmed):
+			out = wikiTableToMarkdown(trimmed, !inTable)
+			inTable = true
+		default:
+			out = wikiInline(line)
+		}
+
+		if inQuote && out != "" {
+			out = "> " + out
+		}
+		b.WriteString(out + "\n")
+	}
+
+	if inCode != "" {
+		b.WriteString("```\n")
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// wikiTableToMarkdown converts one "||h||h||" or "|c|c|" row. The first row of
+// a table also gets the Markdown header separator.
+func wikiTableToMarkdown(row string, first bool) string {
+	header := strings.HasPrefix(row, "||")
+	row = strings.Trim(row, "|")
+	var cells []string
+	if header {
+		cells = strings.Split(row, "||")
+	} else {
+		cells = strings.Split(row, "|")
+	}
+	for i, c := range cells {
+		cells[i] = wikiInline(strings.TrimSpace(c))
+	}
+
+	out := "| " + strings.Join(cells, " | ") + " |"
+	if first {
+		out += "\n|" + strings.Repeat(" --- |", len(cells))
+	}
+	return out
+}
+
+// wikiInline converts inline wiki formatting to Markdown.
+func wikiInline(s string) string {
+	s = wikiColorBlock.ReplaceAllString(s, "")
+	s = wikiMention.ReplaceAllString(s, "@[$1]")
+	s = wikiLink.ReplaceAllString(s, "[$1]($2)")
+	s = wikiBareLink.ReplaceAllString(s, "<$1>")
+	s = wikiMonospace.ReplaceAllString(s, "`$1`")
+	s = wikiStrong.ReplaceAllString(s, "$1**$2**$3")
+	s = wikiEmphasis.ReplaceAllString(s, "$1*$2*$3")
+	s = wikiLineBreak.ReplaceAllString(s, "  \n")
+	return s
+}
+
+// ADFToWiki renders an ADF document as Jira wiki markup. Mentions are written
+// as "[~id]", so on Server/Data Center the id must be the username.
+func ADFToWiki(doc *ContentDoc) string {
+	if doc == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, node := range doc.Content {
+		blockToWiki(node, "", &b)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// blockToWiki writes one block node. listPrefix is the stack of list markers
+// ("*", "#*", ...) for nested list items.
+func blockToWiki(node ContentNode, listPrefix string, b *strings.Builder) {
+	switch node.Type {
+	case "paragraph":
+		b.WriteString(inlineToWiki(node.Content) + "\n\n")
+	case "heading":
+		level := 1
+		if node.Attrs != nil && node.Attrs.Level > 0 {
+			level = node.Attrs.Level
+		}
+		fmt.Fprintf(b, "h%d. %s\n\n", level, inlineToWiki(node.Content))
+	case "bulletList", "orderedList":
+		marker := "*"
+		if node.Type == "orderedList" {
+			marker = "#"
+		}
+		listToWiki(node, listPrefix+marker, b)
+		if listPrefix == "" {
+			b.WriteString("\n")
+		}
+	case "codeBlock":
+		open := "{code}"
+		if node.Attrs != nil && node.Attrs.Language != "" {
+			open = "{code:" + node.Attrs.Language + "}"
+		}
+		b.WriteString(open + "\n" + plainText(node.Content) + "\n{code}\n\n")
+	case "blockquote":
+		b.WriteString("{quote}\n")
+		for _, child := range node.Content {
+			blockToWiki(child, "", b)
+		}
+		b.WriteString("{quote}\n\n")
+	case "rule":
+		b.WriteString("----\n\n")
+	case "panel":
+		macro := "info"
+		if node.Attrs != nil {
+			if m, ok := wikiPanelMacros[node.Attrs.PanelType]; ok {
+				macro = m
+			}
+		}
+		b.WriteString("{" + macro + "}\n")
+		for _, child := range node.Content {
+			blockToWiki(child, "", b)
+		}
+		b.WriteString("{" + macro + "}\n\n")
+	case "taskList":
+		taskListToWiki(node, "*", b)
+		b.WriteString("\n")
+	case "mediaSingle", "mediaGroup":
+		var images []string
+		for _, media := range node.Content {
+			if img := mediaToWiki(media); img != "" {
+				images = append(images, img)
+			}
+		}
+		if len(images) > 0 {
+			b.WriteString(strings.Join(images, " ") + "\n\n")
+		}
+	case "table":
+		for _, row := range node.Content {
+			for _, cell := range row.Content {
+				sep := "|"
+				if cell.Type == "tableHeader" {
+					sep = "||"
+				}
+				var parts []string
+				for _, p := range cell.Content {
+					parts = append(parts, inlineToWiki(p.Content))
+				}
+				b.WriteString(sep + " " + strings.Join(parts, " ") + " ")
+			}
+			if len(row.Content) > 0 && row.Content[0].Type == "tableHeader" {
+				b.WriteString("||\n")
+			} else {
+				b.WriteString("|\n")
+			}
+		}
+		b.WriteString("\n")
+	default:
+		if s := inlineToWiki(node.Content); s != "" {
+			b.WriteString(s + "\n\n")
+		}
+	}
+}
+
+func listToWiki(list ContentNode, prefix string, b *strings.Builder) {
+	for _, item := range list.Content {
+		if item.Type != "listItem" {
+			continue
+		}
+		wroteMarker := false
+		for _, child := range item.Content {
+			switch child.Type {
+			case "bulletList", "orderedList":
+				blockToWiki(child, prefix, b)
+			default:
+				line := inlineToWiki(child.Content)
+				if !wroteMarker {
+					b.WriteString(prefix + " " + line + "\n")
+					wroteMarker = true
+				} else {
+					b.WriteString(line + "\n")
+				}
+			}
+		}
+		if !wroteMarker {
+			b.WriteString(prefix + "\n")
+		}
+	}
+}
+
+// wikiPanelMacros maps ADF panel types to the closest wiki macro.
+var wikiPanelMacros = map[string]string{
+	"info":    "info",
+	"note":    "note",
+	"tip":     "tip",
+	"success": "tip",
+	"warning": "warning",
+	"error":   "warning",
+}
+
+// taskListToWiki writes tasks as list items with a "[ ]" or "[x]" box, wiki
+// markup having no checklists of its own.
+func taskListToWiki(list ContentNode, prefix string, b *strings.Builder) {
+	for _, child := range list.Content {
+		switch child.Type {
+		case "taskItem":
+			box := "[ ]"
+			if child.Attrs != nil && child.Attrs.State == "DONE" {
+				box = "[x]"
+			}
+			b.WriteString(prefix + " " + box + " " + inlineToWiki(child.Content) + "\n")
+		case "taskList":
+			taskListToWiki(child, prefix+"*", b)
+		}
+	}
+}
+
+// mediaToWiki embeds an image by URL, or by attachment name for Jira files.
+func mediaToWiki(media ContentNode) string {
+	if media.Attrs == nil {
+		return ""
+	}
+	if media.Attrs.MediaType == "external" && media.Attrs.URL != "" {
+		return "!" + media.Attrs.URL + "!"
+	}
+	if media.Attrs.Alt != "" {
+		return "!" + media.Attrs.Alt + "!"
+	}
+	return ""
+}
+
+func inlineToWiki(nodes []ContentNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Type {
+		case "text":
+			b.WriteString(applyWikiMarks(n.Text, n.Marks))
+		case "mention":
+			if n.Attrs != nil {
+				b.WriteString("[~" + n.Attrs.ID + "]")
+			}
+		case "hardBreak":
+			b.WriteString("\\\\ ")
+		case "inlineCard":
+			if n.Attrs != nil {
+				b.WriteString("[" + n.Attrs.URL + "]")
+			}
+		case "mediaInline":
+			b.WriteString(mediaToWiki(n))
+		default:
+			b.WriteString(inlineToWiki(n.Content))
+		}
+	}
+	return b.String()
+}
+
+func applyWikiMarks(s string, marks []Mark) string {
+	if s == "" {
+		return s
+	}
+	var href string
+	link := false
+	for _, m := range marks {
+		switch m.Type {
+		case "code":
+			s = "{{" + s + "}}"
+		case "em":
+			s = "_" + s + "_"
+		case "strong":
+			s = "*" + s + "*"
+		case "strike":
+			s = "-" + s + "-"
+		case "link":
+			link = true
+			if m.Attrs != nil {
+				href = m.Attrs.Href
+			}
+		}
+	}
+	if link {
+		if s == href {
+			return "[" + href + "]"
+		}
+		s = "[" + s + "|" + href + "]"
+	}
+	return s
+}
+
+func plainText(nodes []ContentNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		b.WriteString(n.Text)
+		b.WriteString(plainText(n.Content))
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWikiToADF(t *testing.T) {
+	src := "h2. Steps\n" +
+		"Run *make* with _care_ and {{go test}}, see [the docs|https://example.com].\n" +
+		"* one\n" +
+		"** nested\n" +
+		"# first\n" +
+		"{code:go}\nfmt.Println(\"*not bold*\")\n{code}\n" +
+		"ping [~jdoe]"
+
+	got := ADFToMarkdown(WikiToADF(src))
+
+	for _, want := range []string{
+		"## Steps",
+		"**make**",
+		"*care*",
+		"`go test`",
+		"[the docs](https://example.com)",
+		"- one",
+		"  - nested",
+		"1. first",
+		"fmt.Println(\"*not bold*\")",
+		"@[jdoe]",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("converted Markdown missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestWikiMentionBecomesMentionNode(t *testing.T) {
+	doc := WikiToADF("hi [~jdoe]")
+
+	var found bool
+	var walk func([]ContentNode)
+	walk = func(nodes []ContentNode) {
+		for _, n := range nodes {
+			if n.Type == "mention" && n.Attrs != nil && n.Attrs.ID == "jdoe" {
+				found = true
+			}
+			walk(n.Content)
+		}
+	}
+	walk(doc.Content)
+
+	if !found {
+		t.Errorf("expected a mention node for jdoe, got %+v", doc.Content)
+	}
+}
+
+func TestADFToWiki(t *testing.T) {
+	md := "# Title\n\nSome **bold**, *em*, `code` and [link](https://example.com).\n\n- a\n  - b\n\n1. x\n\n```\nraw *text*\n```\n\n---"
+
+	got := ADFToWiki(MarkdownToADF(md))
+
+	for _, want := range []string{
+		"h1. Title",
+		"*bold*",
+		"_em_",
+		"{{code}}",
+		"[link|https://example.com]",
+		"* a\n** b",
+		"# x",
+		"{code}\nraw *text*\n{code}",
+		"----",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("wiki output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestADFToWikiMention(t *testing.T) {
+	users := []User{{ID: "jdoe", Name: "Jane Doe"}}
+	got := ADFToWiki(CommentToADF("thanks @[Jane Doe]", users))
+	if got != "thanks [~jdoe]" {
+		t.Errorf("ADFToWiki() = %q, want %q", got, "thanks [~jdoe]")
+	}
+}
+
+func TestADFToWikiPanelsTasksAndMedia(t *testing.T) {
+	md := "> [!WARNING]\n> Careful\n\n- [ ] todo\n- [x] done\n  - [ ] sub\n\n![diagram.png](media:abc?collection=c)"
+
+	got := ADFToWiki(MarkdownToADF(md))
+
+	for _, want := range []string{
+		"{warning}\nCareful\n\n{warning}",
+		"* [ ] todo\n* [x] done\n** [ ] sub",
+		"!diagram.png!",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("wiki output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestADFToWikiInlineMedia(t *testing.T) {
+	got := ADFToWiki(MarkdownToADF("See ![shot.png](media:abc?collection=c) for details"))
+	if want := "See !shot.png! for details"; got != want {
+		t.Errorf("ADFToWiki() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,278 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Worklog backends selectable with WithWorklogBackend.
+const (
+	WorklogBackendTempo = "tempo"
+	WorklogBackendJira  = "jira"
+)
+
+// WorklogBackend reads and writes worklogs. Tempo and Jira's native worklog API
+// store the same data behind different endpoints and payloads; both are mapped
+// to and from Worklog so the TUI doesn't care which one is in use.
+type WorklogBackend interface {
+	GetWorkLogs(ctx context.Context, issueID string) ([]Worklog, error)
+	PostWorkLog(ctx context.Context, issueID, startDate, accountID, description string, time int) error
+	PutWorkLog(ctx context.Context, worklogID, issueID, startDate, accountID, description string, time int) error
+	DeleteWorkLog(ctx context.Context, issueID, worklogID string) error
+}
+
+// WithWorklogBackend selects the worklog backend by name (WorklogBackendTempo
+// or WorklogBackendJira). An empty or unknown name keeps NewClient's default.
+func WithWorklogBackend(name string) ClientOption {
+	return func(c *Client) {
+		switch name {
+		case WorklogBackendTempo:
+			c.worklogs = tempoWorklogs{c}
+		case WorklogBackendJira:
+			c.worklogs = jiraWorklogs{c}
+		}
+	}
+}
+
+func (c *Client) GetWorkLogs(ctx context.Context, issueID string) ([]Worklog, error) {
+	return c.worklogs.GetWorkLogs(ctx, issueID)
+}
+
+func (c *Client) PostWorkLog(ctx context.Context, issueID, startDate, accountID, description string, time int) error {
+	return c.worklogs.PostWorkLog(ctx, issueID, startDate, accountID, description, time)
+}
+
+func (c *Client) PutWorkLog(ctx context.Context, worklogID, issueID, startDate, accountID, description string, time int) error {
+	return c.worklogs.PutWorkLog(ctx, worklogID, issueID, startDate, accountID, description, time)
+}
+
+func (c *Client) DeleteWorkLog(ctx context.Context, issueID, worklogID string) error {
+	return c.worklogs.DeleteWorkLog(ctx, issueID, worklogID)
+}
+
+// tempoWorklogs stores worklogs in Tempo (/4/worklogs).
+type tempoWorklogs struct {
+	c *Client
+}
+
+type worklogsResponse struct {
+	Results []Worklog `json:"results"`
+}
+
+func (t tempoWorklogs) GetWorkLogs(ctx context.Context, issueID string) ([]Worklog, error) {
+	apiURL := fmt.Sprintf("/4/worklogs/issue/%s", issueID)
+	var result worklogsResponse
+	err := t.c.doTempoRequest(
+		ctx,
+		"GET",
+		apiURL,
+		nil,
+		nil,
+		&result,
+	)
+
+	return result.Results, err
+}
+
+func (t tempoWorklogs) PostWorkLog(ctx context.Context, issueID, startDate, accountID, description string, time int) error {
+	body := map[string]any{
+		"authorAccountId":  accountID,
+		"description":      description,
+		"issueId":          issueID,
+		"startDate":        startDate,
+		"timeSpentSeconds": time,
+	}
+
+	err := t.c.doTempoRequest(
+		ctx,
+		"POST",
+		"/4/worklogs",
+		nil,
+		body,
+		nil,
+	)
+
+	return err
+}
+
+func (t tempoWorklogs) PutWorkLog(ctx context.Context, worklogID, issueID, startDate, accountID, description string, time int) error {
+	apiURL := fmt.Sprintf("/4/worklogs/%s", worklogID)
+	body := map[string]any{
+		"authorAccountId":  accountID,
+		"description":      description,
+		"issueId":          issueID,
+		"startDate":        startDate,
+		"timeSpentSeconds": time,
+	}
+
+	err := t.c.doTempoRequest(
+		ctx,
+		"PUT",
+		apiURL,
+		nil,
+		body,
+		nil,
+	)
+
+	return err
+}
+
+func (t tempoWorklogs) DeleteWorkLog(ctx context.Context, _, worklogID string) error {
+	apiURL := fmt.Sprintf("/4/worklogs/%s", worklogID)
+
+	err := t.c.doTempoRequest(
+		ctx,
+		"DELETE",
+		apiURL,
+		nil,
+		nil,
+		nil,
+	)
+
+	return err
+}
+
+// jiraWorklogs stores worklogs with Jira's native issue worklog API. The author
+// is always the authenticated user, so accountID is ignored on writes.
+type jiraWorklogs struct {
+	c *Client
+}
+
+// jiraStartedLayout is the timestamp format Jira uses for a worklog's
+// "started" field.
+const jiraStartedLayout = "2006-01-02T15:04:05.000-0700"
+
+type jiraWorklog struct {
+	ID               string      `json:"id"`
+	Author           UserField   `json:"author"`
+	Comment          *ContentDoc `json:"comment"`
+	Started          string      `json:"started"`
+	TimeSpentSeconds int         `json:"timeSpentSeconds"`
+	Updated          string      `json:"updated"`
+}
+
+type jiraWorklogsResponse struct {
+	StartAt    int           `json:"startAt"`
+	MaxResults int           `json:"maxResults"`
+	Total      int           `json:"total"`
+	Worklogs   []jiraWorklog `json:"worklogs"`
+}
+
+func (j jiraWorklogs) GetWorkLogs(ctx context.Context, issueID string) ([]Worklog, error) {
+	apiURL := j.c.apiPath("/issue/%s/worklog", issueID)
+
+	var all []Worklog
+	startAt := 0
+	for page := 0; page < 100; page++ { // safety cap
+		query := url.Values{
+			"startAt":    {strconv.Itoa(startAt)},
+			"maxResults": {"1000"},
+		}
+
+		var resp jiraWorklogsResponse
+		if err := j.c.doJiraRequest(ctx, "GET", apiURL, query, nil, &resp, http.StatusOK); err != nil {
+			return all, err
+		}
+
+		for _, w := range resp.Worklogs {
+			all = append(all, w.toWorklog())
+		}
+
+		startAt += len(resp.Worklogs)
+		if len(resp.Worklogs) == 0 || startAt >= resp.Total {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+func (w jiraWorklog) toWorklog() Worklog {
+	id, _ := strconv.Atoi(w.ID)
+	wl := Worklog{
+		ID:          id,
+		Time:        w.TimeSpentSeconds,
+		Author:      Author{AccountID: w.Author.ID},
+		Description: ADFToMarkdown(w.Comment),
+		UpdatedAt:   w.Updated,
+	}
+	if started, err := time.Parse(jiraStartedLayout, w.Started); err == nil {
+		wl.StartDate = started.Format("2006-01-02")
+	}
+	return wl
+}
+
+// worklogBody builds the create/update payload. startDate is a plain
+// "2006-01-02" date; it's logged at midnight local time.
+func (j jiraWorklogs) worklogBody(startDate, description string, seconds int) (map[string]any, error) {
+	started, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid worklog date %q: %w", startDate, err)
+	}
+
+	body := map[string]any{
+		"started":          started.Format(jiraStartedLayout),
+		"timeSpentSeconds": seconds,
+	}
+	if strings.TrimSpace(description) != "" {
+		body["comment"] = j.c.richText(MarkdownToADF(description))
+	}
+	return body, nil
+}
+
+func (j jiraWorklogs) PostWorkLog(ctx context.Context, issueID, startDate, _, description string, time int) error {
+	apiURL := j.c.apiPath("/issue/%s/worklog", issueID)
+
+	body, err := j.worklogBody(startDate, description, time)
+	if err != nil {
+		return err
+	}
+
+	return j.c.doJiraRequest(
+		ctx,
+		"POST",
+		apiURL,
+		nil,
+		body,
+		nil,
+		http.StatusCreated,
+	)
+}
+
+func (j jiraWorklogs) PutWorkLog(ctx context.Context, worklogID, issueID, startDate, _, description string, time int) error {
+	apiURL := j.c.apiPath("/issue/%s/worklog/%s", issueID, worklogID)
+
+	body, err := j.worklogBody(startDate, description, time)
+	if err != nil {
+		return err
+	}
+
+	return j.c.doJiraRequest(
+		ctx,
+		"PUT",
+		apiURL,
+		nil,
+		body,
+		nil,
+		http.StatusOK,
+	)
+}
+
+func (j jiraWorklogs) DeleteWorkLog(ctx context.Context, issueID, worklogID string) error {
+	apiURL := j.c.apiPath("/issue/%s/worklog/%s", issueID, worklogID)
+
+	return j.c.doJiraRequest(
+		ctx,
+		"DELETE",
+		apiURL,
+		nil,
+		nil,
+		nil,
+		http.StatusNoContent,
+	)
+}
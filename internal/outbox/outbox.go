@@ -0,0 +1,230 @@
+// Package outbox is a durable, ordered queue of writes (comments, worklogs,
+// transitions) that could not reach Jira, so they can be replayed once the
+// connection is back instead of being lost with the modal that typed them.
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Kind is the type of queued write.
+type Kind string
+
+const (
+	Comment    Kind = "comment"
+	Worklog    Kind = "worklog"
+	Transition Kind = "transition"
+)
+
+// Op is one queued write. Which fields are set depends on Kind.
+type Op struct {
+	ID       string    `json:"id"`
+	Kind     Kind      `json:"kind"`
+	IssueKey string    `json:"issue_key"`
+	QueuedAt time.Time `json:"queued_at"`
+
+	// Comment, and the optional comment a transition posts.
+	Body string `json:"body,omitempty"`
+
+	// Worklog
+	IssueID     string `json:"issue_id,omitempty"`
+	StartDate   string `json:"start_date,omitempty"`
+	AccountID   string `json:"account_id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Seconds     int    `json:"seconds,omitempty"`
+
+	// Transition
+	TransitionID   string         `json:"transition_id,omitempty"`
+	TransitionName string         `json:"transition_name,omitempty"`
+	Fields         map[string]any `json:"fields,omitempty"`
+	WorklogTime    string         `json:"worklog_time,omitempty"`
+
+	// Attempts and LastError record failed replays.
+	Attempts  int    `json:"attempts,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// Summary is a one-line description of op for lists and status messages.
+func (op Op) Summary() string {
+	switch op.Kind {
+	case Comment:
+		return fmt.Sprintf("comment on %s", op.IssueKey)
+	case Worklog:
+		return fmt.Sprintf("%s logged on %s", formatSeconds(op.Seconds), op.IssueKey)
+	case Transition:
+		name := op.TransitionName
+		if name == "" {
+			name = "#" + op.TransitionID
+		}
+		return fmt.Sprintf("%s → %s", op.IssueKey, name)
+	default:
+		return fmt.Sprintf("%s on %s", op.Kind, op.IssueKey)
+	}
+}
+
+// Outbox is a queue persisted to a single JSON file after every change. It is
+// safe for concurrent use, since writes fail (and get queued) from command
+// goroutines while the UI reads it.
+type Outbox struct {
+	mu   sync.Mutex
+	path string
+	ops  []Op
+}
+
+// DefaultPath returns $XDG_STATE_HOME/jira-tui/<profile>/outbox.json, falling
+// back to ~/.local/state. Queued writes are state, not cache: --no-cache must
+// never drop them.
+func DefaultPath(profile string) string {
+	if profile == "" {
+		profile = "default"
+	}
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".local", "state", "jira-tui", profile, "outbox.json")
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "jira-tui", profile, "outbox.json")
+}
+
+// Open loads the outbox at path. A missing file is an empty outbox.
+func Open(path string) (*Outbox, error) {
+	o := &Outbox{path: path}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("outbox: %w", err)
+	}
+	if err := json.Unmarshal(raw, &o.ops); err != nil {
+		return nil, fmt.Errorf("outbox: decoding %s: %w", path, err)
+	}
+	return o, nil
+}
+
+// Add appends op, assigning its ID and QueuedAt, and persists the queue.
+func (o *Outbox) Add(op Op) (Op, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op.ID = newID()
+	if op.QueuedAt.IsZero() {
+		op.QueuedAt = time.Now()
+	}
+	o.ops = append(o.ops, op)
+	if err := o.save(); err != nil {
+		o.ops = o.ops[:len(o.ops)-1]
+		return Op{}, err
+	}
+	return op, nil
+}
+
+// Remove drops the op with the given ID. Removing an unknown ID is a no-op.
+func (o *Outbox) Remove(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i, op := range o.ops {
+		if op.ID == id {
+			o.ops = append(o.ops[:i:i], o.ops[i+1:]...)
+			return o.save()
+		}
+	}
+	return nil
+}
+
+// MarkFailed records a failed replay of the op with the given ID.
+func (o *Outbox) MarkFailed(id string, cause error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.ops {
+		if o.ops[i].ID == id {
+			o.ops[i].Attempts++
+			o.ops[i].LastError = cause.Error()
+			return o.save()
+		}
+	}
+	return nil
+}
+
+// List returns a copy of the queued ops, oldest first.
+func (o *Outbox) List() []Op {
+	if o == nil {
+		return nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]Op(nil), o.ops...)
+}
+
+// Len returns the number of queued ops. A nil outbox is empty.
+func (o *Outbox) Len() int {
+	if o == nil {
+		return 0
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.ops)
+}
+
+// save writes the queue atomically. Callers hold mu.
+func (o *Outbox) save() error {
+	raw, err := json.MarshalIndent(o.ops, "", "  ")
+	if err != nil {
+		return fmt.Errorf("outbox: encoding: %w", err)
+	}
+
+	dir := filepath.Dir(o.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".outbox-*")
+	if err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("outbox: writing: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("outbox: writing: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), o.path); err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	return nil
+}
+
+// formatSeconds renders a worklog duration as "1h 30m".
+func formatSeconds(s int) string {
+	h, m := s/3600, s%3600/60
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh %dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dm", m)
+	}
+}
+
+func newID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTemp(t *testing.T) (*Outbox, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "work", "outbox.json")
+	o, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return o, path
+}
+
+func TestOutboxPersistsInOrder(t *testing.T) {
+	o, path := openTemp(t)
+
+	if _, err := o.Add(Op{Kind: Comment, IssueKey: "DEV-1", Body: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.Add(Op{Kind: Transition, IssueKey: "DEV-1", TransitionID: "31", TransitionName: "Done"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	ops := reopened.List()
+	if len(ops) != 2 {
+		t.Fatalf("got %d ops, want 2", len(ops))
+	}
+	if ops[0].Kind != Comment || ops[0].Body != "first" || ops[1].TransitionID != "31" {
+		t.Errorf("ops not persisted in order: %+v", ops)
+	}
+	if ops[0].ID == "" || ops[0].ID == ops[1].ID {
+		t.Errorf("ops need distinct ids: %q, %q", ops[0].ID, ops[1].ID)
+	}
+	if ops[0].QueuedAt.IsZero() {
+		t.Error("QueuedAt not set")
+	}
+}
+
+func TestOutboxRemoveAndMarkFailed(t *testing.T) {
+	o, path := openTemp(t)
+
+	a, _ := o.Add(Op{Kind: Comment, IssueKey: "DEV-1"})
+	b, _ := o.Add(Op{Kind: Worklog, IssueKey: "DEV-2", Seconds: 3600})
+
+	if err := o.MarkFailed(b.ID, errors.New("boom")); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Remove(a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Remove("unknown"); err != nil {
+		t.Errorf("removing an unknown id: %v", err)
+	}
+
+	reopened, _ := Open(path)
+	ops := reopened.List()
+	if len(ops) != 1 || ops[0].ID != b.ID {
+		t.Fatalf("ops = %+v, want only %s", ops, b.ID)
+	}
+	if ops[0].Attempts != 1 || ops[0].LastError != "boom" {
+		t.Errorf("failure not recorded: %+v", ops[0])
+	}
+}
+
+func TestOpenMissingAndCorrupt(t *testing.T) {
+	o, path := openTemp(t)
+	if o.Len() != 0 {
+		t.Errorf("missing file should be an empty outbox")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("a corrupt outbox must be reported, not silently emptied")
+	}
+}
+
+func TestNilOutboxIsEmpty(t *testing.T) {
+	var o *Outbox
+	if o.Len() != 0 || o.List() != nil {
+		t.Error("nil outbox should be empty")
+	}
+}
+
+func TestOpSummary(t *testing.T) {
+	tests := []struct {
+		op   Op
+		want string
+	}{
+		{Op{Kind: Comment, IssueKey: "DEV-1"}, "comment on DEV-1"},
+		{Op{Kind: Worklog, IssueKey: "DEV-1", Seconds: int((90 * time.Minute).Seconds())}, "1h 30m logged on DEV-1"},
+		{Op{Kind: Transition, IssueKey: "DEV-1", TransitionName: "Done"}, "DEV-1 → Done"},
+		{Op{Kind: Transition, IssueKey: "DEV-1", TransitionID: "31"}, "DEV-1 → #31"},
+	}
+	for _, tt := range tests {
+		if got := tt.op.Summary(); got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+	if got, want := DefaultPath("work"), filepath.Join("/tmp/state", "jira-tui", "work", "outbox.json"); got != want {
+		t.Errorf("DefaultPath = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package termimg
+
+import (
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi/kitty"
+)
+
+// KittyTransmit returns the sequence that uploads img as image id with a
+// virtual placement of cols×rows cells, which KittyBlock then shows. The
+// terminal keeps the image until it's deleted, so it's sent once.
+func KittyTransmit(id int, img image.Image, cols, rows int) (string, error) {
+	var seq strings.Builder
+	err := kitty.EncodeGraphics(&seq, img, &kitty.Options{
+		Action:           kitty.TransmitAndPut,
+		Quite:            2,
+		ID:               id,
+		Format:           kitty.PNG,
+		Transmission:     kitty.Direct,
+		Chunk:            true,
+		VirtualPlacement: true,
+		Columns:          cols,
+		Rows:             rows,
+	})
+	if err != nil {
+		return "", err
+	}
+	return seq.String(), nil
+}
+
+// KittyBlock lays out image id as rows lines of cols Unicode placeholders.
+// Each cell names its row and column with diacritics; the foreground color
+// names the image.
+func KittyBlock(id, cols, rows int) string {
+	lines := make([]string, rows)
+	for r := range rows {
+		var line strings.Builder
+		line.WriteString("\x1b[38;5;" + strconv.Itoa(id) + "m")
+		for c := range cols {
+			line.WriteRune(kitty.Placeholder)
+			line.WriteRune(kitty.Diacritic(r))
+			line.WriteRune(kitty.Diacritic(c))
+		}
+		line.WriteString("\x1b[39m")
+		lines[r] = line.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,132 @@
+package termimg
+
+import (
+	"bytes"
+	"cmp"
+	"image"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/ansi/kitty"
+	"github.com/charmbracelet/x/ansi/sixel"
+)
+
+// EncodeSixel returns img as a sixel sequence shrunk to fit cols×rows cells.
+func EncodeSixel(img image.Image, cols, rows int, cell CellSize) (string, error) {
+	b := img.Bounds()
+	scale := min(1,
+		float64(cols*cell.Width)/float64(b.Dx()),
+		float64(rows*cell.Height)/float64(b.Dy()))
+	w := max(1, int(float64(b.Dx())*scale))
+	h := max(1, int(float64(b.Dy())*scale))
+
+	var payload bytes.Buffer
+	if err := (&sixel.Encoder{}).Encode(&payload, resize(img, w, h)); err != nil {
+		return "", err
+	}
+	return ansi.SixelGraphics(0, 1, 0, payload.Bytes()), nil
+}
+
+// SixelBlock reserves rows lines of cols cells for image id. The first cell
+// of each line is a marker naming the image and row, for FindSixelBlocks;
+// StripMarkers blanks it before the frame is shown.
+func SixelBlock(id, cols, rows int) string {
+	lines := make([]string, rows)
+	pad := strings.Repeat(" ", max(0, cols-1))
+	for r := range rows {
+		lines[r] = string(kitty.Placeholder) + string(kitty.Diacritic(r)) + string(kitty.Diacritic(id)) + pad
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Placement is where, in cells from the top left of the screen, a sixel
+// image is drawn.
+type Placement struct {
+	ID   int
+	X, Y int
+}
+
+// FindSixelBlocks returns the blocks of frame that are on screen whole:
+// every one of their rows, as given by heights, is there and lined up.
+// Partly scrolled out images are left blank.
+func FindSixelBlocks(frame string, heights map[int]int) []Placement {
+	type marker struct{ id, row int }
+	at := make(map[marker]image.Point)
+	for y, line := range strings.Split(frame, "\n") {
+		for i := 0; ; {
+			j := strings.IndexRune(line[i:], kitty.Placeholder)
+			if j < 0 {
+				break
+			}
+			start := i + j
+			rest := []rune(line[start+len(string(kitty.Placeholder)):])
+			i = start + len(string(kitty.Placeholder))
+			if len(rest) < 2 {
+				continue
+			}
+			row, okRow := diacriticIndex[rest[0]]
+			id, okID := diacriticIndex[rest[1]]
+			if !okRow || !okID {
+				continue
+			}
+			at[marker{id, row}] = image.Pt(ansi.StringWidth(line[:start]), y)
+		}
+	}
+
+	var placements []Placement
+	for id, rows := range heights {
+		top, ok := at[marker{id, 0}]
+		if !ok {
+			continue
+		}
+		whole := true
+		for r := 1; r < rows && whole; r++ {
+			whole = at[marker{id, r}] == top.Add(image.Pt(0, r))
+		}
+		if whole {
+			placements = append(placements, Placement{ID: id, X: top.X, Y: top.Y})
+		}
+	}
+	// Top to bottom, so equal layouts compare equal.
+	slices.SortFunc(placements, func(a, b Placement) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	return placements
+}
+
+// StripMarkers replaces every placeholder in frame, with its diacritics, by a
+// space.
+func StripMarkers(frame string) string {
+	if !strings.ContainsRune(frame, kitty.Placeholder) {
+		return frame
+	}
+	var out strings.Builder
+	marker := false
+	for _, r := range frame {
+		if r == kitty.Placeholder {
+			out.WriteByte(' ')
+			marker = true
+			continue
+		}
+		if _, ok := diacriticIndex[r]; ok && marker {
+			continue
+		}
+		marker = false
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+// DrawSixels returns the sequence that draws the sixel image of each
+// placement at its cells, leaving the cursor where it was.
+func DrawSixels(placements []Placement, sixels func(id int) string) string {
+	var seq strings.Builder
+	seq.WriteString(ansi.SaveCursor)
+	for _, p := range placements {
+		seq.WriteString(ansi.CursorPosition(p.X+1, p.Y+1))
+		seq.WriteString(sixels(p.ID))
+	}
+	seq.WriteString(ansi.RestoreCursor)
+	return seq.String()
+}
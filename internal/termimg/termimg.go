@@ -0,0 +1,148 @@
+// Package termimg draws images in the terminal with the kitty graphics and
+// sixel protocols.
+//
+// Images are laid out as a block of ordinary cells so they scroll and clip
+// with the text around them: kitty draws its Unicode placeholders itself;
+// sixel blocks are marked with placeholders that are found in the rendered
+// frame, blanked, and drawn over once the frame is on screen.
+package termimg
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+
+	// Jira previews are PNG, JPEG or GIF.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/charmbracelet/x/ansi/kitty"
+)
+
+// Protocol is a terminal graphics protocol.
+type Protocol int
+
+const (
+	// None means images are shown by name only.
+	None Protocol = iota
+	Kitty
+	Sixel
+)
+
+func (p Protocol) String() string {
+	switch p {
+	case Kitty:
+		return "kitty"
+	case Sixel:
+		return "sixel"
+	default:
+		return "none"
+	}
+}
+
+// ParseProtocol parses a protocol name as used by the inline_images setting.
+// "off" and "none" are None.
+func ParseProtocol(s string) (Protocol, error) {
+	switch strings.ToLower(s) {
+	case "kitty":
+		return Kitty, nil
+	case "sixel":
+		return Sixel, nil
+	case "off", "none":
+		return None, nil
+	}
+	return None, fmt.Errorf("unknown image protocol %q", s)
+}
+
+// FromEnv guesses the protocol from the environment of terminals known to
+// speak kitty graphics with Unicode placeholders. Sixel support can't be told
+// from the environment; see SupportsSixel.
+func FromEnv(getenv func(string) string) Protocol {
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "",
+		strings.Contains(getenv("TERM"), "kitty"),
+		getenv("TERM_PROGRAM") == "ghostty",
+		strings.Contains(getenv("TERM"), "ghostty"):
+		return Kitty
+	}
+	return None
+}
+
+// SupportsSixel reports whether a primary device attributes reply (DA1)
+// advertises sixel graphics.
+func SupportsSixel(attrs []int) bool {
+	for _, a := range attrs {
+		if a == 4 {
+			return true
+		}
+	}
+	return false
+}
+
+// CellSize is the size of a terminal cell in pixels.
+type CellSize struct {
+	Width, Height int
+}
+
+// DefaultCellSize is assumed until the terminal reports its own.
+var DefaultCellSize = CellSize{Width: 10, Height: 20}
+
+// MaxID is the highest image ID: IDs are carried in 256-color foreground
+// colors and a single diacritic.
+const MaxID = 255
+
+// Decode reads a PNG, JPEG or GIF image.
+func Decode(r io.Reader) (image.Image, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// Fit returns how many cells an image of size px takes when shrunk, keeping
+// its aspect ratio, to at most maxCols×maxRows cells. Images are never
+// enlarged.
+func Fit(px image.Point, cell CellSize, maxCols, maxRows int) (cols, rows int) {
+	if px.X <= 0 || px.Y <= 0 || cell.Width <= 0 || cell.Height <= 0 {
+		return 0, 0
+	}
+	scale := min(1,
+		float64(maxCols*cell.Width)/float64(px.X),
+		float64(maxRows*cell.Height)/float64(px.Y))
+	w := float64(px.X) * scale
+	h := float64(px.Y) * scale
+	cols = max(1, min(maxCols, int(math.Ceil(w/float64(cell.Width)))))
+	rows = max(1, min(maxRows, int(math.Ceil(h/float64(cell.Height)))))
+	return cols, rows
+}
+
+// resize scales img to w×h pixels, nearest neighbour.
+func resize(img image.Image, w, h int) image.Image {
+	b := img.Bounds()
+	if b.Dx() == w && b.Dy() == h {
+		return img
+	}
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		sy := b.Min.Y + y*b.Dy()/h
+		for x := range w {
+			sx := b.Min.X + x*b.Dx()/w
+			out.Set(x, y, color.RGBAModel.Convert(img.At(sx, sy)))
+		}
+	}
+	return out
+}
+
+// diacriticIndex maps each row/column diacritic back to its number.
+var diacriticIndex = func() map[rune]int {
+	idx := map[rune]int{kitty.Diacritic(0): 0}
+	for i := 1; kitty.Diacritic(i) != kitty.Diacritic(0); i++ {
+		idx[kitty.Diacritic(i)] = i
+	}
+	return idx
+}()
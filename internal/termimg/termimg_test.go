@@ -0,0 +1,151 @@
+package termimg
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/ansi/kitty"
+)
+
+func testImage(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	return img
+}
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want Protocol
+	}{
+		{map[string]string{"TERM": "xterm-kitty"}, Kitty},
+		{map[string]string{"KITTY_WINDOW_ID": "1", "TERM": "xterm-256color"}, Kitty},
+		{map[string]string{"TERM_PROGRAM": "ghostty"}, Kitty},
+		{map[string]string{"TERM": "xterm-256color"}, None},
+		{map[string]string{"TERM": "foot"}, None},
+	}
+	for _, tt := range tests {
+		if got := FromEnv(func(k string) string { return tt.env[k] }); got != tt.want {
+			t.Errorf("FromEnv(%v) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+	if !SupportsSixel([]int{62, 4, 22}) || SupportsSixel([]int{62, 22}) {
+		t.Error("SupportsSixel should look for attribute 4")
+	}
+}
+
+func TestParseProtocol(t *testing.T) {
+	for s, want := range map[string]Protocol{"kitty": Kitty, "SIXEL": Sixel, "off": None} {
+		if got, err := ParseProtocol(s); err != nil || got != want {
+			t.Errorf("ParseProtocol(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := ParseProtocol("iterm"); err == nil {
+		t.Error("unknown protocols should be rejected")
+	}
+}
+
+func TestFit(t *testing.T) {
+	cell := CellSize{Width: 10, Height: 20}
+	tests := []struct {
+		px         image.Point
+		cols, rows int
+	}{
+		{image.Pt(100, 40), 10, 2},     // small images keep their size
+		{image.Pt(1000, 400), 40, 8},   // wide: limited by columns
+		{image.Pt(200, 2000), 2, 10},   // tall: limited by rows
+		{image.Pt(3, 3), 1, 1},         // never zero
+		{image.Pt(0, 100), 0, 0},       // nothing to draw
+		{image.Pt(1001, 401), 40, 9},   // partial cells round up
+		{image.Pt(4000, 4000), 20, 10}, // square: limited by rows
+	}
+	for _, tt := range tests {
+		cols, rows := Fit(tt.px, cell, 40, 10)
+		if cols != tt.cols || rows != tt.rows {
+			t.Errorf("Fit(%v) = %dx%d, want %dx%d", tt.px, cols, rows, tt.cols, tt.rows)
+		}
+	}
+}
+
+func TestKittyTransmitAndBlock(t *testing.T) {
+	seq, err := KittyTransmit(7, testImage(30, 20), 3, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(seq, "\x1b_G") {
+		t.Fatalf("not a kitty graphics sequence: %q", seq[:min(20, len(seq))])
+	}
+	for _, opt := range []string{"a=T", "i=7", "f=100", "U=1", "c=3", "r=1", "q=2"} {
+		if !strings.Contains(seq, opt) {
+			t.Errorf("transmit sequence is missing %s", opt)
+		}
+	}
+
+	block := KittyBlock(7, 3, 2)
+	lines := strings.Split(block, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("block has %d lines, want 2", len(lines))
+	}
+	for r, line := range lines {
+		if w := ansi.StringWidth(line); w != 3 {
+			t.Errorf("line %d is %d cells wide, want 3", r, w)
+		}
+		if !strings.HasPrefix(line, "\x1b[38;5;7m") {
+			t.Errorf("line %d doesn't carry the image ID in its color: %q", r, line)
+		}
+		cell := string(kitty.Placeholder) + string(kitty.Diacritic(r)) + string(kitty.Diacritic(2))
+		if !strings.Contains(line, cell) {
+			t.Errorf("line %d is missing its last cell", r)
+		}
+	}
+}
+
+func TestSixelBlocks(t *testing.T) {
+	block := strings.Split(SixelBlock(3, 4, 2), "\n")
+	frame := strings.Join([]string{
+		"header",
+		"│ \x1b[1m" + block[0] + "\x1b[m │",
+		"│ " + block[1] + " │",
+		"│ " + strings.Split(SixelBlock(5, 4, 3), "\n")[0] + " │", // cut off
+	}, "\n")
+
+	got := FindSixelBlocks(frame, map[int]int{3: 2, 5: 3})
+	if want := []Placement{{ID: 3, X: 2, Y: 1}}; !slices.Equal(got, want) {
+		t.Errorf("FindSixelBlocks = %+v, want %+v", got, want)
+	}
+
+	stripped := StripMarkers(frame)
+	if strings.ContainsRune(stripped, kitty.Placeholder) || ansi.StringWidth(strings.Split(stripped, "\n")[1]) != ansi.StringWidth(strings.Split(frame, "\n")[1]) {
+		t.Errorf("StripMarkers should blank markers in place: %q", stripped)
+	}
+	if strings.ContainsRune(stripped, kitty.Diacritic(0)) {
+		t.Error("StripMarkers left diacritics behind")
+	}
+
+	seq := DrawSixels(got, func(id int) string { return "<img>" })
+	if seq != ansi.SaveCursor+ansi.CursorPosition(3, 2)+"<img>"+ansi.RestoreCursor {
+		t.Errorf("DrawSixels = %q", seq)
+	}
+}
+
+func TestEncodeSixel(t *testing.T) {
+	seq, err := EncodeSixel(testImage(100, 50), 4, 2, CellSize{Width: 10, Height: 20})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(seq, "\x1bP0;1q") || !strings.HasSuffix(seq, "\x1b\\") {
+		t.Errorf("not a sixel sequence: %q…", seq[:min(20, len(seq))])
+	}
+	// Shrunk to 40x20 pixels to fit 4x2 cells.
+	if !strings.Contains(seq, `"1;1;40;20`) {
+		t.Errorf("sixel raster should be 40x20: %q…", seq[:min(40, len(seq))])
+	}
+}
@@ -13,6 +13,9 @@ type ColumnWidths struct {
 	Cursor      int
 	Empty       int
 	TimeSpent   int
+	// Extra is the width of columns appended after the built-in ones
+	// (custom fields), gaps included.
+	Extra int
 }
 
 func CalculateColumnWidths(terminalWidth int) ColumnWidths {
@@ -63,13 +66,24 @@ func CalculateColumnWidths(terminalWidth int) ColumnWidths {
 }
 
 // TotalWidth is the full rendered row width: the cursor prefix, all ten
-// columns, and the nine single-space gaps between them.
+// columns, the nine single-space gaps between them and any extra columns.
 func (c ColumnWidths) TotalWidth() int {
 	const gaps = 9
 	return c.Cursor +
 		c.Type + c.Key + c.Status + c.Priority + c.Summary +
 		c.Reporter + c.Assignee + c.CreatedDate + c.DueDate + c.TimeSpent +
-		c.Empty*gaps
+		c.Empty*gaps + c.Extra
+}
+
+// minSummaryWithExtra is how narrow extra columns may squeeze the summary.
+const minSummaryWithExtra = 20
+
+// WithExtra makes room for extra columns of the given total width by taking
+// it out of the summary, down to minSummaryWithExtra.
+func (c ColumnWidths) WithExtra(width int) ColumnWidths {
+	c.Extra = width
+	c.Summary = max(c.Summary-width, minSummaryWithExtra)
+	return c
 }
 
 func (c ColumnWidths) RenderKey(text string) string {
@@ -60,3 +60,14 @@ func TestTotalWidthSumsComponents(t *testing.T) {
 		t.Errorf("TotalWidth() = %d, want %d", got, want)
 	}
 }
+
+func TestWithExtraTakesFromSummary(t *testing.T) {
+	cw := CalculateColumnWidths(200)
+	wide := cw.WithExtra(13)
+	if wide.Summary != cw.Summary-13 || wide.TotalWidth() != cw.TotalWidth() {
+		t.Errorf("WithExtra(13): summary %d -> %d, total %d -> %d", cw.Summary, wide.Summary, cw.TotalWidth(), wide.TotalWidth())
+	}
+	if got := cw.WithExtra(500).Summary; got != minSummaryWithExtra {
+		t.Errorf("summary squeezed to %d, want the %d minimum", got, minSummaryWithExtra)
+	}
+}
@@ -47,6 +47,19 @@ func RepeatChar(char string, count int) string {
 	return result.String()
 }
 
+// RenderChips renders values as chips separated by a space, or a dim dash
+// when there are none.
+func RenderChips(values []string, style lipgloss.Style) string {
+	if len(values) == 0 {
+		return DimTextStyle.Render("—")
+	}
+	chips := make([]string, len(values))
+	for i, v := range values {
+		chips[i] = style.Render(v)
+	}
+	return strings.Join(chips, " ")
+}
+
 func RenderFieldStyled(label, value string, width int) string {
 	content := DetailLabelStyle.Render(label+": ") + DetailValueStyle.Render(value)
 
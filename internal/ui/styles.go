@@ -2,6 +2,8 @@
 package ui
 
 import (
+	"image/color"
+
 	"charm.land/lipgloss/v2"
 )
 
@@ -68,6 +70,15 @@ var (
 	ThemeComment = CatOverlay0
 	ThemeMention = CatSapphire
 	ThemeLink    = CatBlue
+
+	// Syntax highlighting
+	ThemeSyntaxKeyword  = CatMauve
+	ThemeSyntaxType     = CatYellow
+	ThemeSyntaxFunction = CatBlue
+	ThemeSyntaxString   = CatGreen
+	ThemeSyntaxNumber   = CatPeach
+	ThemeSyntaxComment  = CatOverlay0
+	ThemeSyntaxOperator = CatSky
 )
 
 // ============================================================================
@@ -113,6 +124,8 @@ var (
 	IconTime       = ""
 	IconSeparator  = "●"
 	IconEnter      = "↳"
+	IconPending    = "⇡"
+	IconSprint     = "󰃰"
 
 	// Error
 	IconError = ""
@@ -349,6 +362,22 @@ var (
 				Foreground(ThemeFgMuted).
 				PaddingLeft(4).
 				Bold(true)
+
+	// Chips for labels, components and fix versions.
+	LabelChipStyle = lipgloss.NewStyle().
+			Foreground(ThemeInfo).
+			Background(ThemeBgLight).
+			Padding(0, 1)
+
+	ComponentChipStyle = lipgloss.NewStyle().
+				Foreground(ThemeAccentAlt).
+				Background(ThemeBgLight).
+				Padding(0, 1)
+
+	VersionChipStyle = lipgloss.NewStyle().
+				Foreground(ThemeSuccess).
+				Background(ThemeBgLight).
+				Padding(0, 1)
 )
 
 // ============================================================================
@@ -443,6 +472,13 @@ var (
 				Foreground(ThemeFgDim).
 				Italic(true)
 
+	InfoPanelPendingStyle = lipgloss.NewStyle().
+				Foreground(ThemeWarning).
+				Bold(true)
+
+	PendingMarkerStyle = lipgloss.NewStyle().
+				Foreground(ThemeWarning)
+
 	// Status count icons
 	IconInfoInProgress = lipgloss.NewStyle().Foreground(ThemeStatusInProgress).Render("●")
 	IconInfoToDo       = lipgloss.NewStyle().Foreground(ThemeStatusToDo).Render("○")
@@ -454,6 +490,8 @@ var (
 // ============================================================================
 
 var (
+	CodeBlockBg = lipgloss.Color("236")
+
 	BoldStyle       = lipgloss.NewStyle().Bold(true)
 	ItalicStyle     = lipgloss.NewStyle().Italic(true)
 	InlineCodeStyle = lipgloss.NewStyle().
@@ -465,13 +503,15 @@ var (
 			Foreground(lipgloss.Color("39"))
 
 	CodeBlockStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("236")).
+			Background(CodeBlockBg).
 			Padding(0, 1).
 			MarginTop(1).
 			MarginBottom(1)
 	LinkStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("39")).
 			Underline(true)
+	SelectedLinkStyle = lipgloss.NewStyle().
+				Reverse(true)
 )
 
 // ============================================================================
@@ -490,3 +530,80 @@ var (
 	TabInactiveStyle = lipgloss.NewStyle().
 				Foreground(ThemeFgDim)
 )
+
+// ============================================================================
+// ADF STYLES
+// ============================================================================
+
+var (
+	StrikeStyle    = lipgloss.NewStyle().Strikethrough(true)
+	UnderlineStyle = lipgloss.NewStyle().Underline(true)
+
+	DateStyle = lipgloss.NewStyle().
+			Foreground(ThemeAccent).
+			Background(ThemeBgLight)
+
+	LozengeStyle = lipgloss.NewStyle().
+			Foreground(ThemeBgDark).
+			Bold(true).
+			Padding(0, 1)
+
+	BlockquoteBarStyle = lipgloss.NewStyle().
+				Foreground(ThemeBorder)
+
+	ExpandTitleStyle = lipgloss.NewStyle().
+				Foreground(ThemeAccentAlt).
+				Bold(true)
+
+	TaskDoneStyle = lipgloss.NewStyle().
+			Foreground(ThemeFgDim)
+
+	DecisionMarkerStyle = lipgloss.NewStyle().
+				Foreground(ThemeSuccess)
+
+	// Panel borders by ADF panelType
+	AdfPanelColors = map[string]color.Color{
+		"info":    ThemeInfo,
+		"note":    ThemeAccentAlt,
+		"tip":     ThemeSuccess,
+		"success": ThemeSuccess,
+		"warning": ThemeWarning,
+		"error":   ThemeError,
+	}
+
+	AdfPanelIcons = map[string]string{
+		"info":    "ℹ",
+		"note":    "✎",
+		"tip":     "★",
+		"success": "✔",
+		"warning": "⚠",
+		"error":   "✖",
+	}
+
+	// Status lozenge backgrounds by ADF color
+	AdfLozengeColors = map[string]color.Color{
+		"neutral": CatOverlay1,
+		"purple":  CatMauve,
+		"blue":    CatBlue,
+		"red":     CatRed,
+		"yellow":  CatYellow,
+		"green":   CatGreen,
+	}
+)
+
+// ============================================================================
+// SYNTAX STYLES
+// ============================================================================
+
+// Token styles carry the code block background so it survives the reset
+// after each token.
+var (
+	SyntaxTextStyle     = lipgloss.NewStyle().Background(CodeBlockBg)
+	SyntaxKeywordStyle  = SyntaxTextStyle.Foreground(ThemeSyntaxKeyword)
+	SyntaxTypeStyle     = SyntaxTextStyle.Foreground(ThemeSyntaxType)
+	SyntaxFunctionStyle = SyntaxTextStyle.Foreground(ThemeSyntaxFunction)
+	SyntaxStringStyle   = SyntaxTextStyle.Foreground(ThemeSyntaxString)
+	SyntaxNumberStyle   = SyntaxTextStyle.Foreground(ThemeSyntaxNumber)
+	SyntaxCommentStyle  = SyntaxTextStyle.Foreground(ThemeSyntaxComment).Italic(true)
+	SyntaxOperatorStyle = SyntaxTextStyle.Foreground(ThemeSyntaxOperator)
+)